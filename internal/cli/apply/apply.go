@@ -3,6 +3,7 @@ package apply
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -10,9 +11,12 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/aryankumar/fleet/internal/cli/rollout"
+	"github.com/aryankumar/fleet/internal/cli/target"
 	"github.com/aryankumar/fleet/internal/cluster"
 	"github.com/aryankumar/fleet/internal/config"
 	"github.com/aryankumar/fleet/internal/executor"
+	"github.com/aryankumar/fleet/internal/history"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,6 +46,7 @@ func NewApplyCmd() *cobra.Command {
 	var dryRun bool
 	var namespace string
 	var skipConfirmation bool
+	var rolloutOpts rollout.Options
 
 	cmd := &cobra.Command{
 		Use:   "apply",
@@ -62,6 +67,12 @@ Resources are applied using server-side apply for better conflict handling.`,
   # Apply to specific clusters only
   fleet apply -f deployment.yaml --clusters prod-east,prod-west
 
+  # Apply to clusters selected by fleet config labels
+  fleet apply -f deployment.yaml --cluster-selector 'env=prod,region in (us-east-1,us-west-2)'
+
+  # Apply to a named group of clusters from the fleet config
+  fleet apply -f deployment.yaml --group canary
+
   # Dry-run to preview changes without applying
   fleet apply -f deployment.yaml --dry-run
 
@@ -69,14 +80,24 @@ Resources are applied using server-side apply for better conflict handling.`,
   fleet apply -f deployment.yaml -n production
 
   # Skip confirmation prompt
-  fleet apply -f deployment.yaml -y`,
+  fleet apply -f deployment.yaml -y
+
+  # Roll out progressively: a canary cluster, then 25% of clusters per wave,
+  # aborting once more than one cluster has failed
+  fleet apply -f deployment.yaml --strategy waves --wave-size 25% --max-failures 1
+
+  # Use the staging clusters as canaries and wait 2 minutes between waves
+  fleet apply -f deployment.yaml --strategy waves --canary 'staging-*' --wave-pause 2m
+
+  # Stop at the first cluster that fails and skip the rest
+  fleet apply -f deployment.yaml --fail-fast`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if filename == "" {
 				return fmt.Errorf("filename is required (-f flag)")
 			}
 
 			ctx := cmd.Context()
-			return runApply(ctx, filename, recursive, dryRun, namespace, skipConfirmation)
+			return runApply(ctx, filename, recursive, dryRun, namespace, skipConfirmation, &rolloutOpts)
 		},
 	}
 
@@ -85,13 +106,14 @@ Resources are applied using server-side apply for better conflict handling.`,
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview changes without applying")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Override namespace for resources")
 	cmd.Flags().BoolVarP(&skipConfirmation, "yes", "y", false, "Skip confirmation prompt")
+	rollout.AddFlags(cmd, &rolloutOpts)
 
 	cmd.MarkFlagRequired("filename")
 
 	return cmd
 }
 
-func runApply(ctx context.Context, filename string, recursive bool, dryRun bool, overrideNamespace string, skipConfirmation bool) error {
+func runApply(ctx context.Context, filename string, recursive bool, dryRun bool, overrideNamespace string, skipConfirmation bool, rolloutOpts *rollout.Options) error {
 	logger := slog.Default()
 
 	logger.Debug("applying manifests",
@@ -120,6 +142,13 @@ func runApply(ctx context.Context, filename string, recursive bool, dryRun bool,
 		logger.Debug("overridden namespace for all resources", "namespace", overrideNamespace)
 	}
 
+	// Load the effective fleet settings (flag > env > config file > default)
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	defaults := configManager.GetConfig().Defaults
+
 	// Load kubeconfig and create cluster manager
 	kubeconfigPath := viper.GetString("kubeconfig")
 	loader := config.NewKubeconfigLoader(kubeconfigPath)
@@ -127,16 +156,9 @@ func runApply(ctx context.Context, filename string, recursive bool, dryRun bool,
 	mgr := cluster.NewManager(loader, logger)
 	defer mgr.Close()
 
-	// Determine which clusters to connect to
-	targetClusters := viper.GetStringSlice("clusters")
-	if len(targetClusters) == 0 {
-		err = mgr.ConnectAll(ctx)
-	} else {
-		err = mgr.Connect(ctx, targetClusters)
-	}
-
-	if err != nil {
-		logger.Warn("some cluster connections failed", "error", err)
+	// Connect to the targeted clusters
+	if err := target.Connect(ctx, mgr, logger); err != nil {
+		return err
 	}
 
 	if mgr.Count() == 0 {
@@ -145,6 +167,12 @@ func runApply(ctx context.Context, filename string, recursive bool, dryRun bool,
 
 	logger.Info("connected to clusters", "count", mgr.Count())
 
+	// Plan the rollout waves, if any, before asking for confirmation
+	plan, err := rolloutOpts.Plan(ctx, mgr)
+	if err != nil {
+		return err
+	}
+
 	// Show preview and ask for confirmation unless skipped
 	if !skipConfirmation && !dryRun {
 		if !confirmApply(manifests, mgr.GetClientNames()) {
@@ -154,41 +182,48 @@ func runApply(ctx context.Context, filename string, recursive bool, dryRun bool,
 	}
 
 	// Create executor pool
-	parallelism := viper.GetInt("parallel")
-	pool := executor.NewPool(parallelism, logger)
+	parallelism := defaults.Parallel
+	pool := executor.NewPool[[]ApplyResult](parallelism, logger)
+	if defaults.AdaptiveParallel {
+		pool.SetAdaptive(&executor.AdaptiveOptions{})
+	}
+	pool.SetFailFast(rolloutOpts.FailFast)
+	retry := executor.NewRetryPolicy(defaults.Retries)
 
 	// Submit tasks for each cluster
 	clients := mgr.GetAllClients()
 	for _, client := range clients {
-		clusterName := client.Name
-		restConfig := client.RestConfig
-
-		task := executor.Task{
-			ClusterName: clusterName,
-			Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
-				return applyManifests(ctx, clusterName, restConfig, manifests, dryRun, logger)
-			},
+		task := executor.Task[[]ApplyResult]{
+			Client:  client,
+			Timeout: client.EffectiveTimeout(defaults.Timeout),
+			Retry:   retry,
+			Execute: cluster.Guard(mgr, func(ctx context.Context, c *cluster.Client) ([]ApplyResult, error) {
+				return applyManifests(ctx, c.Name, c.RestConfig, manifests, dryRun, logger)
+			}),
 		}
 
 		if err := pool.Submit(task); err != nil {
-			logger.Error("failed to submit task", "cluster", clusterName, "error", err)
+			logger.Error("failed to submit task", "cluster", client.Name, "error", err)
 			continue
 		}
 	}
 
-	// Execute tasks with timeout
-	timeout := viper.GetDuration("timeout")
-	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	// Each task's timeout runs from when it starts; --deadline bounds the run
+	execCtx, cancel := executor.WithRunDeadline(ctx, defaults.Deadline)
 	defer cancel()
 
 	fmt.Printf("\n%s manifests to %d cluster(s)...\n\n",
 		map[bool]string{true: "Dry-running", false: "Applying"}[dryRun],
 		mgr.Count())
+	fmt.Print(plan.Describe())
 
-	results := pool.Execute(execCtx)
-
-	// Format and display results
-	return formatApplyResults(results, dryRun)
+	// Print each cluster's results as soon as it finishes
+	results := history.Tee(ctx, rollout.Execute(execCtx, pool, plan), historyResource)
+	err = formatApplyResults(results, dryRun, plan.Progress())
+	if report, ok := pool.ConcurrencyReport(); ok {
+		fmt.Printf("Concurrency: %s\n", report)
+	}
+	return err
 }
 
 // parseManifests parses YAML/JSON manifests from a file or directory
@@ -378,7 +413,24 @@ func applyManifests(
 			"action", result.Action)
 	}
 
-	return results, nil
+	return results, failedResources(results)
+}
+
+// failedResources returns an error if any resource failed to apply, so that
+// the cluster counts as failed, e.g. towards --max-failures
+// The error wraps every resource's error, so that retries and the circuit
+// breaker can tell an unreachable cluster from a rejected manifest.
+func failedResources(results []ApplyResult) error {
+	var errs []error
+	for _, result := range results {
+		if result.Error != nil {
+			errs = append(errs, result.Error)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d resource(s) failed to apply: %w", len(errs), len(results), errors.Join(errs...))
 }
 
 // confirmApply prompts the user for confirmation before applying
@@ -417,40 +469,61 @@ func formatResourceName(obj *unstructured.Unstructured) string {
 	return fmt.Sprintf("%s/%s", kind, name)
 }
 
-// formatApplyResults formats and displays apply results
-func formatApplyResults(results []executor.Result, dryRun bool) error {
+// historyResource converts a apply result for the run history
+func historyResource(r ApplyResult) history.Resource {
+	res := history.Resource{
+		Resource:  r.Resource,
+		Kind:      r.Kind,
+		Name:      r.Name,
+		Namespace: r.Namespace,
+		Action:    r.Action,
+	}
+	if r.Error != nil {
+		res.Error = r.Error.Error()
+	}
+	return res
+}
+
+// formatApplyResults displays apply results as they arrive, followed by any
+// cluster errors and a summary once every cluster has finished
+// progress adds wave headers and reports clusters skipped by an aborted
+// rollout, fail-fast or an open circuit.
+func formatApplyResults(results <-chan executor.Result[[]ApplyResult], dryRun bool, progress *rollout.Progress) error {
 	var allResults []ApplyResult
 	var errors []string
 
 	successCount := 0
 	failureCount := 0
 
-	for _, result := range results {
-		if result.Error != nil {
+	for result := range results {
+		if progress.Observe(os.Stdout, result.ClusterName, result.Wave, result.Status, result.Error) {
+			continue
+		}
+
+		// Resource failures come with their results and are listed below
+		if result.Error != nil && len(result.Data) == 0 {
 			errors = append(errors, fmt.Sprintf("%s: %v", result.ClusterName, result.Error))
 			failureCount++
 			continue
 		}
 
-		if applyResults, ok := result.Data.([]ApplyResult); ok {
-			for _, ar := range applyResults {
-				if ar.Error != nil {
-					fmt.Printf("  %s [%s] %s: %v\n",
-						getStatusIcon(false),
-						ar.Cluster,
-						ar.Resource,
-						ar.Error)
-					failureCount++
-				} else {
-					fmt.Printf("  %s [%s] %s %s\n",
-						getStatusIcon(true),
-						ar.Cluster,
-						ar.Resource,
-						ar.Action)
-					successCount++
-				}
-				allResults = append(allResults, ar)
+		for _, ar := range result.Data {
+			if ar.Error != nil {
+				fmt.Printf("  %s [%s] %s: %v\n",
+					getStatusIcon(false),
+					ar.Cluster,
+					ar.Resource,
+					ar.Error)
+				failureCount++
+			} else {
+				fmt.Printf("  %s [%s] %s %s\n",
+					getStatusIcon(true),
+					ar.Cluster,
+					ar.Resource,
+					ar.Action)
+				successCount++
 			}
+			allResults = append(allResults, ar)
 		}
 	}
 
@@ -471,6 +544,10 @@ func formatApplyResults(results []executor.Result, dryRun bool) error {
 		fmt.Printf("Apply completed: %d succeeded, %d failed\n", successCount, failureCount)
 	}
 
+	if err := progress.Finish(os.Stdout); err != nil {
+		return err
+	}
+
 	if failureCount > 0 {
 		return fmt.Errorf("some resources failed to apply")
 	}
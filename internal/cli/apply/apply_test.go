@@ -1,11 +1,18 @@
 package apply
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/aryankumar/fleet/internal/cli/rollout"
+	"github.com/aryankumar/fleet/internal/cluster"
+	"github.com/aryankumar/fleet/internal/executor"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -664,3 +671,59 @@ func TestGVRPluralization(t *testing.T) {
 		})
 	}
 }
+
+func TestFailedResources_RetriedAndTripsBreaker(t *testing.T) {
+	mgr := cluster.NewManager(nil, nil)
+	mgr.SetCircuitBreaker(cluster.NewCircuitBreaker(cluster.BreakerOptions{Threshold: 2}, nil))
+
+	calls := 0
+	pool := executor.NewPool[[]ApplyResult](1, nil)
+	pool.Submit(executor.Task[[]ApplyResult]{
+		Client: &cluster.Client{Name: "prod"},
+		Retry:  executor.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond},
+		Execute: cluster.Guard(mgr, func(ctx context.Context, c *cluster.Client) ([]ApplyResult, error) {
+			calls++
+			results := []ApplyResult{
+				{Cluster: c.Name, Resource: "deployment/web", Error: apierrors.NewServiceUnavailable("apiserver down")},
+				{Cluster: c.Name, Resource: "configmap/web", Action: "configured"},
+			}
+			return results, failedResources(results)
+		}),
+	})
+
+	result := pool.Execute(context.Background())[0]
+	if !apierrors.IsServiceUnavailable(result.Error) || !strings.Contains(result.Error.Error(), "1 of 2 resource(s) failed") {
+		t.Errorf("error = %v, want the resource's 503 wrapped", result.Error)
+	}
+
+	// The second failure opens the circuit, which turns the third attempt away
+	if calls != 2 || result.Attempts != 2 {
+		t.Errorf("ran %d times over %d attempts, want the 503 retried once", calls, result.Attempts)
+	}
+	if err := mgr.CircuitBreaker().Allow("prod"); !errors.Is(err, cluster.ErrCircuitOpen) {
+		t.Errorf("Allow() = %v, want the circuit open", err)
+	}
+}
+
+func TestFormatApplyResults_OpenCircuit(t *testing.T) {
+	mgr := cluster.NewManager(nil, nil)
+	breaker := cluster.NewCircuitBreaker(cluster.BreakerOptions{Threshold: 2}, nil)
+	breaker.Record("prod", context.DeadlineExceeded)
+	breaker.Record("prod", context.DeadlineExceeded)
+	mgr.SetCircuitBreaker(breaker)
+
+	pool := executor.NewPool[[]ApplyResult](2, nil)
+	for _, name := range []string{"staging", "prod"} {
+		pool.Submit(executor.Task[[]ApplyResult]{
+			Client: &cluster.Client{Name: name},
+			Execute: cluster.Guard(mgr, func(ctx context.Context, c *cluster.Client) ([]ApplyResult, error) {
+				return []ApplyResult{{Cluster: c.Name, Resource: "deployment/web", Action: "configured"}}, nil
+			}),
+		})
+	}
+
+	// prod is skipped, not failed, so the apply still succeeds
+	if err := formatApplyResults(pool.ExecuteStream(context.Background()), false, (*rollout.Plan)(nil).Progress()); err != nil {
+		t.Errorf("formatApplyResults() = %v, want no error for a cluster with an open circuit", err)
+	}
+}
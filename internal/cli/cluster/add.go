@@ -1,26 +1,98 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"os"
+	"slices"
 
+	"github.com/aryankumar/fleet/internal/config"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // newAddCmd creates the cluster add command
-// This is a placeholder for Phase 2 - full implementation in later phases
 func newAddCmd() *cobra.Command {
+	var (
+		contextName string
+		alias       string
+		labels      map[string]string
+		enabled     bool
+		overwrite   bool
+	)
+
 	cmd := &cobra.Command{
 		Use:   "add NAME",
 		Short: "Add a new cluster to fleet configuration",
 		Long: `Add a new cluster to fleet configuration.
 
 This command allows you to add cluster-specific configuration such as
-aliases, labels, and enable/disable status.`,
+aliases, labels, and enable/disable status. The kubeconfig context defaults
+to NAME and should match a context in your kubeconfig.`,
+		Example: `  # Add a cluster whose name matches its kubeconfig context
+  fleet cluster add prod-east --alias production --label env=prod --label region=us-east-1
+
+  # Add an EKS cluster under a short name
+  fleet cluster add prod-east --context arn:aws:eks:us-east-1:123456789012:cluster/prod-east
+
+  # Add a cluster but keep it out of fleet-wide operations
+  fleet cluster add legacy --enabled=false
+
+  # Replace an existing entry
+  fleet cluster add prod-east --alias prod --overwrite`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fmt.Errorf("not implemented yet - coming in later phases")
+			return runAdd(cmd.Context(), args[0], contextName, alias, labels, enabled, overwrite)
 		},
 	}
 
+	cmd.Flags().StringVar(&contextName, "context", "", "kubeconfig context for the cluster (default is NAME)")
+	cmd.Flags().StringVar(&alias, "alias", "", "friendly name for the cluster")
+	cmd.Flags().StringToStringVar(&labels, "label", nil, "label to attach to the cluster (key=value, repeatable)")
+	cmd.Flags().BoolVar(&enabled, "enabled", true, "include the cluster in fleet operations")
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "replace the cluster if it already exists")
+
 	return cmd
 }
+
+func runAdd(ctx context.Context, name, contextName, alias string, labels map[string]string, enabled, overwrite bool) error {
+	logger := slog.Default()
+
+	if contextName == "" {
+		contextName = name
+	}
+
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load fleet config: %w", err)
+	}
+
+	if _, exists := configManager.GetClusterConfig(name); exists && !overwrite {
+		return fmt.Errorf("cluster %q already exists in fleet config (use --overwrite to replace it)", name)
+	}
+
+	// Warn rather than fail so clusters can be configured ahead of kubeconfig changes
+	loader := config.NewKubeconfigLoader(viper.GetString("kubeconfig"))
+	if contexts, err := loader.GetContexts(); err != nil {
+		logger.Warn("could not verify context against kubeconfig", "error", err)
+	} else if !slices.Contains(contexts, contextName) {
+		logger.Warn("context not found in kubeconfig", "context", contextName)
+	}
+
+	err = configManager.SetClusterConfig(name, config.ClusterConfig{
+		Context: contextName,
+		Alias:   alias,
+		Labels:  labels,
+		Enabled: enabled,
+	})
+	if err != nil {
+		return err
+	}
+	if err := configManager.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Cluster %q added to %s\n", name, configManager.ConfigPath())
+	return nil
+}
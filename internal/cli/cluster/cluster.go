@@ -12,7 +12,8 @@ func NewClusterCmd() *cobra.Command {
 		Long: `Manage Kubernetes clusters in your kubeconfig.
 
 This command provides subcommands for listing, adding, removing,
-and switching between Kubernetes cluster contexts.`,
+switching between and exporting Kubernetes cluster contexts, and for
+checking cluster health.`,
 	}
 
 	// Add subcommands
@@ -20,6 +21,8 @@ and switching between Kubernetes cluster contexts.`,
 	cmd.AddCommand(newAddCmd())
 	cmd.AddCommand(newRemoveCmd())
 	cmd.AddCommand(newSwitchCmd())
+	cmd.AddCommand(newExportCmd())
+	cmd.AddCommand(newHealthCmd())
 
 	return cmd
 }
@@ -0,0 +1,137 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/aryankumar/fleet/internal/cli/target"
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// newExportCmd creates the cluster export command
+func newExportCmd() *cobra.Command {
+	var (
+		outputFile string
+		flatten    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a kubeconfig for a subset of the fleet",
+		Long: `Export a standalone kubeconfig for a subset of the fleet.
+
+The targeted contexts are taken from the merged kubeconfig (and from the
+dedicated kubeconfig of fleet config clusters that have one), together with
+only the clusters and users they reference. Select clusters with --clusters,
+--exclude-clusters, --group or --cluster-selector; with none of them every
+enabled cluster is exported.
+
+Certificate and key files are referenced by path unless --flatten is given,
+which inlines them so the file works on another machine. Token files and exec
+credential plugins are copied as-is.`,
+		Example: `  # Kubeconfig for a CI job that deploys to staging
+  fleet cluster export --clusters staging --output-file ci-kubeconfig --flatten
+
+  # Everything labelled for a contractor, printed to stdout
+  fleet cluster export --cluster-selector team=payments
+
+  # A named group
+  fleet cluster export --group prod --output-file prod.kubeconfig`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(cmd.Context(), os.Stdout, outputFile, flatten)
+		},
+	}
+
+	cmd.Flags().StringVar(&outputFile, "output-file", "-", "file to write the kubeconfig to (- for stdout)")
+	cmd.Flags().BoolVar(&flatten, "flatten", false, "inline certificate and key files into the kubeconfig")
+
+	return cmd
+}
+
+func runExport(ctx context.Context, w io.Writer, outputFile string, flatten bool) error {
+	logger := slog.Default()
+
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load fleet config: %w", err)
+	}
+	fleetConfig := configManager.GetConfig()
+
+	loader := config.NewKubeconfigLoader(viper.GetString("kubeconfig"))
+	contexts, err := loader.GetContexts()
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	for _, contextName := range fleetConfig.DedicatedKubeconfigContexts() {
+		if !slices.Contains(contexts, contextName) {
+			contexts = append(contexts, contextName)
+		}
+	}
+	sort.Strings(contexts)
+
+	selected, err := target.Resolve(configManager, contexts, logger)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		selected = contexts
+		if !viper.GetBool("include-disabled") {
+			selected, _ = fleetConfig.EnabledContexts(contexts)
+		}
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("no clusters to export")
+	}
+
+	exported, err := loader.ExportContexts(selected, fleetConfig, flatten)
+	if err != nil {
+		return err
+	}
+
+	if outputFile == "" || outputFile == "-" {
+		data, err := clientcmd.Write(*exported)
+		if err != nil {
+			return fmt.Errorf("failed to encode kubeconfig: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	if err := checkNotSourceKubeconfig(outputFile, loader.GetPaths()); err != nil {
+		return err
+	}
+
+	// WriteToFile creates the file with 0600 permissions, as it holds credentials
+	if err := clientcmd.WriteToFile(*exported, outputFile); err != nil {
+		return fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d context(s) to %s\n", len(exported.Contexts), outputFile)
+	return nil
+}
+
+// checkNotSourceKubeconfig refuses to overwrite one of the kubeconfig files
+// the export was read from, which would discard every other context
+func checkNotSourceKubeconfig(outputFile string, sources []string) error {
+	out, err := filepath.Abs(outputFile)
+	if err != nil {
+		return fmt.Errorf("invalid output file: %w", err)
+	}
+
+	for _, source := range sources {
+		if abs, err := filepath.Abs(source); err == nil && abs == out {
+			return fmt.Errorf("refusing to overwrite source kubeconfig %s; choose another output file", outputFile)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/cli/target"
+	"github.com/aryankumar/fleet/internal/cluster"
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/output"
+	"github.com/aryankumar/fleet/internal/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// ClusterHealth is the health report for one cluster
+type ClusterHealth struct {
+	Cluster     string             `json:"cluster" yaml:"cluster"`
+	Healthy     bool               `json:"healthy" yaml:"healthy"`
+	Reachable   bool               `json:"reachable" yaml:"reachable"`
+	Latency     string             `json:"latency,omitempty" yaml:"latency,omitempty"`
+	Version     string             `json:"version,omitempty" yaml:"version,omitempty"`
+	Checks      []ReadyzCheck      `json:"checks,omitempty" yaml:"checks,omitempty"`
+	Nodes       *NodeReadiness     `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+	Credentials *CredentialsReport `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+	ErrorClass  string             `json:"errorClass,omitempty" yaml:"errorClass,omitempty"`
+	Circuit     string             `json:"circuit,omitempty" yaml:"circuit,omitempty"`
+	Error       string             `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ReadyzCheck is one component check from /readyz?verbose
+type ReadyzCheck struct {
+	Name    string `json:"name" yaml:"name"`
+	Healthy bool   `json:"healthy" yaml:"healthy"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// NodeReadiness counts ready nodes
+type NodeReadiness struct {
+	Ready int `json:"ready" yaml:"ready"`
+	Total int `json:"total" yaml:"total"`
+}
+
+// CredentialsReport describes the credentials used for a cluster
+type CredentialsReport struct {
+	Type     string     `json:"type" yaml:"type"`
+	Expiry   *time.Time `json:"expiry,omitempty" yaml:"expiry,omitempty"`
+	Expiring bool       `json:"expiring" yaml:"expiring"`
+}
+
+// newHealthCmd creates the cluster health command
+func newHealthCmd() *cobra.Command {
+	var expiryWarning time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "health",
+		Short: "Check the health of the targeted clusters",
+		Long: `Check the health of every targeted cluster.
+
+For each cluster this reports whether the API server is reachable, the API
+latency, the server version, the /readyz component checks, how many nodes are
+ready and when the credentials in use expire. Credentials expiring within
+--expiry-warning are flagged.
+
+The command exits non-zero when any cluster is unhealthy, so it can be run
+from cron or CI to alert on cluster problems.`,
+		Example: `  # Check every cluster
+  fleet cluster health
+
+  # Check production clusters and print JSON
+  fleet cluster health --group prod -o json
+
+  # Flag credentials expiring within 3 days
+  fleet cluster health --expiry-warning 72h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHealth(cmd.Context(), os.Stdout, expiryWarning)
+		},
+	}
+
+	cmd.Flags().DurationVar(&expiryWarning, "expiry-warning", 7*24*time.Hour, "flag credentials that expire within this duration")
+
+	return cmd
+}
+
+func runHealth(ctx context.Context, w io.Writer, expiryWarning time.Duration) error {
+	logger := slog.Default()
+
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	defaults := configManager.GetConfig().Defaults
+
+	loader := config.NewKubeconfigLoader(viper.GetString("kubeconfig"))
+	mgr := cluster.NewManager(loader, logger)
+	defer mgr.Close()
+
+	if err := target.Connect(ctx, mgr, logger); err != nil {
+		return err
+	}
+	if mgr.Count() == 0 {
+		return fmt.Errorf("no clusters connected")
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, mgr.MaxTimeout(defaults.Timeout))
+	defer cancel()
+
+	statuses := mgr.HealthCheckWithStatus(checkCtx)
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ClusterName < statuses[j].ClusterName
+	})
+
+	// Health checks bypass the circuit breaker and count as its trial
+	// requests, so a recovered cluster is used again straight away
+	breaker := mgr.CircuitBreaker()
+
+	now := time.Now()
+	reports := make([]ClusterHealth, 0, len(statuses))
+	unhealthy := 0
+	for _, status := range statuses {
+		report := healthReport(status, now, expiryWarning)
+		if breaker != nil {
+			breaker.Record(status.ClusterName, status.Error)
+			report.Circuit = string(breaker.State(status.ClusterName))
+		}
+		if !report.Healthy {
+			unhealthy++
+		}
+		reports = append(reports, report)
+	}
+
+	if err := formatHealth(w, reports, defaults, now); err != nil {
+		return err
+	}
+
+	if unhealthy > 0 {
+		return fmt.Errorf("%d of %d clusters unhealthy", unhealthy, len(reports))
+	}
+	return nil
+}
+
+// healthReport converts a manager health status for display
+func healthReport(status cluster.HealthStatus, now time.Time, expiryWarning time.Duration) ClusterHealth {
+	report := ClusterHealth{
+		Cluster:    status.ClusterName,
+		Healthy:    status.Healthy,
+		Version:    status.ServerVersion,
+		ErrorClass: string(status.ErrorClass),
+	}
+
+	// Timeouts and network errors mean no answer; anything else came from the server
+	report.Reachable = status.ErrorClass != cluster.ErrorClassUnreachable &&
+		status.ErrorClass != cluster.ErrorClassTimeout
+	if report.Reachable && status.Latency > 0 {
+		report.Latency = status.Latency.Round(time.Millisecond).String()
+	}
+	if status.Error != nil {
+		report.Error = status.Error.Error()
+	}
+
+	for _, check := range status.Checks {
+		report.Checks = append(report.Checks, ReadyzCheck(check))
+	}
+	if status.Nodes != nil {
+		report.Nodes = &NodeReadiness{Ready: status.Nodes.Ready, Total: status.Nodes.Total}
+	}
+	if creds := status.Credentials; creds != nil {
+		report.Credentials = &CredentialsReport{Type: creds.Type}
+		if !creds.Expiry.IsZero() {
+			expiry := creds.Expiry
+			report.Credentials.Expiry = &expiry
+			report.Credentials.Expiring = expiry.Sub(now) < expiryWarning
+		}
+	}
+
+	return report
+}
+
+func formatHealth(w io.Writer, reports []ClusterHealth, defaults config.DefaultsConfig, now time.Time) error {
+	switch defaults.OutputFormat {
+	case "json":
+		return output.NewFormatter(output.FormatJSON).Format(w, reports)
+	case "yaml":
+		return output.NewFormatter(output.FormatYAML).Format(w, reports)
+	default:
+		return formatHealthTable(w, reports, defaults.NoColor, now)
+	}
+}
+
+func formatHealthTable(w io.Writer, reports []ClusterHealth, noColor bool, now time.Time) error {
+	colors := output.NewColorScheme(w, noColor)
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		colors.Header("CLUSTER"),
+		colors.Header("STATUS"),
+		colors.Header("LATENCY"),
+		colors.Header("VERSION"),
+		colors.Header("NODES"),
+		colors.Header("READYZ"),
+		colors.Header("CREDENTIALS"))
+
+	unhealthy := 0
+	for _, report := range reports {
+		status := colors.Success("Healthy")
+		if !report.Healthy {
+			unhealthy++
+			status = colors.Error("Unhealthy")
+			if !report.Reachable {
+				status = colors.Error("Unreachable")
+			}
+		}
+
+		nodes := "-"
+		if report.Nodes != nil {
+			nodes = fmt.Sprintf("%d/%d", report.Nodes.Ready, report.Nodes.Total)
+			if report.Nodes.Ready < report.Nodes.Total {
+				nodes = colors.Warning(nodes)
+			}
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			colors.ClusterName(util.ShortClusterName(report.Cluster)),
+			status,
+			valueOrDash(report.Latency),
+			valueOrDash(report.Version),
+			nodes,
+			readyzSummary(report.Checks, colors),
+			credentialsSummary(report.Credentials, colors, now))
+	}
+	tw.Flush()
+
+	// Explain failures below the table, where long messages don't break alignment
+	for _, report := range reports {
+		if report.Error != "" {
+			fmt.Fprintf(w, "\n%s: %s", util.ShortClusterName(report.Cluster), colors.Error("%s", report.Error))
+		}
+	}
+	if unhealthy > 0 {
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "\nTotal: %d clusters, %d healthy, %d unhealthy\n", len(reports), len(reports)-unhealthy, unhealthy)
+	return nil
+}
+
+// readyzSummary shows "ok" when every check passed, or the failed checks
+func readyzSummary(checks []ReadyzCheck, colors *output.ColorScheme) string {
+	if len(checks) == 0 {
+		return "-"
+	}
+
+	var failed []string
+	for _, check := range checks {
+		if !check.Healthy {
+			failed = append(failed, check.Name)
+		}
+	}
+	if len(failed) == 0 {
+		return colors.Success("ok")
+	}
+	return colors.Error("failed: %s", strings.Join(failed, ","))
+}
+
+// credentialsSummary shows the credential type and how long until it expires
+func credentialsSummary(creds *CredentialsReport, colors *output.ColorScheme, now time.Time) string {
+	if creds == nil {
+		return "-"
+	}
+	if creds.Expiry == nil {
+		return creds.Type
+	}
+
+	remaining := creds.Expiry.Sub(now)
+	if remaining <= 0 {
+		return colors.Error("%s, expired", creds.Type)
+	}
+	summary := fmt.Sprintf("%s, expires in %s", creds.Type, duration.HumanDuration(remaining))
+	if creds.Expiring {
+		return colors.Warning("%s", summary)
+	}
+	return summary
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/inventory"
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
@@ -20,6 +22,7 @@ import (
 func newListCmd() *cobra.Command {
 	var (
 		showLabels bool
+		showGroups bool
 		outputFormat string
 	)
 
@@ -30,20 +33,24 @@ func newListCmd() *cobra.Command {
 
 This command displays all available contexts, showing the current context,
 cluster names, servers, namespaces, and users. It supports multiple kubeconfig
-sources including the KUBECONFIG environment variable.`,
+sources including the KUBECONFIG environment variable.
+
+Use --show-labels and --show-groups to include labels and group membership
+from the fleet config.`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runList(cmd, showLabels, outputFormat)
+			return runList(cmd, showLabels, showGroups, outputFormat)
 		},
 	}
 
 	cmd.Flags().BoolVar(&showLabels, "show-labels", false, "show cluster labels from fleet config")
+	cmd.Flags().BoolVar(&showGroups, "show-groups", false, "show cluster groups from fleet config")
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "output format (table, json, yaml)")
 
 	return cmd
 }
 
-func runList(cmd *cobra.Command, showLabels bool, outputFormat string) error {
+func runList(cmd *cobra.Command, showLabels, showGroups bool, outputFormat string) error {
 	logger := slog.Default()
 
 	// Get kubeconfig path from flag or viper
@@ -64,20 +71,23 @@ func runList(cmd *cobra.Command, showLabels bool, outputFormat string) error {
 		return fmt.Errorf("failed to load clusters: %w", err)
 	}
 
+	configManager, err := config.FromContext(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to load fleet config: %w", err)
+	}
+	fleetConfig := configManager.GetConfig()
+	logger.Debug("loaded fleet config", "clusters", len(fleetConfig.Clusters))
+
+	// Add clusters discovered from kubeconfig directories and the hub cluster
+	clusters = append(clusters, discoverClusters(cmd.Context(), loader, fleetConfig, clusters, logger)...)
+
 	if len(clusters) == 0 {
 		fmt.Fprintf(os.Stderr, "No clusters found in kubeconfig\n")
 		return nil
 	}
 
-	// Load fleet configuration to merge cluster metadata
-	cfgPath := viper.GetString("config")
-	configManager := config.NewManager(cfgPath)
-	if fleetConfig, err := configManager.Load(); err == nil {
-		logger.Debug("loaded fleet config", "clusters", len(fleetConfig.Clusters))
-		clusters = configManager.MergeClusterInfo(clusters)
-	} else {
-		logger.Debug("no fleet config loaded, using kubeconfig only")
-	}
+	// Merge cluster metadata from the fleet configuration
+	clusters = configManager.MergeClusterInfo(clusters)
 
 	// Sort clusters by context name for consistent output
 	sort.Slice(clusters, func(i, j int) bool {
@@ -93,7 +103,7 @@ func runList(cmd *cobra.Command, showLabels bool, outputFormat string) error {
 
 	// Determine output format
 	if outputFormat == "" {
-		outputFormat = viper.GetString("output")
+		outputFormat = fleetConfig.Defaults.OutputFormat
 	}
 	if outputFormat == "" {
 		outputFormat = "table"
@@ -106,13 +116,36 @@ func runList(cmd *cobra.Command, showLabels bool, outputFormat string) error {
 	case "yaml":
 		return outputYAML(clusters)
 	case "table":
-		return outputTable(clusters, showLabels, viper.GetBool("no-color"))
+		return outputTable(clusters, showLabels, showGroups, fleetConfig.Defaults.NoColor)
 	default:
 		return fmt.Errorf("unsupported output format: %s (supported: table, json, yaml)", outputFormat)
 	}
 }
 
-func outputTable(clusters []config.ClusterInfo, showLabels bool, noColor bool) error {
+// discoverClusters returns the clusters found by the inventory sources in the
+// fleet config that are not already listed. Discovery problems are logged.
+func discoverClusters(ctx context.Context, loader *config.KubeconfigLoader, fleetConfig *config.FleetConfig, listed []config.ClusterInfo, logger *slog.Logger) []config.ClusterInfo {
+	sources, err := inventory.FromConfig(fleetConfig.Inventory, loader)
+	if err != nil {
+		logger.Warn("cluster discovery incomplete", "error", err)
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+
+	known := make([]string, 0, len(listed))
+	for _, cluster := range listed {
+		known = append(known, cluster.Context)
+	}
+
+	discovered, err := inventory.Merge(ctx, sources, known, logger)
+	if err != nil {
+		logger.Warn("cluster discovery incomplete", "error", err)
+	}
+	return config.ClusterInfos(discovered)
+}
+
+func outputTable(clusters []config.ClusterInfo, showLabels, showGroups bool, noColor bool) error {
 	table := tablewriter.NewWriter(os.Stdout)
 
 	// Set up headers
@@ -120,6 +153,9 @@ func outputTable(clusters []config.ClusterInfo, showLabels bool, noColor bool) e
 	if showLabels {
 		headers = append(headers, "Labels")
 	}
+	if showGroups {
+		headers = append(headers, "Groups")
+	}
 	table.SetHeader(headers)
 
 	// Configure table style
@@ -206,6 +242,11 @@ func outputTable(clusters []config.ClusterInfo, showLabels bool, noColor bool) e
 			row = append(row, labelStr)
 		}
 
+		// Groups
+		if showGroups {
+			row = append(row, strings.Join(cluster.Groups, ","))
+		}
+
 		table.Append(row)
 	}
 
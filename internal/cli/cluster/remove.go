@@ -1,13 +1,15 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
+	"os"
 
+	"github.com/aryankumar/fleet/internal/config"
 	"github.com/spf13/cobra"
 )
 
 // newRemoveCmd creates the cluster remove command
-// This is a placeholder for Phase 2 - full implementation in later phases
 func newRemoveCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "remove NAME",
@@ -19,9 +21,30 @@ It does not modify your kubeconfig.`,
 		Aliases: []string{"rm", "delete"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fmt.Errorf("not implemented yet - coming in later phases")
+			return runRemove(cmd.Context(), args[0])
 		},
 	}
 
 	return cmd
 }
+
+func runRemove(ctx context.Context, name string) error {
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load fleet config: %w", err)
+	}
+
+	if _, exists := configManager.GetClusterConfig(name); !exists {
+		return fmt.Errorf("cluster %q not found in fleet config", name)
+	}
+
+	if err := configManager.RemoveClusterConfig(name); err != nil {
+		return err
+	}
+	if err := configManager.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Cluster %q removed from %s\n", name, configManager.ConfigPath())
+	return nil
+}
@@ -1,25 +1,63 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"os"
+	"slices"
 
+	"github.com/aryankumar/fleet/internal/config"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // newSwitchCmd creates the cluster switch command
-// This is a placeholder for Phase 2 - full implementation in later phases
 func newSwitchCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "switch CONTEXT",
 		Short: "Switch to a different cluster context",
 		Long: `Switch the current Kubernetes context to a different cluster.
 
-This command updates the current-context in your kubeconfig file.`,
+This command updates the current-context in your kubeconfig file. When
+several kubeconfig files are merged (KUBECONFIG), the file that currently
+sets current-context is the one rewritten. A cluster name from the fleet
+config may be used in place of the context name.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fmt.Errorf("not implemented yet - coming in later phases")
+			return runSwitch(cmd.Context(), args[0])
 		},
 	}
 
 	return cmd
 }
+
+func runSwitch(ctx context.Context, name string) error {
+	logger := slog.Default()
+
+	loader := config.NewKubeconfigLoader(viper.GetString("kubeconfig"))
+
+	contexts, err := loader.GetContexts()
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	// Fall back to resolving a fleet config cluster name to its context
+	contextName := name
+	if !slices.Contains(contexts, contextName) {
+		if configManager, err := config.FromContext(ctx); err == nil {
+			contextName = configManager.ResolveContext(name)
+		} else {
+			logger.Debug("no fleet config loaded, using kubeconfig only", "error", err)
+		}
+	}
+
+	path, err := loader.SetCurrentContext(contextName)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("updated current context", "context", contextName, "file", path)
+	fmt.Fprintf(os.Stdout, "Switched to context %q\n", contextName)
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package configcmd implements the "fleet config" commands for inspecting
+// and editing the fleet configuration file.
+package configcmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// AnnotationTolerateConfigErrors marks commands that still run when the
+// fleet config cannot be loaded, so they can report the problem themselves
+const AnnotationTolerateConfigErrors = "fleet/tolerate-config-errors"
+
+// NewConfigCmd creates the config command
+func NewConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and edit the fleet configuration",
+		Long: `Inspect and edit the fleet configuration file.
+
+Settings are resolved in the order flag > environment variable > config
+file > built-in default. "view" shows the effective result and where each
+default came from; "get" and "set" read and write single keys; "validate"
+checks the file for mistakes; "migrate" upgrades it to the current schema.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newViewCmd())
+	cmd.AddCommand(newGetCmd())
+	cmd.AddCommand(newSetCmd())
+	cmd.AddCommand(newValidateCmd())
+	cmd.AddCommand(newMigrateCmd())
+
+	return cmd
+}
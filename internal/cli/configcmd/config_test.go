@@ -0,0 +1,242 @@
+package configcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/config/configtest"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const testConfig = `defaults:
+  timeout: 45s
+clusters:
+  staging:
+    alias: stage
+    enabled: true
+groups:
+  all:
+    - staging
+`
+
+func TestRunView(t *testing.T) {
+	m := configtest.Load(t, testConfig)
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Int("parallel", 5, "")
+	if err := flags.Parse([]string{"--parallel", "8"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	v := viper.New()
+	if err := v.BindPFlags(flags); err != nil {
+		t.Fatalf("BindPFlags() error = %v", err)
+	}
+	m.ApplyOverrides(v, flags)
+
+	t.Run("yaml", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := runView(&buf, m, "yaml"); err != nil {
+			t.Fatalf("runView() error = %v", err)
+		}
+
+		out := buf.String()
+		for _, want := range []string{
+			"# Config file: " + m.ConfigPath(),
+			"timeout: 45s # from file",
+			"parallel: 8 # from flag",
+			"outputFormat: table # from default",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output missing %q:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := runView(&buf, m, "json"); err != nil {
+			t.Fatalf("runView() error = %v", err)
+		}
+
+		var got viewOutput
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("invalid JSON output: %v", err)
+		}
+		if got.Sources["defaults.parallel"] != config.SourceFlag {
+			t.Errorf("parallel source = %q, want %q", got.Sources["defaults.parallel"], config.SourceFlag)
+		}
+		if got.Config["defaults"].(map[string]interface{})["timeout"] != "45s" {
+			t.Errorf("timeout = %v, want \"45s\"", got.Config["defaults"])
+		}
+
+		// The config section must read back as a config file
+		data, err := json.Marshal(got.Config)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		roundTrip := configtest.Load(t, string(data)).GetConfig()
+		if roundTrip.Defaults.Parallel != 8 {
+			t.Errorf("parallel = %d, want 8", roundTrip.Defaults.Parallel)
+		}
+		if roundTrip.Defaults.Timeout != 45*time.Second {
+			t.Errorf("timeout = %v, want 45s", roundTrip.Defaults.Timeout)
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		if err := runView(&bytes.Buffer{}, m, "table"); err == nil {
+			t.Error("runView() expected error for table output")
+		}
+	})
+}
+
+func TestRunGet(t *testing.T) {
+	m := configtest.Load(t, testConfig)
+
+	tests := []struct {
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{key: "defaults.timeout", want: "45s\n"},
+		{key: "clusters.staging.alias", want: "stage\n"},
+		{key: "groups.all", want: "- staging\n"},
+		{key: "clusters.nope", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runGet(&buf, m, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runGet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && buf.String() != tt.want {
+				t.Errorf("runGet() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRunValidate(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: staging
+  cluster:
+    server: https://staging.example.com
+contexts:
+- name: staging
+  context:
+    cluster: staging
+    user: staging
+users:
+- name: staging
+  user:
+    token: test
+current-context: staging
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		path := configtest.Write(t, testConfig)
+
+		var buf bytes.Buffer
+		if err := runValidate(&buf, path, kubeconfigPath); err != nil {
+			t.Fatalf("runValidate() error = %v", err)
+		}
+		if !strings.Contains(buf.String(), "is valid") {
+			t.Errorf("output = %q, want it to report the file as valid", buf.String())
+		}
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		path := configtest.Write(t, "defaults:\n  timeout: 30\n  paralel: 5\n")
+
+		var buf bytes.Buffer
+		err := runValidate(&buf, path, kubeconfigPath)
+		if err == nil {
+			t.Fatal("runValidate() expected error")
+		}
+		if !strings.Contains(err.Error(), "found 2 problem(s)") {
+			t.Errorf("error = %v, want 2 problems", err)
+		}
+
+		out := buf.String()
+		for _, want := range []string{
+			path + ":2: defaults.timeout: invalid duration",
+			path + ":3: defaults.paralel: unknown key",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output missing %q:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("no config file", func(t *testing.T) {
+		if err := runValidate(&bytes.Buffer{}, "", kubeconfigPath); err == nil {
+			t.Error("runValidate() expected error without a config file")
+		}
+	})
+}
+
+func TestRunMigrate(t *testing.T) {
+	const legacy = "# shared with the team\nclusters:\n  staging:\n    alias: stage\n"
+
+	t.Run("dry run", func(t *testing.T) {
+		path := configtest.Write(t, legacy)
+
+		var buf bytes.Buffer
+		if err := runMigrate(&buf, path, true); err != nil {
+			t.Fatalf("runMigrate() error = %v", err)
+		}
+		if !strings.HasPrefix(buf.String(), "apiVersion: fleet/v1\nkind: FleetConfig\n") {
+			t.Errorf("output = %q, want the migrated file", buf.String())
+		}
+
+		data, _ := os.ReadFile(path)
+		if string(data) != legacy {
+			t.Error("dry run rewrote the config file")
+		}
+	})
+
+	t.Run("rewrite", func(t *testing.T) {
+		path := configtest.Write(t, legacy)
+
+		var buf bytes.Buffer
+		if err := runMigrate(&buf, path, false); err != nil {
+			t.Fatalf("runMigrate() error = %v", err)
+		}
+
+		data, _ := os.ReadFile(path)
+		if !strings.Contains(string(data), "apiVersion: fleet/v1") || !strings.Contains(string(data), "# shared with the team") {
+			t.Errorf("migrated file = %q, want header added and comments kept", data)
+		}
+
+		// Running again leaves the file alone
+		buf.Reset()
+		if err := runMigrate(&buf, path, false); err != nil {
+			t.Fatalf("second runMigrate() error = %v", err)
+		}
+		if !strings.Contains(buf.String(), "already uses fleet/v1") {
+			t.Errorf("output = %q, want already current", buf.String())
+		}
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		path := configtest.Write(t, "apiVersion: fleet/v9\n")
+		if err := runMigrate(&bytes.Buffer{}, path, false); err == nil {
+			t.Error("runMigrate() expected error for a newer apiVersion")
+		}
+	})
+}
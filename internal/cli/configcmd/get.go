@@ -0,0 +1,58 @@
+package configcmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// newGetCmd creates the config get command
+func newGetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get KEY",
+		Short: "Print a single configuration value",
+		Long: `Print the effective value of a single configuration key.
+
+Keys are dotted paths into the config file, such as defaults.timeout or
+clusters.prod-east.alias. Mappings and lists are printed as YAML.`,
+		Example: `  # Effective timeout (after flags and environment variables)
+  fleet config get defaults.timeout
+
+  # Everything configured for one cluster
+  fleet config get clusters.prod-east`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configManager, err := config.FromContext(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load fleet config: %w", err)
+			}
+			return runGet(os.Stdout, configManager, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runGet(w io.Writer, configManager *config.Manager, key string) error {
+	value, err := configManager.Get(key)
+	if err != nil {
+		return err
+	}
+
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		defer encoder.Close()
+		return encoder.Encode(value)
+	case nil:
+		return nil
+	default:
+		_, err := fmt.Fprintln(w, value)
+		return err
+	}
+}
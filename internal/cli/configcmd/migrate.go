@@ -0,0 +1,84 @@
+package configcmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// newMigrateCmd creates the config migrate command
+func newMigrateCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Add the apiVersion header to an unversioned fleet config file",
+		Long: `Add the apiVersion header to an unversioned fleet config file.
+
+Files written before fleet config files were versioned have no apiVersion.
+They already use the current layout and are read as ` + config.APIVersion + ` whenever
+fleet loads them; this command writes the apiVersion and kind header into the
+file itself so the change can be reviewed and committed. Comments and key
+order are preserved. Files that already declare an apiVersion are left alone.`,
+		Example: `  # Preview the updated file
+  fleet config migrate --dry-run
+
+  # Update the default config file
+  fleet config migrate
+
+  # Update a config file kept in a git repository
+  fleet config migrate --config ./fleet.yaml`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{AnnotationTolerateConfigErrors: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configManager, err := config.FromContext(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load fleet config: %w", err)
+			}
+			return runMigrate(os.Stdout, configManager.ConfigPath(), dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the updated file instead of writing it")
+
+	return cmd
+}
+
+func runMigrate(w io.Writer, path string, dryRun bool) error {
+	if path == "" {
+		return fmt.Errorf("no fleet config file found (looked for ~/.fleet/config.yaml and ~/.fleet.yaml)")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	stamped, changed, err := config.Migrate(data)
+	if err != nil {
+		return fmt.Errorf("failed to migrate %s: %w", path, err)
+	}
+	if !changed {
+		fmt.Fprintf(w, "%s already uses %s\n", path, config.APIVersion)
+		return nil
+	}
+
+	if dryRun {
+		_, err := w.Write(stamped)
+		return err
+	}
+
+	if err := os.WriteFile(path, stamped, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	fmt.Fprintf(w, "Added apiVersion %s to %s\n", config.APIVersion, path)
+	return nil
+}
@@ -0,0 +1,53 @@
+package configcmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// newSetCmd creates the config set command
+func newSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set KEY VALUE",
+		Short: "Set a single configuration value",
+		Long: `Set a single configuration value and save the config file.
+
+The value is checked against the key's type: durations need a unit (30s, 5m),
+booleans are true or false, and lists are comma-separated. Unknown keys are
+rejected.`,
+		Example: `  # Raise the default timeout
+  fleet config set defaults.timeout 2m
+
+  # Give a cluster an alias
+  fleet config set clusters.prod-east.alias production
+
+  # Define a group
+  fleet config set groups.canary staging,prod-east`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configManager, err := config.FromContext(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load fleet config: %w", err)
+			}
+			return runSet(configManager, args[0], args[1])
+		},
+	}
+
+	return cmd
+}
+
+func runSet(configManager *config.Manager, key, value string) error {
+	if err := configManager.Set(key, value); err != nil {
+		return err
+	}
+
+	if err := configManager.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Set %s to %q in %s\n", key, value, configManager.ConfigPath())
+	return nil
+}
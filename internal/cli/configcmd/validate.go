@@ -0,0 +1,70 @@
+package configcmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// newValidateCmd creates the config validate command
+func newValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the fleet config file for problems",
+		Long: `Check the fleet config file for problems.
+
+Reports unknown keys, values of the wrong type, bad durations, duplicate
+aliases, group members that refer to nothing and cluster contexts missing
+from the kubeconfig, each with its line number. Exits non-zero when any
+problem is found.`,
+		Example: `  # Validate the default config file
+  fleet config validate
+
+  # Validate another file
+  fleet config validate --config ./fleet.yaml`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{AnnotationTolerateConfigErrors: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configManager, err := config.FromContext(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load fleet config: %w", err)
+			}
+			return runValidate(os.Stdout, configManager.ConfigPath(), viper.GetString("kubeconfig"))
+		},
+	}
+
+	return cmd
+}
+
+func runValidate(w io.Writer, path, kubeconfigPath string) error {
+	if path == "" {
+		return fmt.Errorf("no fleet config file found (looked for ~/.fleet/config.yaml and ~/.fleet.yaml)")
+	}
+
+	contexts, err := config.NewKubeconfigLoader(kubeconfigPath).GetContexts()
+	if err != nil {
+		slog.Warn("skipping kubeconfig context check", "error", err)
+		contexts = nil
+	}
+
+	issues, err := config.ValidateFile(path, contexts)
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s:%d: %s: %s\n", path, issue.Line, issue.Key, issue.Message)
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("found %d problem(s) in %s", len(issues), path)
+	}
+
+	fmt.Fprintf(w, "%s is valid\n", path)
+	return nil
+}
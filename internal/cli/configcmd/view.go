@@ -0,0 +1,126 @@
+package configcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// viewOutput is the JSON form of "config view"
+type viewOutput struct {
+	ConfigFile string                 `json:"configFile"`
+	Sources    map[string]string      `json:"sources"`
+	Config     map[string]interface{} `json:"config"`
+}
+
+// newViewCmd creates the config view command
+func newViewCmd() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Show the effective fleet configuration",
+		Long: `Show the effective fleet configuration.
+
+The output is the config file merged with flags, environment variables and
+built-in defaults. Each setting under defaults is annotated with its source:
+flag, env, file or default.`,
+		Example: `  # Show the effective configuration
+  fleet config view
+
+  # See how an environment variable changes the result
+  FLEET_TIMEOUT=2m fleet config view
+
+  # Machine-readable output
+  fleet config view -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configManager, err := config.FromContext(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load fleet config: %w", err)
+			}
+			return runView(os.Stdout, configManager, outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "yaml", "output format (yaml, json)")
+
+	return cmd
+}
+
+func runView(w io.Writer, configManager *config.Manager, outputFormat string) error {
+	switch outputFormat {
+	case "json":
+		fileConfig, err := toFileFormat(configManager.GetConfig())
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(viewOutput{
+			ConfigFile: configManager.ConfigPath(),
+			Sources:    configManager.Sources(),
+			Config:     fileConfig,
+		})
+	case "yaml":
+		return writeAnnotatedYAML(w, configManager)
+	default:
+		return fmt.Errorf("unsupported output format: %s (supported: yaml, json)", outputFormat)
+	}
+}
+
+// toFileFormat converts the config to the generic form the config file is
+// written in, so durations come out as "30s" rather than nanoseconds
+func toFileFormat(fleetConfig *config.FleetConfig) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(fleetConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	var out map[string]interface{}
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	return out, nil
+}
+
+// writeAnnotatedYAML writes the effective config as YAML, commenting each
+// default with the layer it came from
+func writeAnnotatedYAML(w io.Writer, configManager *config.Manager) error {
+	var doc yaml.Node
+	if err := doc.Encode(configManager.GetConfig()); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if path := configManager.ConfigPath(); path != "" {
+		doc.HeadComment = "Config file: " + path
+		if configManager.Unversioned() {
+			doc.HeadComment += "\nThe file has no apiVersion; run \"fleet config migrate\" to add it"
+		}
+	} else {
+		doc.HeadComment = "No config file found, showing built-in defaults"
+	}
+
+	sources := configManager.Sources()
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value != "defaults" {
+			continue
+		}
+		defaults := doc.Content[i+1]
+		for j := 0; j+1 < len(defaults.Content); j += 2 {
+			key := "defaults." + defaults.Content[j].Value
+			if source, ok := sources[key]; ok {
+				defaults.Content[j+1].LineComment = "from " + source
+			}
+		}
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	defer encoder.Close()
+	return encoder.Encode(&doc)
+}
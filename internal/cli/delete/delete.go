@@ -3,6 +3,7 @@ package delete
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -10,9 +11,12 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/aryankumar/fleet/internal/cli/rollout"
+	"github.com/aryankumar/fleet/internal/cli/target"
 	"github.com/aryankumar/fleet/internal/cluster"
 	"github.com/aryankumar/fleet/internal/config"
 	"github.com/aryankumar/fleet/internal/executor"
+	"github.com/aryankumar/fleet/internal/history"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,6 +47,7 @@ func NewDeleteCmd() *cobra.Command {
 	var skipConfirmation bool
 	var resourceType string
 	var resourceName string
+	var rolloutOpts rollout.Options
 
 	cmd := &cobra.Command{
 		Use:   "delete",
@@ -63,17 +68,24 @@ Requires confirmation before deletion unless --yes flag is provided.`,
   # Delete from specific clusters only
   fleet delete -f deployment.yaml --clusters prod-east,prod-west
 
+  # Delete from clusters selected by fleet config labels
+  fleet delete -f deployment.yaml --cluster-selector env=staging
+
   # Dry-run to preview deletions without deleting
   fleet delete -f deployment.yaml --dry-run
 
   # Skip confirmation prompt
-  fleet delete -f deployment.yaml -y`,
+  fleet delete -f deployment.yaml -y
+
+  # Delete progressively, two clusters at a time after a canary, stopping at
+  # the first failure
+  fleet delete -f deployment.yaml --strategy waves --wave-size 2`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
 			// Handle two modes: from file or by type/name
 			if filename != "" {
-				return runDeleteFromFile(ctx, filename, recursive, dryRun, namespace, skipConfirmation)
+				return runDeleteFromFile(ctx, filename, recursive, dryRun, namespace, skipConfirmation, &rolloutOpts)
 			}
 
 			// Delete by type and name from args
@@ -84,7 +96,7 @@ Requires confirmation before deletion unless --yes flag is provided.`,
 			resourceType = args[0]
 			resourceName = args[1]
 
-			return runDeleteByName(ctx, resourceType, resourceName, namespace, dryRun, skipConfirmation)
+			return runDeleteByName(ctx, resourceType, resourceName, namespace, dryRun, skipConfirmation, &rolloutOpts)
 		},
 	}
 
@@ -93,11 +105,12 @@ Requires confirmation before deletion unless --yes flag is provided.`,
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview deletions without deleting")
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of resources to delete")
 	cmd.Flags().BoolVarP(&skipConfirmation, "yes", "y", false, "Skip confirmation prompt")
+	rollout.AddFlags(cmd, &rolloutOpts)
 
 	return cmd
 }
 
-func runDeleteFromFile(ctx context.Context, filename string, recursive bool, dryRun bool, overrideNamespace string, skipConfirmation bool) error {
+func runDeleteFromFile(ctx context.Context, filename string, recursive bool, dryRun bool, overrideNamespace string, skipConfirmation bool, rolloutOpts *rollout.Options) error {
 	logger := slog.Default()
 
 	logger.Debug("deleting from manifests",
@@ -126,6 +139,13 @@ func runDeleteFromFile(ctx context.Context, filename string, recursive bool, dry
 		logger.Debug("overridden namespace for all resources", "namespace", overrideNamespace)
 	}
 
+	// Load the effective fleet settings (flag > env > config file > default)
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	defaults := configManager.GetConfig().Defaults
+
 	// Load kubeconfig and create cluster manager
 	kubeconfigPath := viper.GetString("kubeconfig")
 	loader := config.NewKubeconfigLoader(kubeconfigPath)
@@ -133,16 +153,9 @@ func runDeleteFromFile(ctx context.Context, filename string, recursive bool, dry
 	mgr := cluster.NewManager(loader, logger)
 	defer mgr.Close()
 
-	// Determine which clusters to connect to
-	targetClusters := viper.GetStringSlice("clusters")
-	if len(targetClusters) == 0 {
-		err = mgr.ConnectAll(ctx)
-	} else {
-		err = mgr.Connect(ctx, targetClusters)
-	}
-
-	if err != nil {
-		logger.Warn("some cluster connections failed", "error", err)
+	// Connect to the targeted clusters
+	if err := target.Connect(ctx, mgr, logger); err != nil {
+		return err
 	}
 
 	if mgr.Count() == 0 {
@@ -151,6 +164,12 @@ func runDeleteFromFile(ctx context.Context, filename string, recursive bool, dry
 
 	logger.Info("connected to clusters", "count", mgr.Count())
 
+	// Plan the rollout waves, if any, before asking for confirmation
+	plan, err := rolloutOpts.Plan(ctx, mgr)
+	if err != nil {
+		return err
+	}
+
 	// Show preview and ask for confirmation unless skipped
 	if !skipConfirmation && !dryRun {
 		if !confirmDelete(manifests, mgr.GetClientNames()) {
@@ -160,44 +179,52 @@ func runDeleteFromFile(ctx context.Context, filename string, recursive bool, dry
 	}
 
 	// Create executor pool
-	parallelism := viper.GetInt("parallel")
-	pool := executor.NewPool(parallelism, logger)
+	parallelism := defaults.Parallel
+	pool := executor.NewPool[[]DeleteResult](parallelism, logger)
+	if defaults.AdaptiveParallel {
+		pool.SetAdaptive(&executor.AdaptiveOptions{})
+	}
+	pool.SetFailFast(rolloutOpts.FailFast)
+	retry := executor.NewRetryPolicy(defaults.Retries)
 
 	// Submit tasks for each cluster
 	clients := mgr.GetAllClients()
 	for _, client := range clients {
-		clusterName := client.Name
-		restConfig := client.RestConfig
-
-		task := executor.Task{
-			ClusterName: clusterName,
-			Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
-				return deleteManifests(ctx, clusterName, restConfig, manifests, dryRun, logger)
-			},
+		task := executor.Task[[]DeleteResult]{
+			Client:  client,
+			Timeout: client.EffectiveTimeout(defaults.Timeout),
+			Retry:   retry,
+			Execute: cluster.Guard(mgr, func(ctx context.Context, c *cluster.Client) ([]DeleteResult, error) {
+				return deleteManifests(ctx, c.Name, c.RestConfig, manifests, dryRun, logger)
+			}),
 		}
 
 		if err := pool.Submit(task); err != nil {
-			logger.Error("failed to submit task", "cluster", clusterName, "error", err)
+			logger.Error("failed to submit task", "cluster", client.Name, "error", err)
 			continue
 		}
 	}
 
-	// Execute tasks with timeout
-	timeout := viper.GetDuration("timeout")
-	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	// Each task's timeout runs from when it starts; --deadline bounds the run
+	execCtx, cancel := executor.WithRunDeadline(ctx, defaults.Deadline)
 	defer cancel()
 
 	fmt.Printf("\n%s resources from %d cluster(s)...\n\n",
 		map[bool]string{true: "Dry-running delete for", false: "Deleting"}[dryRun],
 		mgr.Count())
 
-	results := pool.Execute(execCtx)
+	fmt.Print(plan.Describe())
 
-	// Format and display results
-	return formatDeleteResults(results, dryRun)
+	// Print each cluster's results as soon as it finishes
+	results := history.Tee(ctx, rollout.Execute(execCtx, pool, plan), historyResource)
+	err = formatDeleteResults(results, dryRun, plan.Progress())
+	if report, ok := pool.ConcurrencyReport(); ok {
+		fmt.Printf("Concurrency: %s\n", report)
+	}
+	return err
 }
 
-func runDeleteByName(ctx context.Context, resourceType, resourceName, namespace string, dryRun bool, skipConfirmation bool) error {
+func runDeleteByName(ctx context.Context, resourceType, resourceName, namespace string, dryRun bool, skipConfirmation bool, rolloutOpts *rollout.Options) error {
 	logger := slog.Default()
 
 	if namespace == "" {
@@ -210,6 +237,13 @@ func runDeleteByName(ctx context.Context, resourceType, resourceName, namespace
 		"namespace", namespace,
 		"dry_run", dryRun)
 
+	// Load the effective fleet settings (flag > env > config file > default)
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	defaults := configManager.GetConfig().Defaults
+
 	// Load kubeconfig and create cluster manager
 	kubeconfigPath := viper.GetString("kubeconfig")
 	loader := config.NewKubeconfigLoader(kubeconfigPath)
@@ -217,23 +251,21 @@ func runDeleteByName(ctx context.Context, resourceType, resourceName, namespace
 	mgr := cluster.NewManager(loader, logger)
 	defer mgr.Close()
 
-	// Connect to clusters
-	targetClusters := viper.GetStringSlice("clusters")
-	var err error
-	if len(targetClusters) == 0 {
-		err = mgr.ConnectAll(ctx)
-	} else {
-		err = mgr.Connect(ctx, targetClusters)
-	}
-
-	if err != nil {
-		logger.Warn("some cluster connections failed", "error", err)
+	// Connect to the targeted clusters
+	if err := target.Connect(ctx, mgr, logger); err != nil {
+		return err
 	}
 
 	if mgr.Count() == 0 {
 		return fmt.Errorf("no clusters connected")
 	}
 
+	// Plan the rollout waves, if any, before asking for confirmation
+	plan, err := rolloutOpts.Plan(ctx, mgr)
+	if err != nil {
+		return err
+	}
+
 	// Confirm deletion
 	if !skipConfirmation && !dryRun {
 		if !confirmDeleteByName(resourceType, resourceName, namespace, mgr.GetClientNames()) {
@@ -243,31 +275,34 @@ func runDeleteByName(ctx context.Context, resourceType, resourceName, namespace
 	}
 
 	// Create executor pool
-	parallelism := viper.GetInt("parallel")
-	pool := executor.NewPool(parallelism, logger)
+	parallelism := defaults.Parallel
+	pool := executor.NewPool[[]DeleteResult](parallelism, logger)
+	if defaults.AdaptiveParallel {
+		pool.SetAdaptive(&executor.AdaptiveOptions{})
+	}
+	pool.SetFailFast(rolloutOpts.FailFast)
+	retry := executor.NewRetryPolicy(defaults.Retries)
 
 	// Submit tasks for each cluster
 	clients := mgr.GetAllClients()
 	for _, client := range clients {
-		clusterName := client.Name
-		restConfig := client.RestConfig
-
-		task := executor.Task{
-			ClusterName: clusterName,
-			Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
-				return deleteResource(ctx, clusterName, restConfig, resourceType, resourceName, namespace, dryRun, logger)
-			},
+		task := executor.Task[[]DeleteResult]{
+			Client:  client,
+			Timeout: client.EffectiveTimeout(defaults.Timeout),
+			Retry:   retry,
+			Execute: cluster.Guard(mgr, func(ctx context.Context, c *cluster.Client) ([]DeleteResult, error) {
+				return deleteResource(ctx, c.Name, c.RestConfig, resourceType, resourceName, namespace, dryRun, logger)
+			}),
 		}
 
 		if err := pool.Submit(task); err != nil {
-			logger.Error("failed to submit task", "cluster", clusterName, "error", err)
+			logger.Error("failed to submit task", "cluster", client.Name, "error", err)
 			continue
 		}
 	}
 
-	// Execute tasks
-	timeout := viper.GetDuration("timeout")
-	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	// Each task's timeout runs from when it starts; --deadline bounds the run
+	execCtx, cancel := executor.WithRunDeadline(ctx, defaults.Deadline)
 	defer cancel()
 
 	fmt.Printf("\n%s %s/%s from %d cluster(s)...\n\n",
@@ -276,9 +311,15 @@ func runDeleteByName(ctx context.Context, resourceType, resourceName, namespace
 		resourceName,
 		mgr.Count())
 
-	results := pool.Execute(execCtx)
+	fmt.Print(plan.Describe())
 
-	return formatDeleteResults(results, dryRun)
+	// Print each cluster's results as soon as it finishes
+	results := history.Tee(ctx, rollout.Execute(execCtx, pool, plan), historyResource)
+	err = formatDeleteResults(results, dryRun, plan.Progress())
+	if report, ok := pool.ConcurrencyReport(); ok {
+		fmt.Printf("Concurrency: %s\n", report)
+	}
+	return err
 }
 
 // parseManifests parses YAML/JSON manifests from a file or directory
@@ -444,7 +485,7 @@ func deleteManifests(
 			"action", result.Action)
 	}
 
-	return results, nil
+	return results, failedResources(results)
 }
 
 // deleteResource deletes a single resource by type and name
@@ -500,7 +541,25 @@ func deleteResource(
 		}
 	}
 
-	return []DeleteResult{result}, nil
+	results := []DeleteResult{result}
+	return results, failedResources(results)
+}
+
+// failedResources returns an error if any resource failed to delete, so that
+// the cluster counts as failed, e.g. towards --max-failures
+// The error wraps every resource's error, so that retries and the circuit
+// breaker can tell an unreachable cluster from a rejected manifest.
+func failedResources(results []DeleteResult) error {
+	var errs []error
+	for _, result := range results {
+		if result.Error != nil {
+			errs = append(errs, result.Error)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d resource(s) failed to delete: %w", len(errs), len(results), errors.Join(errs...))
 }
 
 // confirmDelete prompts the user for confirmation before deleting
@@ -556,40 +615,61 @@ func formatResourceName(obj *unstructured.Unstructured) string {
 	return fmt.Sprintf("%s/%s", kind, name)
 }
 
-// formatDeleteResults formats and displays delete results
-func formatDeleteResults(results []executor.Result, dryRun bool) error {
+// historyResource converts a delete result for the run history
+func historyResource(r DeleteResult) history.Resource {
+	res := history.Resource{
+		Resource:  r.Resource,
+		Kind:      r.Kind,
+		Name:      r.Name,
+		Namespace: r.Namespace,
+		Action:    r.Action,
+	}
+	if r.Error != nil {
+		res.Error = r.Error.Error()
+	}
+	return res
+}
+
+// formatDeleteResults displays delete results as they arrive, followed by any
+// cluster errors and a summary once every cluster has finished
+// progress adds wave headers and reports clusters skipped by an aborted
+// rollout, fail-fast or an open circuit.
+func formatDeleteResults(results <-chan executor.Result[[]DeleteResult], dryRun bool, progress *rollout.Progress) error {
 	var allResults []DeleteResult
 	var errors []string
 
 	successCount := 0
 	failureCount := 0
 
-	for _, result := range results {
-		if result.Error != nil {
+	for result := range results {
+		if progress.Observe(os.Stdout, result.ClusterName, result.Wave, result.Status, result.Error) {
+			continue
+		}
+
+		// Resource failures come with their results and are listed below
+		if result.Error != nil && len(result.Data) == 0 {
 			errors = append(errors, fmt.Sprintf("%s: %v", result.ClusterName, result.Error))
 			failureCount++
 			continue
 		}
 
-		if deleteResults, ok := result.Data.([]DeleteResult); ok {
-			for _, dr := range deleteResults {
-				if dr.Error != nil {
-					fmt.Printf("  %s [%s] %s: %v\n",
-						getStatusIcon(false),
-						dr.Cluster,
-						dr.Resource,
-						dr.Error)
-					failureCount++
-				} else {
-					fmt.Printf("  %s [%s] %s %s\n",
-						getStatusIcon(true),
-						dr.Cluster,
-						dr.Resource,
-						dr.Action)
-					successCount++
-				}
-				allResults = append(allResults, dr)
+		for _, dr := range result.Data {
+			if dr.Error != nil {
+				fmt.Printf("  %s [%s] %s: %v\n",
+					getStatusIcon(false),
+					dr.Cluster,
+					dr.Resource,
+					dr.Error)
+				failureCount++
+			} else {
+				fmt.Printf("  %s [%s] %s %s\n",
+					getStatusIcon(true),
+					dr.Cluster,
+					dr.Resource,
+					dr.Action)
+				successCount++
 			}
+			allResults = append(allResults, dr)
 		}
 	}
 
@@ -610,6 +690,10 @@ func formatDeleteResults(results []executor.Result, dryRun bool) error {
 		fmt.Printf("Delete completed: %d deleted, %d failed\n", successCount, failureCount)
 	}
 
+	if err := progress.Finish(os.Stdout); err != nil {
+		return err
+	}
+
 	if failureCount > 0 {
 		return fmt.Errorf("some resources failed to delete")
 	}
@@ -5,6 +5,10 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/aryankumar/fleet/internal/cli/rollout"
+	"github.com/aryankumar/fleet/internal/cluster"
+	"github.com/aryankumar/fleet/internal/executor"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -679,3 +683,37 @@ func TestShortFormAliases(t *testing.T) {
 		})
 	}
 }
+
+func TestFailedResources(t *testing.T) {
+	if err := failedResources([]DeleteResult{{Resource: "deployment/web", Action: "deleted"}}); err != nil {
+		t.Errorf("failedResources() = %v, want nil when every resource was deleted", err)
+	}
+
+	results := []DeleteResult{
+		{Resource: "deployment/web", Error: apierrors.NewTooManyRequests("slow down", 1)},
+		{Resource: "service/web", Action: "deleted"},
+	}
+	err := failedResources(results)
+	if !apierrors.IsTooManyRequests(err) || !executor.IsRetryableError(err) {
+		t.Errorf("failedResources() = %v, want the 429 kept so the cluster is retried", err)
+	}
+}
+
+func TestFormatDeleteResults_OpenCircuit(t *testing.T) {
+	results := make(chan executor.Result[[]DeleteResult], 2)
+	results <- executor.Result[[]DeleteResult]{
+		ClusterName: "staging",
+		Data:        []DeleteResult{{Cluster: "staging", Resource: "deployment/web", Action: "deleted"}},
+		Status:      executor.StatusSucceeded,
+	}
+	results <- executor.Result[[]DeleteResult]{
+		ClusterName: "prod",
+		Error:       &cluster.CircuitOpenError{Cluster: "prod", Failures: 3},
+		Status:      executor.StatusSkipped,
+	}
+	close(results)
+
+	if err := formatDeleteResults(results, false, (*rollout.Plan)(nil).Progress()); err != nil {
+		t.Errorf("formatDeleteResults() = %v, want no error for a cluster with an open circuit", err)
+	}
+}
@@ -5,12 +5,13 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"text/tabwriter"
 	"time"
 
+	"github.com/aryankumar/fleet/internal/cli/target"
 	"github.com/aryankumar/fleet/internal/cluster"
 	"github.com/aryankumar/fleet/internal/config"
 	"github.com/aryankumar/fleet/internal/executor"
+	"github.com/aryankumar/fleet/internal/history"
 	"github.com/aryankumar/fleet/internal/output"
 	"github.com/aryankumar/fleet/internal/util"
 	"github.com/spf13/cobra"
@@ -84,6 +85,13 @@ func runGetDeployments(ctx context.Context, namespace string, allNamespaces bool
 		"namespace", queryNamespace,
 		"all_namespaces", allNamespaces)
 
+	// Load the effective fleet settings (flag > env > config file > default)
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	defaults := configManager.GetConfig().Defaults
+
 	// Load kubeconfig
 	kubeconfigPath := viper.GetString("kubeconfig")
 	loader := config.NewKubeconfigLoader(kubeconfigPath)
@@ -92,18 +100,9 @@ func runGetDeployments(ctx context.Context, namespace string, allNamespaces bool
 	mgr := cluster.NewManager(loader, logger)
 	defer mgr.Close()
 
-	// Determine which clusters to connect to
-	targetClusters := viper.GetStringSlice("clusters")
-	var err error
-
-	if len(targetClusters) == 0 {
-		err = mgr.ConnectAll(ctx)
-	} else {
-		err = mgr.Connect(ctx, targetClusters)
-	}
-
-	if err != nil {
-		logger.Warn("some cluster connections failed", "error", err)
+	// Connect to the targeted clusters
+	if err := target.Connect(ctx, mgr, logger); err != nil {
+		return err
 	}
 
 	if mgr.Count() == 0 {
@@ -113,37 +112,39 @@ func runGetDeployments(ctx context.Context, namespace string, allNamespaces bool
 	logger.Info("connected to clusters", "count", mgr.Count())
 
 	// Create executor pool
-	parallelism := viper.GetInt("parallel")
-	pool := executor.NewPool(parallelism, logger)
+	parallelism := defaults.Parallel
+	pool := executor.NewPool[[]DeploymentInfo](parallelism, logger)
+	if defaults.AdaptiveParallel {
+		pool.SetAdaptive(&executor.AdaptiveOptions{})
+	}
+	retry := executor.NewRetryPolicy(defaults.Retries)
 
 	// Submit tasks for each cluster
 	clients := mgr.GetAllClients()
 	for _, client := range clients {
-		clusterName := client.Name
-		clientset := client.Clientset
-
-		task := executor.Task{
-			ClusterName: clusterName,
-			Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
-				return getDeployments(ctx, clientset, queryNamespace, clusterName)
-			},
+		clusterNamespace := resolveNamespace(client, namespace, allNamespaces)
+
+		task := executor.Task[[]DeploymentInfo]{
+			Client:  client,
+			Timeout: client.EffectiveTimeout(defaults.Timeout),
+			Retry:   retry,
+			Execute: cluster.Guard(mgr, func(ctx context.Context, c *cluster.Client) ([]DeploymentInfo, error) {
+				return getDeployments(ctx, c.Clientset, clusterNamespace, c.Name)
+			}),
 		}
 
 		if err := pool.Submit(task); err != nil {
-			logger.Error("failed to submit task", "cluster", clusterName, "error", err)
+			logger.Error("failed to submit task", "cluster", client.Name, "error", err)
 			continue
 		}
 	}
 
-	// Execute tasks with timeout
-	timeout := viper.GetDuration("timeout")
-	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	// Each task's timeout runs from when it starts; --deadline bounds the run
+	execCtx, cancel := executor.WithRunDeadline(ctx, defaults.Deadline)
 	defer cancel()
 
-	results := pool.Execute(execCtx)
-
-	// Format and display results
-	return formatDeploymentResults(results)
+	// Each cluster's deployments are shown as soon as it answers
+	return writeResults(os.Stdout, history.Tee(ctx, pool.ExecuteStream(execCtx), nil), defaults, deploymentColumns)
 }
 
 func getDeployments(ctx context.Context, clientset kubernetes.Interface, namespace, clusterName string) ([]DeploymentInfo, error) {
@@ -180,89 +181,22 @@ func calculateDeploymentReady(deploy *appsv1.Deployment) string {
 	return fmt.Sprintf("%d/%d", ready, desired)
 }
 
-func formatDeploymentResults(results []executor.Result) error {
-	// Collect all deployments from successful results
-	var allDeployments []DeploymentInfo
-	var errors []string
-
-	for _, result := range results {
-		if result.Error != nil {
-			errors = append(errors, fmt.Sprintf("%s: %v", result.ClusterName, result.Error))
-			continue
-		}
-
-		if deployments, ok := result.Data.([]DeploymentInfo); ok {
-			allDeployments = append(allDeployments, deployments...)
-		}
-	}
-
-	// Print errors if any
-	if len(errors) > 0 {
-		for _, errMsg := range errors {
-			slog.Error("cluster query failed", "error", errMsg)
-		}
-	}
-
-	// Format output
-	outputFormat := viper.GetString("output")
-	noColor := viper.GetBool("no-color")
-
-	var format output.Format
-	switch outputFormat {
-	case "json":
-		format = output.FormatJSON
-	case "yaml":
-		format = output.FormatYAML
-	default:
-		format = output.FormatTable
-	}
-
-	formatter := output.NewFormatter(format, output.WithNoColor(noColor))
-
-	// For table format, create a custom table
-	if format == output.FormatTable {
-		return formatDeploymentsTable(allDeployments, noColor)
-	}
-
-	// For JSON/YAML, output the deployment data
-	return formatter.Format(os.Stdout, allDeployments)
+// deploymentColumns lays out the deployments table
+var deploymentColumns = &output.Columns{
+	Headers: []string{"CLUSTER", "NAMESPACE", "NAME", "READY", "UP-TO-DATE", "AVAILABLE", "AGE"},
+	Rows:    output.ItemRows(deploymentRow),
+	Noun:    "deployments",
 }
 
-func formatDeploymentsTable(deployments []DeploymentInfo, noColor bool) error {
-	if len(deployments) == 0 {
-		fmt.Println("No deployments found")
-		return nil
+// deploymentRow formats a deployment as a table row
+func deploymentRow(deploy DeploymentInfo, colors *output.ColorScheme) []string {
+	return []string{
+		colors.ClusterName(util.ShortClusterName(deploy.Cluster)),
+		deploy.Namespace,
+		deploy.Name,
+		deploy.Ready,
+		fmt.Sprintf("%d", deploy.UpToDate),
+		fmt.Sprintf("%d", deploy.Available),
+		deploy.Age,
 	}
-
-	colors := output.NewColorScheme(os.Stdout, noColor)
-
-	// Create tabwriter for aligned columns
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-
-	// Print header
-	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-		colors.Header("CLUSTER"),
-		colors.Header("NAMESPACE"),
-		colors.Header("NAME"),
-		colors.Header("READY"),
-		colors.Header("UP-TO-DATE"),
-		colors.Header("AVAILABLE"),
-		colors.Header("AGE"))
-
-	// Print rows
-	for _, deploy := range deployments {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
-			colors.ClusterName(util.ShortClusterName(deploy.Cluster)),
-			deploy.Namespace,
-			deploy.Name,
-			deploy.Ready,
-			deploy.UpToDate,
-			deploy.Available,
-			deploy.Age)
-	}
-
-	w.Flush()
-	fmt.Fprintf(os.Stdout, "\nTotal: %d deployments\n", len(deployments))
-
-	return nil
 }
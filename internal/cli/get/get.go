@@ -1,6 +1,15 @@
 package get
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/aryankumar/fleet/internal/cluster"
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/executor"
+	"github.com/aryankumar/fleet/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -26,8 +35,20 @@ with filtering options and formatted output.`,
   # Get deployments in JSON format
   fleet get deployments -o json
 
+  # Stream pods as NDJSON, one line per pod as each cluster answers
+  fleet get pods -A -o ndjson | jq -r .Name
+
   # Get nodes from specific clusters
-  fleet get nodes --clusters prod-east,prod-west`,
+  fleet get nodes --clusters prod-east,prod-west
+
+  # Get nodes from every cluster matching a glob, except one
+  fleet get nodes --clusters 'prod-*' --exclude-clusters prod-legacy
+
+  # Get pods from clusters labelled env=prod in the fleet config
+  fleet get pods --cluster-selector env=prod
+
+  # Get pods from the clusters in the "prod" fleet config group
+  fleet get pods --group prod`,
 	}
 
 	// Register all subcommands
@@ -39,3 +60,63 @@ with filtering options and formatted output.`,
 
 	return cmd
 }
+
+// resolveNamespace returns the namespace to query on a cluster
+// An explicit -n wins, then the cluster's namespace from the fleet config, then "default"
+func resolveNamespace(client *cluster.Client, namespace string, allNamespaces bool) string {
+	switch {
+	case allNamespaces:
+		return "" // Empty string means all namespaces in client-go
+	case namespace != "":
+		return namespace
+	case client.Namespace != "":
+		return client.Namespace
+	default:
+		return "default"
+	}
+}
+
+// writeResults writes the items from each cluster's results in the output
+// format from defaults. Table rows and NDJSON lines are written as each
+// cluster answers, so fast clusters aren't held back by the slowest one; JSON
+// and YAML are written once every cluster has answered. Failed clusters and
+// those skipped for an open circuit are logged.
+func writeResults[T any](w io.Writer, results <-chan executor.Result[[]T], defaults config.DefaultsConfig, columns *output.Columns) error {
+	format := output.Format(defaults.OutputFormat)
+	table := output.NewStreamFormatter(output.FormatTable, output.WithNoColor(defaults.NoColor), output.WithColumns(columns))
+	ndjson := output.NewNDJSONFormatter(nil)
+
+	var items []T
+	for result := range results {
+		if result.Status == executor.StatusSkipped || errors.Is(result.Error, cluster.ErrCircuitOpen) {
+			slog.Warn("cluster skipped", "cluster", result.ClusterName, "reason", result.Error)
+			continue
+		}
+		if result.Error != nil {
+			slog.Error("cluster query failed", "error", fmt.Sprintf("%s: %v", result.ClusterName, result.Error))
+			continue
+		}
+
+		var err error
+		switch format {
+		case output.FormatNDJSON:
+			err = ndjson.Format(w, result.Data)
+		case output.FormatJSON, output.FormatYAML:
+			items = append(items, result.Data...)
+		default:
+			err = table.WriteResult(w, result.Untyped())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	switch format {
+	case output.FormatNDJSON:
+		return nil
+	case output.FormatJSON, output.FormatYAML:
+		return output.NewFormatter(format).Format(w, items)
+	default:
+		return table.Finish(w)
+	}
+}
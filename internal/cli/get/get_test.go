@@ -3,10 +3,13 @@ package get
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/aryankumar/fleet/internal/cluster"
+	"github.com/aryankumar/fleet/internal/config"
 	"github.com/aryankumar/fleet/internal/executor"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -447,42 +450,90 @@ func TestGetNodeRoles(t *testing.T) {
 	}
 }
 
-// TestFormatPodsTable tests pod table formatting
-func TestFormatPodsTable(t *testing.T) {
-	tests := []struct {
-		name    string
-		pods    []PodInfo
-		noColor bool
-		wantErr bool
-	}{
-		{
-			name: "format multiple pods",
-			pods: []PodInfo{
-				{Cluster: "cluster1", Namespace: "default", Name: "pod1", Ready: "1/1", Status: "Running", Restarts: 0, Age: "1h"},
-				{Cluster: "cluster2", Namespace: "default", Name: "pod2", Ready: "2/2", Status: "Running", Restarts: 1, Age: "2h"},
-			},
-			noColor: true,
-			wantErr: false,
+// TestWriteResults_Table tests that pod rows are written as each cluster's
+// results arrive, followed by the total
+func TestWriteResults_Table(t *testing.T) {
+	results := make(chan executor.Result[[]PodInfo], 2)
+	results <- executor.Result[[]PodInfo]{
+		ClusterName: "cluster1",
+		Data: []PodInfo{
+			{Cluster: "cluster1", Namespace: "default", Name: "pod1", Ready: "1/1", Status: "Running", Restarts: 0, Age: "1h"},
+			{Cluster: "cluster1", Namespace: "default", Name: "pod2", Ready: "2/2", Status: "Running", Restarts: 1, Age: "2h"},
 		},
-		{
-			name:    "format empty pods",
-			pods:    []PodInfo{},
-			noColor: true,
-			wantErr: false,
+	}
+	results <- executor.Result[[]PodInfo]{ClusterName: "cluster2", Error: context.DeadlineExceeded}
+	close(results)
+
+	buf := &bytes.Buffer{}
+	if err := writeResults(buf, results, config.DefaultsConfig{OutputFormat: "table", NoColor: true}, podColumns); err != nil {
+		t.Fatalf("writeResults() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[0], "CLUSTER") || lines[4] != "Total: 2 pods" {
+		t.Fatalf("unexpected table:\n%s", buf.String())
+	}
+	if fields := strings.Fields(lines[2]); len(fields) != 7 || fields[2] != "pod2" || fields[5] != "1" {
+		t.Errorf("row = %q, want the pod2 columns", lines[2])
+	}
+
+	// A failed, skipped or empty cluster leaves no table behind
+	empty := make(chan executor.Result[[]PodInfo], 2)
+	empty <- executor.Result[[]PodInfo]{ClusterName: "cluster2", Error: context.DeadlineExceeded}
+	empty <- executor.Result[[]PodInfo]{ClusterName: "cluster3", Error: &cluster.CircuitOpenError{Cluster: "cluster3"}, Status: executor.StatusSkipped}
+	close(empty)
+
+	buf.Reset()
+	if err := writeResults(buf, empty, config.DefaultsConfig{NoColor: true}, podColumns); err != nil {
+		t.Fatalf("writeResults() error = %v", err)
+	}
+	if buf.String() != "No pods found\n" {
+		t.Errorf("output = %q, want No pods found", buf.String())
+	}
+}
+
+// TestWriteResults_NDJSON tests that each item is written as one line as results arrive
+func TestWriteResults_NDJSON(t *testing.T) {
+	results := make(chan executor.Result[[]PodInfo], 2)
+	results <- executor.Result[[]PodInfo]{
+		ClusterName: "cluster1",
+		Data: []PodInfo{
+			{Cluster: "cluster1", Namespace: "default", Name: "pod1"},
+			{Cluster: "cluster1", Namespace: "default", Name: "pod2"},
 		},
 	}
+	results <- executor.Result[[]PodInfo]{ClusterName: "cluster2", Error: context.DeadlineExceeded}
+	close(results)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			buf := &bytes.Buffer{}
-			// Redirect output to buffer by temporarily changing os.Stdout
-			// For this test, we'll just call the function and verify it doesn't error
-			err := formatPodsTable(tt.pods, tt.noColor)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("formatPodsTable() error = %v, wantErr %v", err, tt.wantErr)
-			}
-			_ = buf // Use buf to avoid unused variable error
-		})
+	buf := &bytes.Buffer{}
+	if err := writeResults(buf, results, config.DefaultsConfig{OutputFormat: "ndjson"}, podColumns); err != nil {
+		t.Fatalf("writeResults() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want one per pod from the successful cluster:\n%s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], `"Name":"pod1"`) || !strings.Contains(lines[1], `"Name":"pod2"`) {
+		t.Errorf("unexpected lines:\n%s", buf.String())
+	}
+}
+
+// TestWriteResults_JSON tests that JSON is one array of every cluster's items
+func TestWriteResults_JSON(t *testing.T) {
+	results := make(chan executor.Result[[]PodInfo], 2)
+	results <- executor.Result[[]PodInfo]{ClusterName: "cluster1", Data: []PodInfo{{Cluster: "cluster1", Name: "pod1"}}}
+	results <- executor.Result[[]PodInfo]{ClusterName: "cluster2", Data: []PodInfo{{Cluster: "cluster2", Name: "pod2"}}}
+	close(results)
+
+	buf := &bytes.Buffer{}
+	if err := writeResults(buf, results, config.DefaultsConfig{OutputFormat: "json"}, podColumns); err != nil {
+		t.Fatalf("writeResults() error = %v", err)
+	}
+
+	var pods []PodInfo
+	if err := json.Unmarshal(buf.Bytes(), &pods); err != nil || len(pods) != 2 {
+		t.Errorf("output = %s (%v), want an array of both pods", buf.String(), err)
 	}
 }
 
@@ -630,20 +681,20 @@ func TestMultiClusterIntegration(t *testing.T) {
 	)
 
 	// Create executor pool
-	pool := executor.NewPool(2, nil)
+	pool := executor.NewPool[[]PodInfo](2, nil)
 
 	// Submit tasks
-	task1 := executor.Task{
-		ClusterName: "cluster1",
-		Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
-			return getPods(ctx, cluster1, "default", "", "cluster1", false)
+	task1 := executor.Task[[]PodInfo]{
+		Client: &cluster.Client{Name: "cluster1", Clientset: cluster1},
+		Execute: func(ctx context.Context, c *cluster.Client) ([]PodInfo, error) {
+			return getPods(ctx, c.Clientset, "default", "", c.Name, false)
 		},
 	}
 
-	task2 := executor.Task{
-		ClusterName: "cluster2",
-		Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
-			return getPods(ctx, cluster2, "default", "", "cluster2", false)
+	task2 := executor.Task[[]PodInfo]{
+		Client: &cluster.Client{Name: "cluster2", Clientset: cluster2},
+		Execute: func(ctx context.Context, c *cluster.Client) ([]PodInfo, error) {
+			return getPods(ctx, c.Clientset, "default", "", c.Name, false)
 		},
 	}
 
@@ -669,14 +720,8 @@ func TestMultiClusterIntegration(t *testing.T) {
 			t.Errorf("cluster %s returned error: %v", result.ClusterName, result.Error)
 		}
 
-		pods, ok := result.Data.([]PodInfo)
-		if !ok {
-			t.Errorf("expected []PodInfo, got %T", result.Data)
-			continue
-		}
-
-		if len(pods) != 1 {
-			t.Errorf("expected 1 pod for %s, got %d", result.ClusterName, len(pods))
+		if len(result.Data) != 1 {
+			t.Errorf("expected 1 pod for %s, got %d", result.ClusterName, len(result.Data))
 		}
 	}
 }
@@ -691,22 +736,22 @@ func TestPartialFailure(t *testing.T) {
 	// Use a fake client that will work
 	failClient := fake.NewSimpleClientset()
 
-	pool := executor.NewPool(2, nil)
+	pool := executor.NewPool[[]PodInfo](2, nil)
 
 	// Submit successful task
-	task1 := executor.Task{
-		ClusterName: "success-cluster",
-		Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
-			return getPods(ctx, successClient, "default", "", "success-cluster", false)
+	task1 := executor.Task[[]PodInfo]{
+		Client: &cluster.Client{Name: "success-cluster", Clientset: successClient},
+		Execute: func(ctx context.Context, c *cluster.Client) ([]PodInfo, error) {
+			return getPods(ctx, c.Clientset, "default", "", c.Name, false)
 		},
 	}
 
 	// Submit task that queries non-existent namespace (simulating failure)
-	task2 := executor.Task{
-		ClusterName: "fail-cluster",
-		Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
+	task2 := executor.Task[[]PodInfo]{
+		Client: &cluster.Client{Name: "fail-cluster", Clientset: failClient},
+		Execute: func(ctx context.Context, c *cluster.Client) ([]PodInfo, error) {
 			// This should succeed with empty result
-			return getPods(ctx, failClient, "nonexistent", "", "fail-cluster", false)
+			return getPods(ctx, c.Clientset, "nonexistent", "", c.Name, false)
 		},
 	}
 
@@ -760,3 +805,29 @@ func BenchmarkCalculateAge(b *testing.B) {
 		_ = calculateAge(created, now)
 	}
 }
+
+func TestResolveNamespace(t *testing.T) {
+	withDefault := &cluster.Client{Name: "airgap", Namespace: "platform"}
+	plain := &cluster.Client{Name: "plain"}
+
+	tests := []struct {
+		name          string
+		client        *cluster.Client
+		namespace     string
+		allNamespaces bool
+		want          string
+	}{
+		{name: "all namespaces", client: withDefault, allNamespaces: true, want: ""},
+		{name: "explicit namespace wins", client: withDefault, namespace: "kube-system", want: "kube-system"},
+		{name: "cluster default namespace", client: withDefault, want: "platform"},
+		{name: "fallback to default", client: plain, want: "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveNamespace(tt.client, tt.namespace, tt.allNamespaces); got != tt.want {
+				t.Errorf("resolveNamespace() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
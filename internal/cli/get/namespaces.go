@@ -5,12 +5,13 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"text/tabwriter"
 	"time"
 
+	"github.com/aryankumar/fleet/internal/cli/target"
 	"github.com/aryankumar/fleet/internal/cluster"
 	"github.com/aryankumar/fleet/internal/config"
 	"github.com/aryankumar/fleet/internal/executor"
+	"github.com/aryankumar/fleet/internal/history"
 	"github.com/aryankumar/fleet/internal/output"
 	"github.com/aryankumar/fleet/internal/util"
 	"github.com/spf13/cobra"
@@ -58,6 +59,13 @@ func runGetNamespaces(ctx context.Context) error {
 
 	logger.Debug("getting namespaces")
 
+	// Load the effective fleet settings (flag > env > config file > default)
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	defaults := configManager.GetConfig().Defaults
+
 	// Load kubeconfig
 	kubeconfigPath := viper.GetString("kubeconfig")
 	loader := config.NewKubeconfigLoader(kubeconfigPath)
@@ -66,18 +74,9 @@ func runGetNamespaces(ctx context.Context) error {
 	mgr := cluster.NewManager(loader, logger)
 	defer mgr.Close()
 
-	// Determine which clusters to connect to
-	targetClusters := viper.GetStringSlice("clusters")
-	var err error
-
-	if len(targetClusters) == 0 {
-		err = mgr.ConnectAll(ctx)
-	} else {
-		err = mgr.Connect(ctx, targetClusters)
-	}
-
-	if err != nil {
-		logger.Warn("some cluster connections failed", "error", err)
+	// Connect to the targeted clusters
+	if err := target.Connect(ctx, mgr, logger); err != nil {
+		return err
 	}
 
 	if mgr.Count() == 0 {
@@ -87,37 +86,37 @@ func runGetNamespaces(ctx context.Context) error {
 	logger.Info("connected to clusters", "count", mgr.Count())
 
 	// Create executor pool
-	parallelism := viper.GetInt("parallel")
-	pool := executor.NewPool(parallelism, logger)
+	parallelism := defaults.Parallel
+	pool := executor.NewPool[[]NamespaceInfo](parallelism, logger)
+	if defaults.AdaptiveParallel {
+		pool.SetAdaptive(&executor.AdaptiveOptions{})
+	}
+	retry := executor.NewRetryPolicy(defaults.Retries)
 
 	// Submit tasks for each cluster
 	clients := mgr.GetAllClients()
 	for _, client := range clients {
-		clusterName := client.Name
-		clientset := client.Clientset
-
-		task := executor.Task{
-			ClusterName: clusterName,
-			Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
-				return getNamespaces(ctx, clientset, clusterName)
-			},
+		task := executor.Task[[]NamespaceInfo]{
+			Client:  client,
+			Timeout: client.EffectiveTimeout(defaults.Timeout),
+			Retry:   retry,
+			Execute: cluster.Guard(mgr, func(ctx context.Context, c *cluster.Client) ([]NamespaceInfo, error) {
+				return getNamespaces(ctx, c.Clientset, c.Name)
+			}),
 		}
 
 		if err := pool.Submit(task); err != nil {
-			logger.Error("failed to submit task", "cluster", clusterName, "error", err)
+			logger.Error("failed to submit task", "cluster", client.Name, "error", err)
 			continue
 		}
 	}
 
-	// Execute tasks with timeout
-	timeout := viper.GetDuration("timeout")
-	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	// Each task's timeout runs from when it starts; --deadline bounds the run
+	execCtx, cancel := executor.WithRunDeadline(ctx, defaults.Deadline)
 	defer cancel()
 
-	results := pool.Execute(execCtx)
-
-	// Format and display results
-	return formatNamespaceResults(results)
+	// Each cluster's namespaces are shown as soon as it answers
+	return writeResults(os.Stdout, history.Tee(ctx, pool.ExecuteStream(execCtx), nil), defaults, namespaceColumns)
 }
 
 func getNamespaces(ctx context.Context, clientset kubernetes.Interface, clusterName string) ([]NamespaceInfo, error) {
@@ -142,88 +141,24 @@ func getNamespaces(ctx context.Context, clientset kubernetes.Interface, clusterN
 	return namespaces, nil
 }
 
-func formatNamespaceResults(results []executor.Result) error {
-	// Collect all namespaces from successful results
-	var allNamespaces []NamespaceInfo
-	var errors []string
-
-	for _, result := range results {
-		if result.Error != nil {
-			errors = append(errors, fmt.Sprintf("%s: %v", result.ClusterName, result.Error))
-			continue
-		}
-
-		if namespaces, ok := result.Data.([]NamespaceInfo); ok {
-			allNamespaces = append(allNamespaces, namespaces...)
-		}
-	}
-
-	// Print errors if any
-	if len(errors) > 0 {
-		for _, errMsg := range errors {
-			slog.Error("cluster query failed", "error", errMsg)
-		}
-	}
-
-	// Format output
-	outputFormat := viper.GetString("output")
-	noColor := viper.GetBool("no-color")
-
-	var format output.Format
-	switch outputFormat {
-	case "json":
-		format = output.FormatJSON
-	case "yaml":
-		format = output.FormatYAML
-	default:
-		format = output.FormatTable
-	}
-
-	formatter := output.NewFormatter(format, output.WithNoColor(noColor))
-
-	// For table format, create a custom table
-	if format == output.FormatTable {
-		return formatNamespacesTable(allNamespaces, noColor)
-	}
-
-	// For JSON/YAML, output the namespace data
-	return formatter.Format(os.Stdout, allNamespaces)
+// namespaceColumns lays out the namespaces table
+var namespaceColumns = &output.Columns{
+	Headers: []string{"CLUSTER", "NAME", "STATUS", "AGE"},
+	Rows:    output.ItemRows(namespaceRow),
+	Noun:    "namespaces",
 }
 
-func formatNamespacesTable(namespaces []NamespaceInfo, noColor bool) error {
-	if len(namespaces) == 0 {
-		fmt.Println("No namespaces found")
-		return nil
+// namespaceRow formats a namespace as a table row
+func namespaceRow(ns NamespaceInfo, colors *output.ColorScheme) []string {
+	statusColor := colors.Success
+	if ns.Status != "Active" {
+		statusColor = colors.Warning
 	}
 
-	colors := output.NewColorScheme(os.Stdout, noColor)
-
-	// Create tabwriter for aligned columns
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-
-	// Print header
-	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-		colors.Header("CLUSTER"),
-		colors.Header("NAME"),
-		colors.Header("STATUS"),
-		colors.Header("AGE"))
-
-	// Print rows
-	for _, ns := range namespaces {
-		statusColor := colors.Success
-		if ns.Status != "Active" {
-			statusColor = colors.Warning
-		}
-
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-			colors.ClusterName(util.ShortClusterName(ns.Cluster)),
-			ns.Name,
-			statusColor(ns.Status),
-			ns.Age)
+	return []string{
+		colors.ClusterName(util.ShortClusterName(ns.Cluster)),
+		ns.Name,
+		statusColor(ns.Status),
+		ns.Age,
 	}
-
-	w.Flush()
-	fmt.Fprintf(os.Stdout, "\nTotal: %d namespaces\n", len(namespaces))
-
-	return nil
 }
@@ -6,12 +6,13 @@ import (
 	"log/slog"
 	"os"
 	"strings"
-	"text/tabwriter"
 	"time"
 
+	"github.com/aryankumar/fleet/internal/cli/target"
 	"github.com/aryankumar/fleet/internal/cluster"
 	"github.com/aryankumar/fleet/internal/config"
 	"github.com/aryankumar/fleet/internal/executor"
+	"github.com/aryankumar/fleet/internal/history"
 	"github.com/aryankumar/fleet/internal/output"
 	"github.com/aryankumar/fleet/internal/util"
 	"github.com/spf13/cobra"
@@ -61,6 +62,13 @@ func runGetNodes(ctx context.Context) error {
 
 	logger.Debug("getting nodes")
 
+	// Load the effective fleet settings (flag > env > config file > default)
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	defaults := configManager.GetConfig().Defaults
+
 	// Load kubeconfig
 	kubeconfigPath := viper.GetString("kubeconfig")
 	loader := config.NewKubeconfigLoader(kubeconfigPath)
@@ -69,18 +77,9 @@ func runGetNodes(ctx context.Context) error {
 	mgr := cluster.NewManager(loader, logger)
 	defer mgr.Close()
 
-	// Determine which clusters to connect to
-	targetClusters := viper.GetStringSlice("clusters")
-	var err error
-
-	if len(targetClusters) == 0 {
-		err = mgr.ConnectAll(ctx)
-	} else {
-		err = mgr.Connect(ctx, targetClusters)
-	}
-
-	if err != nil {
-		logger.Warn("some cluster connections failed", "error", err)
+	// Connect to the targeted clusters
+	if err := target.Connect(ctx, mgr, logger); err != nil {
+		return err
 	}
 
 	if mgr.Count() == 0 {
@@ -90,37 +89,37 @@ func runGetNodes(ctx context.Context) error {
 	logger.Info("connected to clusters", "count", mgr.Count())
 
 	// Create executor pool
-	parallelism := viper.GetInt("parallel")
-	pool := executor.NewPool(parallelism, logger)
+	parallelism := defaults.Parallel
+	pool := executor.NewPool[[]NodeInfo](parallelism, logger)
+	if defaults.AdaptiveParallel {
+		pool.SetAdaptive(&executor.AdaptiveOptions{})
+	}
+	retry := executor.NewRetryPolicy(defaults.Retries)
 
 	// Submit tasks for each cluster
 	clients := mgr.GetAllClients()
 	for _, client := range clients {
-		clusterName := client.Name
-		clientset := client.Clientset
-
-		task := executor.Task{
-			ClusterName: clusterName,
-			Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
-				return getNodes(ctx, clientset, clusterName)
-			},
+		task := executor.Task[[]NodeInfo]{
+			Client:  client,
+			Timeout: client.EffectiveTimeout(defaults.Timeout),
+			Retry:   retry,
+			Execute: cluster.Guard(mgr, func(ctx context.Context, c *cluster.Client) ([]NodeInfo, error) {
+				return getNodes(ctx, c.Clientset, c.Name)
+			}),
 		}
 
 		if err := pool.Submit(task); err != nil {
-			logger.Error("failed to submit task", "cluster", clusterName, "error", err)
+			logger.Error("failed to submit task", "cluster", client.Name, "error", err)
 			continue
 		}
 	}
 
-	// Execute tasks with timeout
-	timeout := viper.GetDuration("timeout")
-	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	// Each task's timeout runs from when it starts; --deadline bounds the run
+	execCtx, cancel := executor.WithRunDeadline(ctx, defaults.Deadline)
 	defer cancel()
 
-	results := pool.Execute(execCtx)
-
-	// Format and display results
-	return formatNodeResults(results)
+	// Each cluster's nodes are shown as soon as it answers
+	return writeResults(os.Stdout, history.Tee(ctx, pool.ExecuteStream(execCtx), nil), defaults, nodeColumns)
 }
 
 func getNodes(ctx context.Context, clientset kubernetes.Interface, clusterName string) ([]NodeInfo, error) {
@@ -179,92 +178,26 @@ func getNodeRoles(node *corev1.Node) string {
 	return strings.Join(roles, ",")
 }
 
-func formatNodeResults(results []executor.Result) error {
-	// Collect all nodes from successful results
-	var allNodes []NodeInfo
-	var errors []string
-
-	for _, result := range results {
-		if result.Error != nil {
-			errors = append(errors, fmt.Sprintf("%s: %v", result.ClusterName, result.Error))
-			continue
-		}
-
-		if nodes, ok := result.Data.([]NodeInfo); ok {
-			allNodes = append(allNodes, nodes...)
-		}
-	}
-
-	// Print errors if any
-	if len(errors) > 0 {
-		for _, errMsg := range errors {
-			slog.Error("cluster query failed", "error", errMsg)
-		}
-	}
-
-	// Format output
-	outputFormat := viper.GetString("output")
-	noColor := viper.GetBool("no-color")
-
-	var format output.Format
-	switch outputFormat {
-	case "json":
-		format = output.FormatJSON
-	case "yaml":
-		format = output.FormatYAML
-	default:
-		format = output.FormatTable
-	}
-
-	formatter := output.NewFormatter(format, output.WithNoColor(noColor))
-
-	// For table format, create a custom table
-	if format == output.FormatTable {
-		return formatNodesTable(allNodes, noColor)
-	}
-
-	// For JSON/YAML, output the node data
-	return formatter.Format(os.Stdout, allNodes)
+// nodeColumns lays out the nodes table
+var nodeColumns = &output.Columns{
+	Headers: []string{"CLUSTER", "NAME", "STATUS", "ROLES", "AGE", "VERSION"},
+	Rows:    output.ItemRows(nodeRow),
+	Noun:    "nodes",
 }
 
-func formatNodesTable(nodes []NodeInfo, noColor bool) error {
-	if len(nodes) == 0 {
-		fmt.Println("No nodes found")
-		return nil
+// nodeRow formats a node as a table row
+func nodeRow(node NodeInfo, colors *output.ColorScheme) []string {
+	statusColor := colors.Success
+	if node.Status != "Ready" {
+		statusColor = colors.Error
 	}
 
-	colors := output.NewColorScheme(os.Stdout, noColor)
-
-	// Create tabwriter for aligned columns
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-
-	// Print header
-	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
-		colors.Header("CLUSTER"),
-		colors.Header("NAME"),
-		colors.Header("STATUS"),
-		colors.Header("ROLES"),
-		colors.Header("AGE"),
-		colors.Header("VERSION"))
-
-	// Print rows
-	for _, node := range nodes {
-		statusColor := colors.Success
-		if node.Status != "Ready" {
-			statusColor = colors.Error
-		}
-
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
-			colors.ClusterName(util.ShortClusterName(node.Cluster)),
-			node.Name,
-			statusColor(node.Status),
-			node.Roles,
-			node.Age,
-			node.Version)
+	return []string{
+		colors.ClusterName(util.ShortClusterName(node.Cluster)),
+		node.Name,
+		statusColor(node.Status),
+		node.Roles,
+		node.Age,
+		node.Version,
 	}
-
-	w.Flush()
-	fmt.Fprintf(os.Stdout, "\nTotal: %d nodes\n", len(nodes))
-
-	return nil
 }
@@ -5,12 +5,13 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"text/tabwriter"
 	"time"
 
+	"github.com/aryankumar/fleet/internal/cli/target"
 	"github.com/aryankumar/fleet/internal/cluster"
 	"github.com/aryankumar/fleet/internal/config"
 	"github.com/aryankumar/fleet/internal/executor"
+	"github.com/aryankumar/fleet/internal/history"
 	"github.com/aryankumar/fleet/internal/output"
 	"github.com/aryankumar/fleet/internal/util"
 	"github.com/spf13/cobra"
@@ -89,6 +90,13 @@ func runGetPods(ctx context.Context, namespace, selector string, allNamespaces b
 		"selector", selector,
 		"all_namespaces", allNamespaces)
 
+	// Load the effective fleet settings (flag > env > config file > default)
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	defaults := configManager.GetConfig().Defaults
+
 	// Load kubeconfig
 	kubeconfigPath := viper.GetString("kubeconfig")
 	loader := config.NewKubeconfigLoader(kubeconfigPath)
@@ -97,21 +105,9 @@ func runGetPods(ctx context.Context, namespace, selector string, allNamespaces b
 	mgr := cluster.NewManager(loader, logger)
 	defer mgr.Close()
 
-	// Determine which clusters to connect to
-	targetClusters := viper.GetStringSlice("clusters")
-	var err error
-
-	if len(targetClusters) == 0 {
-		// Connect to all clusters
-		err = mgr.ConnectAll(ctx)
-	} else {
-		// Connect to specific clusters
-		err = mgr.Connect(ctx, targetClusters)
-	}
-
-	if err != nil {
-		// Continue with partial connections
-		logger.Warn("some cluster connections failed", "error", err)
+	// Connect to the targeted clusters
+	if err := target.Connect(ctx, mgr, logger); err != nil {
+		return err
 	}
 
 	if mgr.Count() == 0 {
@@ -121,38 +117,40 @@ func runGetPods(ctx context.Context, namespace, selector string, allNamespaces b
 	logger.Info("connected to clusters", "count", mgr.Count())
 
 	// Create executor pool
-	parallelism := viper.GetInt("parallel")
-	pool := executor.NewPool(parallelism, logger)
+	parallelism := defaults.Parallel
+	pool := executor.NewPool[[]PodInfo](parallelism, logger)
+	if defaults.AdaptiveParallel {
+		pool.SetAdaptive(&executor.AdaptiveOptions{})
+	}
+	retry := executor.NewRetryPolicy(defaults.Retries)
 
 	// Submit tasks for each cluster
 	clients := mgr.GetAllClients()
 	for _, client := range clients {
 		// Capture variables for closure
-		clusterName := client.Name
-		clientset := client.Clientset
-
-		task := executor.Task{
-			ClusterName: clusterName,
-			Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
-				return getPods(ctx, clientset, queryNamespace, selector, clusterName, allNamespaces)
-			},
+		clusterNamespace := resolveNamespace(client, namespace, allNamespaces)
+
+		task := executor.Task[[]PodInfo]{
+			Client:  client,
+			Timeout: client.EffectiveTimeout(defaults.Timeout),
+			Retry:   retry,
+			Execute: cluster.Guard(mgr, func(ctx context.Context, c *cluster.Client) ([]PodInfo, error) {
+				return getPods(ctx, c.Clientset, clusterNamespace, selector, c.Name, allNamespaces)
+			}),
 		}
 
 		if err := pool.Submit(task); err != nil {
-			logger.Error("failed to submit task", "cluster", clusterName, "error", err)
+			logger.Error("failed to submit task", "cluster", client.Name, "error", err)
 			continue
 		}
 	}
 
-	// Execute tasks with timeout
-	timeout := viper.GetDuration("timeout")
-	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	// Each task's timeout runs from when it starts; --deadline bounds the run
+	execCtx, cancel := executor.WithRunDeadline(ctx, defaults.Deadline)
 	defer cancel()
 
-	results := pool.Execute(execCtx)
-
-	// Format and display results
-	return formatPodResults(results)
+	// Each cluster's pods are shown as soon as it answers
+	return writeResults(os.Stdout, history.Tee(ctx, pool.ExecuteStream(execCtx), nil), defaults, podColumns)
 }
 
 func getPods(ctx context.Context, clientset kubernetes.Interface, namespace, selector, clusterName string, allNamespaces bool) ([]PodInfo, error) {
@@ -220,96 +218,30 @@ func calculateAge(created time.Time, now time.Time) string {
 	}
 }
 
-func formatPodResults(results []executor.Result) error {
-	// Collect all pods from successful results
-	var allPods []PodInfo
-	var errors []string
-
-	for _, result := range results {
-		if result.Error != nil {
-			errors = append(errors, fmt.Sprintf("%s: %v", result.ClusterName, result.Error))
-			continue
-		}
-
-		if pods, ok := result.Data.([]PodInfo); ok {
-			allPods = append(allPods, pods...)
-		}
-	}
-
-	// Print errors if any
-	if len(errors) > 0 {
-		for _, errMsg := range errors {
-			slog.Error("cluster query failed", "error", errMsg)
-		}
-	}
-
-	// Format output
-	outputFormat := viper.GetString("output")
-	noColor := viper.GetBool("no-color")
-
-	var format output.Format
-	switch outputFormat {
-	case "json":
-		format = output.FormatJSON
-	case "yaml":
-		format = output.FormatYAML
-	default:
-		format = output.FormatTable
-	}
-
-	formatter := output.NewFormatter(format, output.WithNoColor(noColor))
-
-	// For table format, create a custom table
-	if format == output.FormatTable {
-		return formatPodsTable(allPods, noColor)
-	}
-
-	// For JSON/YAML, output the pod data
-	return formatter.Format(os.Stdout, allPods)
+// podColumns lays out the pods table
+var podColumns = &output.Columns{
+	Headers: []string{"CLUSTER", "NAMESPACE", "NAME", "READY", "STATUS", "RESTARTS", "AGE"},
+	Rows:    output.ItemRows(podRow),
+	Noun:    "pods",
 }
 
-func formatPodsTable(pods []PodInfo, noColor bool) error {
-	if len(pods) == 0 {
-		fmt.Println("No pods found")
-		return nil
+// podRow formats a pod as a table row
+func podRow(pod PodInfo, colors *output.ColorScheme) []string {
+	statusColor := colors.Success
+	if pod.Status != "Running" {
+		statusColor = colors.Warning
 	}
-
-	colors := output.NewColorScheme(os.Stdout, noColor)
-
-	// Create tabwriter for aligned columns
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-
-	// Print header
-	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-		colors.Header("CLUSTER"),
-		colors.Header("NAMESPACE"),
-		colors.Header("NAME"),
-		colors.Header("READY"),
-		colors.Header("STATUS"),
-		colors.Header("RESTARTS"),
-		colors.Header("AGE"))
-
-	for _, pod := range pods {
-		statusColor := colors.Success
-		if pod.Status != "Running" {
-			statusColor = colors.Warning
-		}
-		if pod.Status == "Failed" || pod.Status == "Unknown" {
-			statusColor = colors.Error
-		}
-
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
-			colors.ClusterName(util.ShortClusterName(pod.Cluster)),
-			pod.Namespace,
-			pod.Name,
-			pod.Ready,
-			statusColor(pod.Status),
-			pod.Restarts,
-			pod.Age)
+	if pod.Status == "Failed" || pod.Status == "Unknown" {
+		statusColor = colors.Error
 	}
 
-	w.Flush()
-	fmt.Fprintf(os.Stdout, "\nTotal: %d pods\n", len(pods))
-
-	return nil
+	return []string{
+		colors.ClusterName(util.ShortClusterName(pod.Cluster)),
+		pod.Namespace,
+		pod.Name,
+		pod.Ready,
+		statusColor(pod.Status),
+		fmt.Sprintf("%d", pod.Restarts),
+		pod.Age,
+	}
 }
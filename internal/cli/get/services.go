@@ -6,12 +6,13 @@ import (
 	"log/slog"
 	"os"
 	"strings"
-	"text/tabwriter"
 	"time"
 
+	"github.com/aryankumar/fleet/internal/cli/target"
 	"github.com/aryankumar/fleet/internal/cluster"
 	"github.com/aryankumar/fleet/internal/config"
 	"github.com/aryankumar/fleet/internal/executor"
+	"github.com/aryankumar/fleet/internal/history"
 	"github.com/aryankumar/fleet/internal/output"
 	"github.com/aryankumar/fleet/internal/util"
 	"github.com/spf13/cobra"
@@ -86,6 +87,13 @@ func runGetServices(ctx context.Context, namespace string, allNamespaces bool) e
 		"namespace", queryNamespace,
 		"all_namespaces", allNamespaces)
 
+	// Load the effective fleet settings (flag > env > config file > default)
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	defaults := configManager.GetConfig().Defaults
+
 	// Load kubeconfig
 	kubeconfigPath := viper.GetString("kubeconfig")
 	loader := config.NewKubeconfigLoader(kubeconfigPath)
@@ -94,18 +102,9 @@ func runGetServices(ctx context.Context, namespace string, allNamespaces bool) e
 	mgr := cluster.NewManager(loader, logger)
 	defer mgr.Close()
 
-	// Determine which clusters to connect to
-	targetClusters := viper.GetStringSlice("clusters")
-	var err error
-
-	if len(targetClusters) == 0 {
-		err = mgr.ConnectAll(ctx)
-	} else {
-		err = mgr.Connect(ctx, targetClusters)
-	}
-
-	if err != nil {
-		logger.Warn("some cluster connections failed", "error", err)
+	// Connect to the targeted clusters
+	if err := target.Connect(ctx, mgr, logger); err != nil {
+		return err
 	}
 
 	if mgr.Count() == 0 {
@@ -115,37 +114,39 @@ func runGetServices(ctx context.Context, namespace string, allNamespaces bool) e
 	logger.Info("connected to clusters", "count", mgr.Count())
 
 	// Create executor pool
-	parallelism := viper.GetInt("parallel")
-	pool := executor.NewPool(parallelism, logger)
+	parallelism := defaults.Parallel
+	pool := executor.NewPool[[]ServiceInfo](parallelism, logger)
+	if defaults.AdaptiveParallel {
+		pool.SetAdaptive(&executor.AdaptiveOptions{})
+	}
+	retry := executor.NewRetryPolicy(defaults.Retries)
 
 	// Submit tasks for each cluster
 	clients := mgr.GetAllClients()
 	for _, client := range clients {
-		clusterName := client.Name
-		clientset := client.Clientset
-
-		task := executor.Task{
-			ClusterName: clusterName,
-			Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
-				return getServices(ctx, clientset, queryNamespace, clusterName)
-			},
+		clusterNamespace := resolveNamespace(client, namespace, allNamespaces)
+
+		task := executor.Task[[]ServiceInfo]{
+			Client:  client,
+			Timeout: client.EffectiveTimeout(defaults.Timeout),
+			Retry:   retry,
+			Execute: cluster.Guard(mgr, func(ctx context.Context, c *cluster.Client) ([]ServiceInfo, error) {
+				return getServices(ctx, c.Clientset, clusterNamespace, c.Name)
+			}),
 		}
 
 		if err := pool.Submit(task); err != nil {
-			logger.Error("failed to submit task", "cluster", clusterName, "error", err)
+			logger.Error("failed to submit task", "cluster", client.Name, "error", err)
 			continue
 		}
 	}
 
-	// Execute tasks with timeout
-	timeout := viper.GetDuration("timeout")
-	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	// Each task's timeout runs from when it starts; --deadline bounds the run
+	execCtx, cancel := executor.WithRunDeadline(ctx, defaults.Deadline)
 	defer cancel()
 
-	results := pool.Execute(execCtx)
-
-	// Format and display results
-	return formatServiceResults(results)
+	// Each cluster's services are shown as soon as it answers
+	return writeResults(os.Stdout, history.Tee(ctx, pool.ExecuteStream(execCtx), nil), defaults, serviceColumns)
 }
 
 func getServices(ctx context.Context, clientset kubernetes.Interface, namespace, clusterName string) ([]ServiceInfo, error) {
@@ -224,91 +225,23 @@ func getServicePorts(svc *corev1.Service) string {
 	return strings.Join(ports, ",")
 }
 
-func formatServiceResults(results []executor.Result) error {
-	// Collect all services from successful results
-	var allServices []ServiceInfo
-	var errors []string
-
-	for _, result := range results {
-		if result.Error != nil {
-			errors = append(errors, fmt.Sprintf("%s: %v", result.ClusterName, result.Error))
-			continue
-		}
-
-		if services, ok := result.Data.([]ServiceInfo); ok {
-			allServices = append(allServices, services...)
-		}
-	}
-
-	// Print errors if any
-	if len(errors) > 0 {
-		for _, errMsg := range errors {
-			slog.Error("cluster query failed", "error", errMsg)
-		}
-	}
-
-	// Format output
-	outputFormat := viper.GetString("output")
-	noColor := viper.GetBool("no-color")
-
-	var format output.Format
-	switch outputFormat {
-	case "json":
-		format = output.FormatJSON
-	case "yaml":
-		format = output.FormatYAML
-	default:
-		format = output.FormatTable
-	}
-
-	formatter := output.NewFormatter(format, output.WithNoColor(noColor))
-
-	// For table format, create a custom table
-	if format == output.FormatTable {
-		return formatServicesTable(allServices, noColor)
-	}
-
-	// For JSON/YAML, output the service data
-	return formatter.Format(os.Stdout, allServices)
+// serviceColumns lays out the services table
+var serviceColumns = &output.Columns{
+	Headers: []string{"CLUSTER", "NAMESPACE", "NAME", "TYPE", "CLUSTER-IP", "EXTERNAL-IP", "PORT(S)", "AGE"},
+	Rows:    output.ItemRows(serviceRow),
+	Noun:    "services",
 }
 
-func formatServicesTable(services []ServiceInfo, noColor bool) error {
-	if len(services) == 0 {
-		fmt.Println("No services found")
-		return nil
+// serviceRow formats a service as a table row
+func serviceRow(svc ServiceInfo, colors *output.ColorScheme) []string {
+	return []string{
+		colors.ClusterName(util.ShortClusterName(svc.Cluster)),
+		svc.Namespace,
+		svc.Name,
+		svc.Type,
+		svc.ClusterIP,
+		svc.ExternalIP,
+		svc.Ports,
+		svc.Age,
 	}
-
-	colors := output.NewColorScheme(os.Stdout, noColor)
-
-	// Create tabwriter for aligned columns
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-
-	// Print header
-	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-		colors.Header("CLUSTER"),
-		colors.Header("NAMESPACE"),
-		colors.Header("NAME"),
-		colors.Header("TYPE"),
-		colors.Header("CLUSTER-IP"),
-		colors.Header("EXTERNAL-IP"),
-		colors.Header("PORT(S)"),
-		colors.Header("AGE"))
-
-	// Print rows
-	for _, svc := range services {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			colors.ClusterName(util.ShortClusterName(svc.Cluster)),
-			svc.Namespace,
-			svc.Name,
-			svc.Type,
-			svc.ClusterIP,
-			svc.ExternalIP,
-			svc.Ports,
-			svc.Age)
-	}
-
-	w.Flush()
-	fmt.Fprintf(os.Stdout, "\nTotal: %d services\n", len(services))
-
-	return nil
 }
@@ -0,0 +1,121 @@
+package historycmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/aryankumar/fleet/internal/history"
+	"github.com/aryankumar/fleet/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// newCompareCmd creates the history compare command
+func newCompareCmd() *cobra.Command {
+	var last int
+	var command string
+
+	cmd := &cobra.Command{
+		Use:   "compare [ID...]",
+		Short: "Compare per-cluster durations across runs",
+		Long: `Compare each cluster's durations across recorded runs: how many runs it
+took part in and failed in, its minimum, average, maximum and latest
+duration, and how much the latest run differs from the average of the
+earlier ones. Runs in which a cluster was skipped, never started or turned
+away by an open circuit don't count for it.
+
+Without IDs the last --last runs are compared, optionally only those of
+--command. Comparing runs of the same command is usually what you want,
+since different commands take different times.`,
+		Example: `  # Compare the last 10 runs of get pods
+  fleet history compare --command "get pods"
+
+  # Compare two specific runs
+  fleet history compare 20261016-134501 20261016-141210
+
+  # Compare as JSON
+  fleet history compare --command apply --last 20 -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, defaults, err := setup(cmd)
+			if err != nil {
+				return err
+			}
+
+			var runs []*history.Run
+			if len(args) > 0 {
+				for _, id := range args {
+					run, err := store.Get(id)
+					if err != nil {
+						return err
+					}
+					runs = append(runs, run)
+				}
+			} else {
+				runs, err = listRuns(store, command, last)
+				if err != nil {
+					return err
+				}
+			}
+
+			if len(runs) == 0 {
+				return fmt.Errorf("no runs to compare")
+			}
+			return writeStats(os.Stdout, len(runs), history.Compare(runs), defaults.OutputFormat, defaults.NoColor)
+		},
+	}
+
+	cmd.Flags().IntVar(&last, "last", 10, "compare this many of the most recent runs (0 for all)")
+	cmd.Flags().StringVar(&command, "command", "", `only compare runs of this command, such as "apply" or "get pods"`)
+
+	return cmd
+}
+
+// writeStats writes per-cluster statistics as a table or in a
+// machine-readable format
+func writeStats(w io.Writer, runs int, stats []history.ClusterStats, format string, noColor bool) error {
+	if format != "" && format != string(output.FormatTable) {
+		return writeData(w, stats, format)
+	}
+
+	fmt.Fprintf(w, "Compared %d runs\n\n", runs)
+	if len(stats) == 0 {
+		fmt.Fprintln(w, "No cluster ran in these runs")
+		return nil
+	}
+
+	colors := output.NewColorScheme(w, noColor)
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		colors.Header("CLUSTER"),
+		colors.Header("RUNS"),
+		colors.Header("FAILED"),
+		colors.Header("MIN"),
+		colors.Header("AVG"),
+		colors.Header("MAX"),
+		colors.Header("LATEST"),
+		colors.Header("CHANGE"))
+
+	for _, s := range stats {
+		change := "-"
+		if s.Runs > 1 {
+			change = fmt.Sprintf("%+.0f%%", s.Change*100)
+			if s.Change > 0 {
+				change = colors.Warning("%s", change)
+			}
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			colors.ClusterName("%s", s.Cluster),
+			s.Runs,
+			s.Failed,
+			s.Min,
+			s.Avg,
+			s.Max,
+			s.Latest,
+			change)
+	}
+
+	return tw.Flush()
+}
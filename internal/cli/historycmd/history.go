@@ -0,0 +1,200 @@
+// Package historycmd implements the "fleet history" commands for looking at
+// recorded runs of apply, delete and get.
+package historycmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/history"
+	"github.com/aryankumar/fleet/internal/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// listTimeFormat shows when a run started, in local time
+const listTimeFormat = "2006-01-02 15:04:05"
+
+// NewHistoryCmd creates the history command, which lists recorded runs
+func NewHistoryCmd() *cobra.Command {
+	var limit int
+	var command string
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List and inspect recorded runs",
+		Long: `List recorded runs of apply, delete and get, newest first.
+
+Every run's results are saved under ~/.fleet/history: the command line, the
+clusters, each cluster's status, duration and error, and the per-resource
+outcomes of apply and delete. The newest 200 runs are kept. Pass
+--no-history (or set FLEET_NO_HISTORY=true) to leave a run out.
+
+"show" prints one run in any output format; "compare" compares each
+cluster's durations across runs.`,
+		Example: `  # List recent runs
+  fleet history
+
+  # List the last 5 apply runs
+  fleet history --command apply --limit 5
+
+  # Show the latest run, or one by ID (a unique prefix is enough)
+  fleet history show
+  fleet history show 20261016-1345
+
+  # Compare per-cluster durations across the last 10 get pods runs
+  fleet history compare --command "get pods" --last 10`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, defaults, err := setup(cmd)
+			if err != nil {
+				return err
+			}
+
+			runs, err := listRuns(store, command, limit)
+			if err != nil {
+				return err
+			}
+			return writeList(os.Stdout, runs, defaults.OutputFormat, defaults.NoColor)
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 20, "show at most this many runs (0 for all)")
+	cmd.Flags().StringVar(&command, "command", "", `only show runs of this command, such as "apply" or "get pods"`)
+
+	cmd.AddCommand(newShowCmd())
+	cmd.AddCommand(newCompareCmd())
+
+	return cmd
+}
+
+// setup returns the history store and the effective defaults
+func setup(cmd *cobra.Command) (*history.Store, config.DefaultsConfig, error) {
+	configManager, err := config.FromContext(cmd.Context())
+	if err != nil {
+		return nil, config.DefaultsConfig{}, err
+	}
+
+	store, err := history.DefaultStore()
+	if err != nil {
+		return nil, config.DefaultsConfig{}, err
+	}
+
+	return store, configManager.GetConfig().Defaults, nil
+}
+
+// listRuns returns the stored runs of command, newest first, up to limit
+// An empty command matches every run and a limit of zero or less means all.
+func listRuns(store *history.Store, command string, limit int) ([]*history.Run, error) {
+	runs, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*history.Run
+	for _, run := range runs {
+		if command != "" && run.Command != command {
+			continue
+		}
+		matched = append(matched, run)
+		if limit > 0 && len(matched) == limit {
+			break
+		}
+	}
+	return matched, nil
+}
+
+// writeList writes runs as a table or in a machine-readable format
+func writeList(w io.Writer, runs []*history.Run, format string, noColor bool) error {
+	if format != "" && format != string(output.FormatTable) {
+		// Keep the machine-readable list light; "show" has the results
+		type summary struct {
+			ID        string           `json:"id" yaml:"id"`
+			Command   string           `json:"command" yaml:"command"`
+			Args      []string         `json:"args" yaml:"args"`
+			StartedAt time.Time        `json:"startedAt" yaml:"startedAt"`
+			Duration  history.Duration `json:"duration" yaml:"duration"`
+			Clusters  []string         `json:"clusters" yaml:"clusters"`
+			Failed    int              `json:"failed" yaml:"failed"`
+		}
+
+		items := make([]summary, 0, len(runs))
+		for _, run := range runs {
+			items = append(items, summary{
+				ID:        run.ID,
+				Command:   run.Command,
+				Args:      run.Args,
+				StartedAt: run.StartedAt,
+				Duration:  run.Duration,
+				Clusters:  run.Clusters,
+				Failed:    run.Failed(),
+			})
+		}
+		return writeData(w, items, format)
+	}
+
+	if len(runs) == 0 {
+		fmt.Fprintln(w, "No runs recorded")
+		return nil
+	}
+
+	colors := output.NewColorScheme(w, noColor)
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+		colors.Header("ID"),
+		colors.Header("STARTED"),
+		colors.Header("CLUSTERS"),
+		colors.Header("FAILED"),
+		colors.Header("DURATION"),
+		colors.Header("COMMAND"))
+
+	for _, run := range runs {
+		failed := fmt.Sprintf("%d", run.Failed())
+		if run.Failed() > 0 {
+			failed = colors.Error("%s", failed)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n",
+			run.ID,
+			run.StartedAt.Local().Format(listTimeFormat),
+			len(run.Clusters),
+			failed,
+			run.Duration,
+			truncate(run.CommandLine(), 60))
+	}
+
+	return tw.Flush()
+}
+
+// writeData writes data as JSON, YAML or NDJSON
+func writeData(w io.Writer, data interface{}, format string) error {
+	switch output.Format(format) {
+	case output.FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	case output.FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		defer encoder.Close()
+		return encoder.Encode(data)
+	case output.FormatNDJSON:
+		return output.NewNDJSONFormatter(nil).Format(w, data)
+	default:
+		return fmt.Errorf("unsupported output format: %s (supported: table, json, yaml, ndjson)", format)
+	}
+}
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.TrimSpace(s[:n-3]) + "..."
+}
@@ -0,0 +1,144 @@
+package historycmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/executor"
+	"github.com/aryankumar/fleet/internal/history"
+)
+
+func newTestStore(t *testing.T) *history.Store {
+	t.Helper()
+
+	store := history.NewStore(t.TempDir())
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	runs := []*history.Run{
+		{
+			Command:   "get pods",
+			Args:      []string{"get", "pods", "-A"},
+			StartedAt: start,
+			Duration:  history.Duration(2 * time.Second),
+			Results: []history.ClusterResult{
+				{Cluster: "prod", Status: executor.StatusSucceeded, Duration: history.Duration(time.Second), Attempts: 1, Items: 12},
+			},
+		},
+		{
+			Command:   "apply",
+			Args:      []string{"apply", "-f", "app.yaml"},
+			StartedAt: start.Add(time.Minute),
+			Duration:  history.Duration(3 * time.Second),
+			Results: []history.ClusterResult{
+				{
+					Cluster: "prod", Status: executor.StatusSucceeded, Duration: history.Duration(2 * time.Second), Attempts: 1, Items: 1,
+					Resources: []history.Resource{{Resource: "deployment/web", Action: "configured"}},
+				},
+				{Cluster: "dev", Status: executor.StatusFailed, Error: "connection refused", Attempts: 3},
+			},
+		},
+		{
+			Command:   "get pods",
+			Args:      []string{"get", "pods", "-A"},
+			StartedAt: start.Add(2 * time.Minute),
+			Duration:  history.Duration(2 * time.Second),
+			Results: []history.ClusterResult{
+				{Cluster: "prod", Status: executor.StatusSucceeded, Duration: history.Duration(3 * time.Second), Attempts: 1, Items: 12},
+			},
+		},
+	}
+	for _, run := range runs {
+		if err := store.Save(run); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	return store
+}
+
+func TestList(t *testing.T) {
+	store := newTestStore(t)
+
+	runs, err := listRuns(store, "get pods", 0)
+	if err != nil {
+		t.Fatalf("listRuns() error = %v", err)
+	}
+	if len(runs) != 2 || !runs[0].StartedAt.After(runs[1].StartedAt) {
+		t.Fatalf("listRuns(get pods) = %d runs, want 2 newest first", len(runs))
+	}
+	if runs, _ := listRuns(store, "", 1); len(runs) != 1 || runs[0].Command != "get pods" {
+		t.Errorf("listRuns(limit 1) = %v, want the newest run", runs)
+	}
+
+	var buf bytes.Buffer
+	if err := writeList(&buf, runs, "table", true); err != nil {
+		t.Fatalf("writeList() error = %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "COMMAND") || !strings.Contains(out, "get pods -A") {
+		t.Errorf("table output:\n%s", out)
+	}
+
+	buf.Reset()
+	if err := writeList(&buf, runs, "ndjson", true); err != nil {
+		t.Fatalf("writeList(ndjson) error = %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 2 {
+		t.Errorf("ndjson output has %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+}
+
+func TestWriteRun(t *testing.T) {
+	store := newTestStore(t)
+	run, err := store.Get("20261016-1201")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeRun(&buf, run, "table", true); err != nil {
+		t.Fatalf("writeRun() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"apply -f app.yaml", "connection refused", "deployment/web", "configured"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := writeRun(&buf, run, "json", true); err != nil {
+		t.Fatalf("writeRun(json) error = %v", err)
+	}
+	var decoded history.Run
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || decoded.ID != run.ID || len(decoded.Results) != 2 {
+		t.Errorf("json output = %+v, %v", decoded, err)
+	}
+
+	buf.Reset()
+	if err := writeRun(&buf, run, "yaml", true); err != nil || !strings.Contains(buf.String(), "resource: deployment/web") {
+		t.Errorf("yaml output = %s, %v", buf.String(), err)
+	}
+
+	if err := writeRun(&buf, run, "csv", true); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
+
+func TestWriteStats(t *testing.T) {
+	store := newTestStore(t)
+	runs, err := listRuns(store, "get pods", 10)
+	if err != nil {
+		t.Fatalf("listRuns() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeStats(&buf, len(runs), history.Compare(runs), "table", true); err != nil {
+		t.Fatalf("writeStats() error = %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Compared 2 runs") || !strings.Contains(out, "+200%") {
+		t.Errorf("table output:\n%s", out)
+	}
+}
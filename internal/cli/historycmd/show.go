@@ -0,0 +1,130 @@
+package historycmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/aryankumar/fleet/internal/executor"
+	"github.com/aryankumar/fleet/internal/history"
+	"github.com/aryankumar/fleet/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// newShowCmd creates the history show command
+func newShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [ID]",
+		Short: "Show one recorded run",
+		Long: `Show the results of one recorded run: each cluster's status, duration,
+attempts and error, and for apply and delete each resource's outcome.
+
+ID is a run ID from "fleet history" or a unique prefix of one; without it,
+or with "latest", the newest run is shown. Use -o json, yaml or ndjson for
+machine-readable output (ndjson writes one line per cluster task).`,
+		Example: `  # Show the latest run
+  fleet history show
+
+  # Show a run as YAML
+  fleet history show 20261016-134501 -o yaml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, defaults, err := setup(cmd)
+			if err != nil {
+				return err
+			}
+
+			id := "latest"
+			if len(args) > 0 {
+				id = args[0]
+			}
+
+			run, err := store.Get(id)
+			if err != nil {
+				return err
+			}
+			return writeRun(os.Stdout, run, defaults.OutputFormat, defaults.NoColor)
+		},
+	}
+}
+
+// writeRun writes one run as a table or in a machine-readable format
+func writeRun(w io.Writer, run *history.Run, format string, noColor bool) error {
+	switch output.Format(format) {
+	case "", output.FormatTable:
+	case output.FormatNDJSON:
+		return writeData(w, run.Results, format)
+	default:
+		return writeData(w, run, format)
+	}
+
+	colors := output.NewColorScheme(w, noColor)
+
+	fmt.Fprintf(w, "Run:      %s\n", run.ID)
+	fmt.Fprintf(w, "Command:  %s\n", run.CommandLine())
+	fmt.Fprintf(w, "Started:  %s\n", run.StartedAt.Local().Format(listTimeFormat))
+	fmt.Fprintf(w, "Duration: %s\n", run.Duration)
+	fmt.Fprintf(w, "Clusters: %d (%d failed)\n\n", len(run.Clusters), run.Failed())
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+		colors.Header("CLUSTER"),
+		colors.Header("STATUS"),
+		colors.Header("DURATION"),
+		colors.Header("ATTEMPTS"),
+		colors.Header("ITEMS"),
+		colors.Header("ERROR"))
+
+	hasResources := false
+	for _, result := range run.Results {
+		status := colors.Success("%s", result.Status)
+		if result.Status != executor.StatusSucceeded {
+			status = colors.Error("%s", result.Status)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%s\n",
+			colors.ClusterName("%s", result.Cluster),
+			status,
+			result.Duration,
+			result.Attempts,
+			result.Items,
+			result.Error)
+
+		if len(result.Resources) > 0 {
+			hasResources = true
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if !hasResources {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+		colors.Header("CLUSTER"),
+		colors.Header("RESOURCE"),
+		colors.Header("ACTION"),
+		colors.Header("ERROR"))
+
+	for _, result := range run.Results {
+		for _, resource := range result.Resources {
+			action := resource.Action
+			if resource.Error != "" {
+				action = colors.Error("failed")
+			}
+
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+				colors.ClusterName("%s", result.Cluster),
+				resource.Resource,
+				action,
+				resource.Error)
+		}
+	}
+
+	return tw.Flush()
+}
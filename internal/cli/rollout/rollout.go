@@ -0,0 +1,266 @@
+// Package rollout provides the rollout strategy flags shared by the mutating
+// commands (apply, delete) and runs their tasks all at once or in waves.
+package rollout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/cluster"
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/executor"
+	"github.com/spf13/cobra"
+)
+
+// gateTimeout bounds the health checks run between waves
+const gateTimeout = 15 * time.Second
+
+// Options holds the rollout strategy flags
+type Options struct {
+	Strategy    string
+	WaveSize    string
+	Canary      []string
+	MaxFailures string
+	Pause       time.Duration
+	HealthGate  bool
+	FailFast    bool
+}
+
+// AddFlags registers the rollout strategy flags on cmd
+func AddFlags(cmd *cobra.Command, o *Options) {
+	cmd.Flags().StringVar(&o.Strategy, "strategy", executor.StrategyAll, "rollout strategy: all (every cluster at once, up to --parallel) or waves")
+	cmd.Flags().StringVar(&o.WaveSize, "wave-size", "25%", "clusters per wave after the canaries with --strategy waves, as a count or a percentage of the targeted clusters")
+	cmd.Flags().StringSliceVar(&o.Canary, "canary", nil, "clusters for the first wave with --strategy waves, in the same forms as --clusters (default: the first targeted cluster)")
+	cmd.Flags().StringVar(&o.MaxFailures, "max-failures", "0", "with --strategy waves, abort once more than this many clusters, or this percentage of them, have failed")
+	cmd.Flags().DurationVar(&o.Pause, "wave-pause", 0, "with --strategy waves, wait this long between waves")
+	cmd.Flags().BoolVar(&o.HealthGate, "health-gate", true, "with --strategy waves, check that a wave's clusters are healthy before starting the next wave")
+	cmd.Flags().BoolVar(&o.FailFast, "fail-fast", false, "stop at the first cluster that fails, cancelling running clusters and skipping the rest")
+}
+
+// Plan is a wave rollout resolved against the connected clusters
+type Plan struct {
+	Options executor.WaveOptions
+	Waves   []executor.Wave
+}
+
+// Plan resolves the options against the clusters connected to mgr
+// It returns nil for the all strategy.
+func (o *Options) Plan(ctx context.Context, mgr *cluster.Manager) (*Plan, error) {
+	plan, err := o.plan(ctx, mgr.GetClientNames())
+	if plan != nil && o.HealthGate {
+		plan.Options.Gate = healthGate(mgr)
+	}
+	return plan, err
+}
+
+// plan resolves the options against the named clusters, without a gate
+func (o *Options) plan(ctx context.Context, clusters []string) (*Plan, error) {
+	switch o.Strategy {
+	case "", executor.StrategyAll:
+		return nil, nil
+	case executor.StrategyWaves:
+	default:
+		return nil, fmt.Errorf("unsupported rollout strategy %q (supported: %s, %s)", o.Strategy, executor.StrategyAll, executor.StrategyWaves)
+	}
+
+	size, err := executor.ParseThreshold(o.WaveSize)
+	if err != nil {
+		return nil, fmt.Errorf("--wave-size: %w", err)
+	}
+	maxFailures, err := executor.ParseThreshold(o.MaxFailures)
+	if err != nil {
+		return nil, fmt.Errorf("--max-failures: %w", err)
+	}
+	if o.Pause < 0 {
+		return nil, fmt.Errorf("--wave-pause must not be negative")
+	}
+
+	clusters = slices.Sorted(slices.Values(clusters))
+
+	canary, err := o.resolveCanary(ctx, clusters)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := executor.WaveOptions{
+		Canary:      canary,
+		Size:        size,
+		MaxFailures: maxFailures,
+		Pause:       o.Pause,
+	}
+
+	return &Plan{Options: opts, Waves: executor.PlanWaves(clusters, opts)}, nil
+}
+
+// resolveCanary matches the --canary entries against the connected clusters
+func (o *Options) resolveCanary(ctx context.Context, clusters []string) ([]string, error) {
+	if len(clusters) == 0 {
+		return nil, nil
+	}
+	if len(o.Canary) == 0 {
+		return clusters[:1], nil
+	}
+
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var canary []string
+	for _, pattern := range o.Canary {
+		matched, err := configManager.MatchContexts(pattern, clusters, true)
+		if err != nil {
+			return nil, err
+		}
+		if len(matched) == 0 {
+			return nil, fmt.Errorf("--canary entry %q matched none of the targeted clusters", pattern)
+		}
+		for _, name := range matched {
+			if !slices.Contains(canary, name) {
+				canary = append(canary, name)
+			}
+		}
+	}
+	return canary, nil
+}
+
+// healthGate checks the health of a finished wave's clusters concurrently
+func healthGate(mgr *cluster.Manager) func(ctx context.Context, wave executor.Wave) error {
+	return func(ctx context.Context, wave executor.Wave) error {
+		ctx, cancel := context.WithTimeout(ctx, gateTimeout)
+		defer cancel()
+
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			errs []error
+		)
+		for _, name := range wave.Clusters {
+			client, err := mgr.GetClient(name)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				continue
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := client.HealthCheck(ctx); err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("%s: %w", name, err))
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		return errors.Join(errs...)
+	}
+}
+
+// Execute runs the pool's tasks, in waves if plan is not nil, and streams
+// the results as they finish
+func Execute[T any](ctx context.Context, pool *executor.Pool[T], plan *Plan) <-chan executor.Result[T] {
+	if plan == nil {
+		return pool.ExecuteStream(ctx)
+	}
+	return pool.ExecuteWaves(ctx, plan.Options)
+}
+
+// Describe returns the plan as one line per wave
+func (p *Plan) Describe() string {
+	if p == nil {
+		return ""
+	}
+
+	limit := p.Options.MaxFailures.String() + " cluster(s)"
+	if p.Options.MaxFailures.Percent > 0 {
+		limit = p.Options.MaxFailures.String() + " of clusters"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Rolling out in %d wave(s), aborting once more than %s fail:\n", len(p.Waves), limit)
+	for _, wave := range p.Waves {
+		fmt.Fprintf(&b, "  %s\n", waveTitle(wave))
+	}
+	return b.String()
+}
+
+// waveTitle names a wave and its clusters
+func waveTitle(wave executor.Wave) string {
+	label := fmt.Sprintf("Wave %d/%d", wave.Number, wave.Total)
+	if wave.Canary {
+		label += " (canary)"
+	}
+	return fmt.Sprintf("%s: %s", label, strings.Join(wave.Clusters, ", "))
+}
+
+// Progress follows a result stream, printing a header as each wave starts
+// and collecting the clusters skipped by an aborted rollout, fail-fast or an
+// open circuit
+type Progress struct {
+	plan    *Plan
+	wave    int
+	skipped []string
+	aborted error
+
+	// unavailable are the clusters turned away by their open circuit
+	unavailable []string
+}
+
+// Progress returns a tracker for the plan's results; a nil plan prints nothing
+func (p *Plan) Progress() *Progress {
+	return &Progress{plan: p}
+}
+
+// Observe is called for every result before it is displayed
+// It prints the wave header to w when a new wave starts and reports whether
+// the result belongs to a cluster skipped by an aborted rollout, fail-fast or
+// an open circuit, in which case it should not be displayed as a failure.
+func (pr *Progress) Observe(w io.Writer, clusterName string, wave int, status executor.Status, err error) bool {
+	switch {
+	case errors.Is(err, executor.ErrRolloutAborted) || errors.Is(err, executor.ErrSkipped):
+		if pr.aborted == nil {
+			pr.aborted = err
+		}
+		if !slices.Contains(pr.skipped, clusterName) {
+			pr.skipped = append(pr.skipped, clusterName)
+		}
+		return true
+	case status == executor.StatusSkipped || errors.Is(err, cluster.ErrCircuitOpen):
+		if !slices.Contains(pr.unavailable, clusterName) {
+			pr.unavailable = append(pr.unavailable, clusterName)
+		}
+		return true
+	}
+
+	if pr.plan != nil && wave != pr.wave && wave > 0 && wave <= len(pr.plan.Waves) {
+		pr.wave = wave
+		fmt.Fprintf(w, "%s\n", waveTitle(pr.plan.Waves[wave-1]))
+	}
+	return false
+}
+
+// Finish prints why the rollout was aborted or clusters were skipped, if
+// they were, and returns the error that aborted the rollout or stopped it
+// under fail-fast. Clusters skipped for an open circuit are only listed.
+func (pr *Progress) Finish(w io.Writer) error {
+	if len(pr.unavailable) > 0 {
+		fmt.Fprintf(w, "Skipped %d cluster(s) with an open circuit: %s\n", len(pr.unavailable), strings.Join(pr.unavailable, ", "))
+	}
+	if pr.aborted == nil {
+		return nil
+	}
+
+	fmt.Fprintf(w, "%s\n", pr.aborted)
+	fmt.Fprintf(w, "Skipped %d cluster(s): %s\n", len(pr.skipped), strings.Join(pr.skipped, ", "))
+	return pr.aborted
+}
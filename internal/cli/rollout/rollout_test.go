@@ -0,0 +1,165 @@
+package rollout
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aryankumar/fleet/internal/cluster"
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/config/configtest"
+	"github.com/aryankumar/fleet/internal/executor"
+)
+
+const testConfig = `
+clusters:
+  staging-east:
+    alias: canary
+    enabled: true
+  prod-east:
+    enabled: true
+  prod-west:
+    enabled: true
+`
+
+var testClusters = []string{"prod-west", "staging-east", "prod-east", "prod-central", "staging-west"}
+
+func testContext(t *testing.T) context.Context {
+	t.Helper()
+
+	return config.NewContext(context.Background(), configtest.Load(t, testConfig))
+}
+
+func TestOptions_Plan(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		want    [][]string
+		wantErr string
+	}{
+		{
+			name: "all strategy has no plan",
+			opts: Options{Strategy: executor.StrategyAll},
+		},
+		{
+			name: "first cluster is the default canary",
+			opts: Options{Strategy: executor.StrategyWaves, WaveSize: "50%"},
+			want: [][]string{{"prod-central"}, {"prod-east", "prod-west", "staging-east"}, {"staging-west"}},
+		},
+		{
+			name: "canary patterns and aliases",
+			opts: Options{Strategy: executor.StrategyWaves, WaveSize: "2", Canary: []string{"canary", "staging-*"}},
+			want: [][]string{{"staging-east", "staging-west"}, {"prod-central", "prod-east"}, {"prod-west"}},
+		},
+		{
+			name:    "unknown strategy",
+			opts:    Options{Strategy: "blue-green"},
+			wantErr: "unsupported rollout strategy",
+		},
+		{
+			name:    "bad wave size",
+			opts:    Options{Strategy: executor.StrategyWaves, WaveSize: "lots"},
+			wantErr: "--wave-size",
+		},
+		{
+			name:    "bad max failures",
+			opts:    Options{Strategy: executor.StrategyWaves, MaxFailures: "200%"},
+			wantErr: "--max-failures",
+		},
+		{
+			name:    "unmatched canary",
+			opts:    Options{Strategy: executor.StrategyWaves, Canary: []string{"dev-*"}},
+			wantErr: `--canary entry "dev-*"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := tt.opts.plan(testContext(t), testClusters)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("plan() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan() error = %v", err)
+			}
+
+			var got [][]string
+			if plan != nil {
+				for _, wave := range plan.Waves {
+					got = append(got, wave.Clusters)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("waves = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgress(t *testing.T) {
+	plan := &Plan{Waves: []executor.Wave{
+		{Number: 1, Total: 2, Clusters: []string{"a"}, Canary: true},
+		{Number: 2, Total: 2, Clusters: []string{"b", "c"}},
+	}}
+	progress := plan.Progress()
+	aborted := errors.Join(executor.ErrRolloutAborted, errors.New("1 of 3 clusters failed"))
+
+	var buf bytes.Buffer
+	if progress.Observe(&buf, "a", 1, executor.StatusFailed, errors.New("boom")) {
+		t.Error("a failed cluster should be displayed")
+	}
+	progress.Observe(&buf, "a", 1, executor.StatusSucceeded, nil)
+	for _, name := range []string{"b", "c"} {
+		if !progress.Observe(&buf, name, 2, executor.StatusSkipped, aborted) {
+			t.Errorf("%s: expected an aborted result to be skipped", name)
+		}
+	}
+
+	if err := progress.Finish(&buf); !errors.Is(err, executor.ErrRolloutAborted) {
+		t.Errorf("Finish() = %v, want the abort error", err)
+	}
+
+	out := buf.String()
+	if strings.Count(out, "Wave 1/2 (canary): a") != 1 || strings.Contains(out, "Wave 2/2") {
+		t.Errorf("expected one header for the wave that ran:\n%s", out)
+	}
+	if !strings.Contains(out, "Skipped 2 cluster(s): b, c") {
+		t.Errorf("expected the skipped clusters to be listed:\n%s", out)
+	}
+
+	// Without a plan nothing is printed
+	buf.Reset()
+	var none *Plan
+	none.Progress().Observe(&buf, "a", 0, executor.StatusSucceeded, nil)
+	if err := none.Progress().Finish(&buf); err != nil || buf.Len() != 0 {
+		t.Errorf("nil plan printed %q, err %v", buf.String(), err)
+	}
+
+	// Clusters skipped by fail-fast are reported without a plan too
+	progress = none.Progress()
+	skipped := fmt.Errorf("%w: fail-fast after a failed", executor.ErrSkipped)
+	if !progress.Observe(&buf, "b", 0, executor.StatusSkipped, skipped) {
+		t.Error("expected a fail-fast result to be skipped")
+	}
+	if err := progress.Finish(&buf); !errors.Is(err, executor.ErrSkipped) || !strings.Contains(buf.String(), "Skipped 1 cluster(s): b") {
+		t.Errorf("Finish() = %v, printed %q", err, buf.String())
+	}
+
+	// Clusters with an open circuit are listed but don't fail the command
+	buf.Reset()
+	progress = none.Progress()
+	open := &cluster.CircuitOpenError{Cluster: "c", Failures: 3}
+	if !progress.Observe(&buf, "c", 0, executor.StatusSkipped, open) {
+		t.Error("expected an open circuit result to be skipped")
+	}
+	if err := progress.Finish(&buf); err != nil || !strings.Contains(buf.String(), "Skipped 1 cluster(s) with an open circuit: c") {
+		t.Errorf("Finish() = %v, printed %q", err, buf.String())
+	}
+}
@@ -5,12 +5,17 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/aryankumar/fleet/internal/cli/apply"
 	"github.com/aryankumar/fleet/internal/cli/cluster"
+	"github.com/aryankumar/fleet/internal/cli/configcmd"
 	"github.com/aryankumar/fleet/internal/cli/delete"
 	"github.com/aryankumar/fleet/internal/cli/get"
+	"github.com/aryankumar/fleet/internal/cli/historycmd"
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/history"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -40,75 +45,120 @@ and monitoring across all your clusters.`,
 	}
 
 	// Define persistent flags
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.fleet.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.fleet/config.yaml or $HOME/.fleet.yaml)")
 	rootCmd.PersistentFlags().String("kubeconfig", "", "path to kubeconfig file (default is $HOME/.kube/config)")
-	rootCmd.PersistentFlags().StringSlice("clusters", []string{}, "target clusters (comma-separated, empty means all)")
-	rootCmd.PersistentFlags().StringP("output", "o", "", "output format (json, yaml, table)")
+	rootCmd.PersistentFlags().StringSlice("clusters", []string{}, "target clusters by context, name, alias, glob (prod-*) or /regex/ (comma-separated, empty means all)")
+	rootCmd.PersistentFlags().StringSlice("exclude-clusters", []string{}, "exclude clusters by context, name, alias, glob or /regex/ (comma-separated)")
+	rootCmd.PersistentFlags().StringSlice("group", []string{}, "target clusters in the named fleet config groups (comma-separated)")
+	rootCmd.PersistentFlags().String("cluster-selector", "", "select clusters by fleet config labels (e.g. 'env=prod,region in (us-east-1,us-west-2),!deprecated')")
+	rootCmd.PersistentFlags().Bool("include-disabled", false, "include clusters marked enabled: false in the fleet config")
+	rootCmd.PersistentFlags().StringP("output", "o", "", "output format (json, yaml, table, ndjson)")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output with debug logging")
 	rootCmd.PersistentFlags().Bool("no-color", false, "disable colored output")
-	rootCmd.PersistentFlags().Duration("timeout", 30*time.Second, "timeout for operations")
+	rootCmd.PersistentFlags().Duration("timeout", 30*time.Second, "timeout for each cluster operation, measured from when it starts")
 	rootCmd.PersistentFlags().IntP("parallel", "p", 5, "number of parallel operations")
+	rootCmd.PersistentFlags().Float32("qps", 5, "client-side requests per second for each cluster")
+	rootCmd.PersistentFlags().Int("burst", 10, "client-side request burst for each cluster")
+	rootCmd.PersistentFlags().Bool("adaptive-qps", false, "lower a cluster's QPS when its API server throttles requests (429) and recover as they succeed")
+	rootCmd.PersistentFlags().Int("retries", 0, "retry cluster operations that fail with timeouts, 429, 5xx or connection resets this many times")
+	rootCmd.PersistentFlags().Duration("deadline", 0, "overall deadline for the whole run, on top of each cluster's --timeout (0 means none)")
+	rootCmd.PersistentFlags().Bool("adaptive-parallel", false, "adjust the number of parallel operations, up to --parallel, as cluster latencies and errors rise and fall")
+	rootCmd.PersistentFlags().Bool("no-history", false, "don't record this run's results in ~/.fleet/history")
 
 	// Bind flags to viper
+	viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
 	viper.BindPFlag("kubeconfig", rootCmd.PersistentFlags().Lookup("kubeconfig"))
 	viper.BindPFlag("clusters", rootCmd.PersistentFlags().Lookup("clusters"))
+	viper.BindPFlag("exclude-clusters", rootCmd.PersistentFlags().Lookup("exclude-clusters"))
+	viper.BindPFlag("group", rootCmd.PersistentFlags().Lookup("group"))
+	viper.BindPFlag("cluster-selector", rootCmd.PersistentFlags().Lookup("cluster-selector"))
+	viper.BindPFlag("include-disabled", rootCmd.PersistentFlags().Lookup("include-disabled"))
 	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
 	viper.BindPFlag("no-color", rootCmd.PersistentFlags().Lookup("no-color"))
 	viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
 	viper.BindPFlag("parallel", rootCmd.PersistentFlags().Lookup("parallel"))
+	viper.BindPFlag("qps", rootCmd.PersistentFlags().Lookup("qps"))
+	viper.BindPFlag("burst", rootCmd.PersistentFlags().Lookup("burst"))
+	viper.BindPFlag("adaptive-qps", rootCmd.PersistentFlags().Lookup("adaptive-qps"))
+	viper.BindPFlag("retries", rootCmd.PersistentFlags().Lookup("retries"))
+	viper.BindPFlag("deadline", rootCmd.PersistentFlags().Lookup("deadline"))
+	viper.BindPFlag("adaptive-parallel", rootCmd.PersistentFlags().Lookup("adaptive-parallel"))
+	viper.BindPFlag("no-history", rootCmd.PersistentFlags().Lookup("no-history"))
 
 	// Add subcommands
 	rootCmd.AddCommand(newVersionCmd())
 	rootCmd.AddCommand(newCompletionCmd())
 	rootCmd.AddCommand(cluster.NewClusterCmd())
+	rootCmd.AddCommand(configcmd.NewConfigCmd())
 	rootCmd.AddCommand(get.NewGetCmd())
 	rootCmd.AddCommand(apply.NewApplyCmd())
 	rootCmd.AddCommand(delete.NewDeleteCmd())
+	rootCmd.AddCommand(historycmd.NewHistoryCmd())
 
 	return rootCmd
 }
 
 // initConfig initializes configuration and logging
+//
+// The fleet config file is the single source for persistent settings. Its
+// defaults section is merged with environment variables and flags using the
+// precedence flag > env > config file > built-in default, and the result is
+// attached to the command context for subcommands to read.
 func initConfig(cmd *cobra.Command) error {
-	// Initialize viper configuration
-	if cfgFile != "" {
-		// Use config file from the flag
-		viper.SetConfigFile(cfgFile)
-	} else {
-		// Search for config in home directory
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
-		}
-
-		viper.AddConfigPath(home)
-		viper.SetConfigType("yaml")
-		viper.SetConfigName(".fleet")
-	}
-
-	// Read environment variables
+	// Read environment variables (FLEET_TIMEOUT, FLEET_NO_COLOR, ...)
 	viper.SetEnvPrefix("FLEET")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv()
 
-	// Read config file if it exists
-	if err := viper.ReadInConfig(); err != nil {
-		// It's okay if config file doesn't exist
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return fmt.Errorf("failed to read config file: %w", err)
+	// Load the fleet config from --config/FLEET_CONFIG or the default locations
+	configManager := config.NewManager(viper.GetString("config"))
+	if _, err := configManager.Load(); err != nil {
+		// Commands such as "config validate" report problems themselves
+		if cmd.Annotations[configcmd.AnnotationTolerateConfigErrors] != "true" {
+			return fmt.Errorf("failed to load config file: %w", err)
 		}
+		slog.Debug("ignoring config load error", "error", err)
+	}
+	configManager.ApplyOverrides(viper.GetViper(), cmd.Flags())
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
 	}
+	cmd.SetContext(config.NewContext(ctx, configManager))
 
 	// Setup structured logging
-	setupLogging(cmd)
+	setupLogging(cmd, configManager)
+
+	setupHistory(cmd)
 
 	return nil
 }
 
+// setupHistory attaches a run recorder to the command context unless
+// --no-history/FLEET_NO_HISTORY is set; only commands that record their
+// results (apply, delete, get) save a run
+func setupHistory(cmd *cobra.Command) {
+	if viper.GetBool("no-history") {
+		return
+	}
+
+	store, err := history.DefaultStore()
+	if err != nil {
+		slog.Debug("not recording run history", "error", err)
+		return
+	}
+
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	recorder := history.NewRecorder(store, command, os.Args[1:], slog.Default())
+	cmd.SetContext(history.NewContext(cmd.Context(), recorder))
+}
+
 // setupLogging configures structured logging with slog
-func setupLogging(cmd *cobra.Command) {
+func setupLogging(cmd *cobra.Command, configManager *config.Manager) {
 	verbose, _ := cmd.Flags().GetBool("verbose")
-	noColor, _ := cmd.Flags().GetBool("no-color")
+	noColor := configManager.GetConfig().Defaults.NoColor
 
 	// Set log level based on verbose flag
 	logLevel := slog.LevelInfo
@@ -136,8 +186,8 @@ func setupLogging(cmd *cobra.Command) {
 
 	if verbose {
 		slog.Debug("verbose logging enabled")
-		if viper.ConfigFileUsed() != "" {
-			slog.Debug("loaded configuration", "file", viper.ConfigFileUsed())
+		if configManager.ConfigPath() != "" {
+			slog.Debug("loaded configuration", "file", configManager.ConfigPath())
 		}
 	}
 }
@@ -24,6 +24,7 @@ func TestRootCommand(t *testing.T) {
 		"version",
 		"completion",
 		"cluster",
+		"config",
 		"get",
 		"apply",
 		"delete",
@@ -83,11 +84,22 @@ func TestRootCommandPersistentFlags(t *testing.T) {
 		"config",
 		"kubeconfig",
 		"clusters",
+		"exclude-clusters",
+		"group",
+		"cluster-selector",
+		"include-disabled",
 		"output",
 		"verbose",
 		"no-color",
 		"timeout",
 		"parallel",
+		"qps",
+		"burst",
+		"adaptive-qps",
+		"retries",
+		"deadline",
+		"adaptive-parallel",
+		"no-history",
 	}
 
 	for _, flagName := range expectedFlags {
@@ -116,6 +128,26 @@ func TestRootCommandFlagDefaults(t *testing.T) {
 			flag:     "kubeconfig",
 			expected: "",
 		},
+		{
+			name:     "exclude-clusters default",
+			flag:     "exclude-clusters",
+			expected: "[]",
+		},
+		{
+			name:     "group default",
+			flag:     "group",
+			expected: "[]",
+		},
+		{
+			name:     "cluster-selector default",
+			flag:     "cluster-selector",
+			expected: "",
+		},
+		{
+			name:     "include-disabled default",
+			flag:     "include-disabled",
+			expected: "false",
+		},
 		{
 			name:     "output default",
 			flag:     "output",
@@ -0,0 +1,259 @@
+// Package target resolves which clusters a command should operate on
+// from the global targeting flags and the fleet configuration.
+package target
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/aryankumar/fleet/internal/cluster"
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/spf13/viper"
+)
+
+// Connect resolves the targeted clusters and connects the manager to them.
+// With no targeting flags it connects to every enabled cluster.
+//
+// Targeting errors (bad selector, unmatched pattern, unreadable fleet config)
+// are returned. Individual connection failures are only logged so commands
+// can carry on with the clusters that did connect.
+func Connect(ctx context.Context, mgr *cluster.Manager, logger *slog.Logger) error {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	configManager, err := config.FromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load fleet config: %w", err)
+	}
+
+	mgr.SetFleetConfig(configManager.GetConfig())
+	mgr.SetIncludeDisabled(viper.GetBool("include-disabled"))
+	if breaker := configManager.GetConfig().CircuitBreaker; !breaker.Disabled {
+		mgr.SetCircuitBreaker(newCircuitBreaker(breaker, logger))
+	}
+
+	// Discovered clusters are optional extras; an unreachable hub should not
+	// stop commands against the kubeconfig clusters
+	if err := mgr.LoadConfiguredInventory(ctx, configManager.GetConfig().Inventory); err != nil {
+		logger.Warn("cluster discovery incomplete", "error", err)
+	}
+
+	contexts, err := mgr.Contexts()
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	targetClusters, err := Resolve(configManager, contexts, logger)
+	if err != nil {
+		return err
+	}
+
+	if len(targetClusters) == 0 {
+		err = mgr.ConnectAll(ctx)
+	} else {
+		err = mgr.Connect(ctx, targetClusters)
+	}
+
+	if err != nil {
+		// Continue with partial connections
+		logger.Warn("some cluster connections failed", "error", err)
+	}
+
+	return nil
+}
+
+// circuitStateFile holds circuit breaker state in config.StateDir
+const circuitStateFile = "circuits.json"
+
+// newCircuitBreaker creates the breaker shared by all invocations
+func newCircuitBreaker(cfg config.CircuitBreakerConfig, logger *slog.Logger) *cluster.CircuitBreaker {
+	opts := cluster.BreakerOptions{Threshold: cfg.Threshold, Cooldown: cfg.Cooldown}
+	if dir, err := config.StateDir(); err == nil {
+		opts.StatePath = filepath.Join(dir, circuitStateFile)
+	} else {
+		logger.Warn("circuit breaker state will not be shared between runs", "error", err)
+	}
+	return cluster.NewCircuitBreaker(opts, logger)
+}
+
+// Resolve returns the kubeconfig contexts selected by the global targeting
+// flags. An empty result means all clusters.
+//
+// --group and --cluster-selector are evaluated against the fleet config; when
+// both are given a cluster must match both. --clusters entries may be context
+// names, fleet config names, aliases, globs ("prod-*") or regexes between
+// slashes ("/^prod-/"), and further restrict the selection. --exclude-clusters
+// takes the same forms and removes clusters. Every --clusters and
+// --exclude-clusters entry must match at least one cluster.
+//
+// contexts lists the contexts available in the kubeconfig.
+func Resolve(configManager *config.Manager, contexts []string, logger *slog.Logger) ([]string, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	clusters := viper.GetStringSlice("clusters")
+	excludes := viper.GetStringSlice("exclude-clusters")
+	groups := viper.GetStringSlice("group")
+	selector := viper.GetString("cluster-selector")
+	includeDisabled := viper.GetBool("include-disabled")
+
+	if len(clusters) == 0 && len(excludes) == 0 && len(groups) == 0 && selector == "" {
+		return nil, nil
+	}
+
+	var (
+		selected []string
+		filtered = len(groups) > 0 || selector != ""
+	)
+
+	if len(groups) > 0 {
+		names, err := configManager.GetClustersByGroups(groups, includeDisabled)
+		if err != nil {
+			return nil, err
+		}
+		selected = resolveContexts(configManager, names)
+	}
+
+	if selector != "" {
+		names, err := configManager.GetClustersBySelector(selector, includeDisabled)
+		if err != nil {
+			return nil, err
+		}
+		matched := resolveContexts(configManager, names)
+		if len(groups) > 0 {
+			selected = intersect(selected, matched)
+		} else {
+			selected = matched
+		}
+	}
+
+	if filtered && len(selected) == 0 {
+		return nil, fmt.Errorf("%s matched no clusters", describeFilters(groups, selector))
+	}
+
+	if len(clusters) > 0 {
+		matched, err := matchAll(configManager, clusters, contexts, includeDisabled, "--clusters")
+		if err != nil {
+			return nil, err
+		}
+		if filtered {
+			selected = intersect(selected, matched)
+			if len(selected) == 0 {
+				return nil, fmt.Errorf("--clusters %q matched none of the clusters selected by %s",
+					strings.Join(clusters, ","), describeFilters(groups, selector))
+			}
+		} else {
+			selected = matched
+		}
+	} else if !filtered {
+		// Only exclusions were given, so start from every enabled cluster
+		selected = contexts
+		if !includeDisabled {
+			selected, _ = configManager.GetConfig().EnabledContexts(contexts)
+		}
+	}
+
+	if len(excludes) > 0 {
+		excluded, err := matchAll(configManager, excludes, contexts, true, "--exclude-clusters")
+		if err != nil {
+			return nil, err
+		}
+		selected = subtract(selected, excluded)
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("--exclude-clusters %q excluded every targeted cluster", strings.Join(excludes, ","))
+		}
+	}
+
+	logger.Debug("resolved target clusters",
+		"clusters", clusters,
+		"exclude", excludes,
+		"groups", groups,
+		"selector", selector,
+		"resolved", selected)
+
+	return selected, nil
+}
+
+// matchAll returns the union of contexts matched by patterns, in pattern order
+// Each pattern must match at least one cluster.
+func matchAll(configManager *config.Manager, patterns, contexts []string, includeDisabled bool, flag string) ([]string, error) {
+	seen := make(map[string]bool)
+	var result []string
+
+	for _, pattern := range patterns {
+		matched, err := configManager.MatchContexts(pattern, contexts, includeDisabled)
+		if err != nil {
+			return nil, err
+		}
+		if len(matched) == 0 {
+			return nil, fmt.Errorf("%s entry %q matched no clusters", flag, pattern)
+		}
+
+		for _, contextName := range matched {
+			if !seen[contextName] {
+				seen[contextName] = true
+				result = append(result, contextName)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// resolveContexts maps fleet config cluster names to their kubeconfig contexts
+func resolveContexts(configManager *config.Manager, names []string) []string {
+	contexts := make([]string, 0, len(names))
+	for _, name := range names {
+		contexts = append(contexts, configManager.ResolveContext(name))
+	}
+	return contexts
+}
+
+// intersect returns the elements of a that are also in b, keeping a's order
+func intersect(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, name := range b {
+		inB[name] = true
+	}
+
+	result := make([]string, 0, len(a))
+	for _, name := range a {
+		if inB[name] {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// subtract returns the elements of a that are not in b, keeping a's order
+func subtract(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, name := range b {
+		inB[name] = true
+	}
+
+	result := make([]string, 0, len(a))
+	for _, name := range a {
+		if !inB[name] {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// describeFilters names the targeting filters in use for error messages
+func describeFilters(groups []string, selector string) string {
+	var parts []string
+	if len(groups) > 0 {
+		parts = append(parts, fmt.Sprintf("group %q", strings.Join(groups, ",")))
+	}
+	if selector != "" {
+		parts = append(parts, fmt.Sprintf("cluster selector %q", selector))
+	}
+	return strings.Join(parts, " and ")
+}
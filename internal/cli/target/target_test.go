@@ -0,0 +1,321 @@
+package target
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/aryankumar/fleet/internal/cluster"
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/config/configtest"
+	"github.com/spf13/viper"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const testConfig = `
+clusters:
+  prod-east:
+    context: arn:aws:eks:us-east-1:123456789012:cluster/prod-east
+    alias: production
+    enabled: true
+    labels:
+      env: prod
+      region: us-east-1
+  prod-west:
+    context: prod-west
+    enabled: true
+    labels:
+      env: prod
+      region: us-west-2
+  staging:
+    enabled: true
+    labels:
+      env: staging
+      region: us-west-2
+  legacy:
+    enabled: false
+    labels:
+      env: prod
+      region: us-east-1
+groups:
+  prod:
+    - prod-east
+    - prod-west
+  west:
+    - selector:region=us-west-2
+  all:
+    - prod
+    - staging
+`
+
+func setupConfig(t *testing.T) *config.Manager {
+	t.Helper()
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	return configtest.Load(t, testConfig)
+}
+
+// testContexts are the kubeconfig contexts available to Resolve
+var testContexts = []string{
+	"arn:aws:eks:us-east-1:123456789012:cluster/prod-east",
+	"dev-local",
+	"legacy",
+	"prod-west",
+	"staging",
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name            string
+		clusters        []string
+		excludes        []string
+		groups          []string
+		selector        string
+		includeDisabled bool
+		want            []string
+		wantErr         bool
+	}{
+		{
+			name: "no flags means all clusters",
+			want: []string{},
+		},
+		{
+			name:     "explicit contexts keep their order",
+			clusters: []string{"staging", "dev-local"},
+			want:     []string{"staging", "dev-local"},
+		},
+		{
+			name:     "fleet config name resolves to context",
+			clusters: []string{"prod-east"},
+			want:     []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east"},
+		},
+		{
+			name:     "alias resolves to context",
+			clusters: []string{"production"},
+			want:     []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east"},
+		},
+		{
+			name:     "glob matches names and contexts",
+			clusters: []string{"prod-*"},
+			want:     []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east", "prod-west"},
+		},
+		{
+			name:     "glob matches across slashes in ARN contexts",
+			clusters: []string{"arn:aws:eks:*prod-east"},
+			want:     []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east"},
+		},
+		{
+			name:     "glob skips disabled clusters",
+			clusters: []string{"l*"},
+			wantErr:  true,
+		},
+		{
+			name:            "glob includes disabled clusters when asked",
+			clusters:        []string{"l*"},
+			includeDisabled: true,
+			want:            []string{"legacy"},
+		},
+		{
+			name:     "literal disabled cluster is targeted",
+			clusters: []string{"legacy"},
+			want:     []string{"legacy"},
+		},
+		{
+			name:     "regex",
+			clusters: []string{"/^(staging|dev-.*)$/"},
+			want:     []string{"dev-local", "staging"},
+		},
+		{
+			name:     "invalid regex is an error",
+			clusters: []string{"/prod-(/"},
+			wantErr:  true,
+		},
+		{
+			name:     "unmatched cluster is an error",
+			clusters: []string{"staging", "nope-*"},
+			wantErr:  true,
+		},
+		{
+			name:     "exclude from all enabled clusters",
+			excludes: []string{"prod-*"},
+			want:     []string{"dev-local", "staging"},
+		},
+		{
+			name:     "exclude by alias from explicit clusters",
+			clusters: []string{"*"},
+			excludes: []string{"production", "dev-local"},
+			want:     []string{"prod-west", "staging"},
+		},
+		{
+			name:     "exclude from group",
+			groups:   []string{"prod"},
+			excludes: []string{"prod-west"},
+			want:     []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east"},
+		},
+		{
+			name:     "unmatched exclude is an error",
+			excludes: []string{"nope"},
+			wantErr:  true,
+		},
+		{
+			name:     "excluding everything is an error",
+			groups:   []string{"prod"},
+			excludes: []string{"prod-*"},
+			wantErr:  true,
+		},
+		{
+			name:     "selector resolves to contexts",
+			selector: "env=prod",
+			want:     []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east", "prod-west"},
+		},
+		{
+			name:            "selector with disabled clusters",
+			selector:        "region=us-east-1",
+			includeDisabled: true,
+			want:            []string{"legacy", "arn:aws:eks:us-east-1:123456789012:cluster/prod-east"},
+		},
+		{
+			name:     "set-based selector",
+			selector: "region in (us-west-2),env!=prod",
+			want:     []string{"staging"},
+		},
+		{
+			name:     "clusters restrict selector matches",
+			clusters: []string{"prod-east"},
+			selector: "env=prod",
+			want:     []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east"},
+		},
+		{
+			name:   "group resolves to contexts",
+			groups: []string{"prod"},
+			want:   []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east", "prod-west"},
+		},
+		{
+			name:   "nested groups",
+			groups: []string{"all"},
+			want:   []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east", "prod-west", "staging"},
+		},
+		{
+			name:     "group and selector must both match",
+			groups:   []string{"west"},
+			selector: "env=prod",
+			want:     []string{"prod-west"},
+		},
+		{
+			name:    "unknown group is an error",
+			groups:  []string{"nope"},
+			wantErr: true,
+		},
+		{
+			name:     "group and selector matching nothing is an error",
+			groups:   []string{"prod"},
+			selector: "env=staging",
+			wantErr:  true,
+		},
+		{
+			name:     "clusters outside the selector is an error",
+			clusters: []string{"staging"},
+			selector: "env=prod",
+			wantErr:  true,
+		},
+		{
+			name:     "selector matching nothing is an error",
+			selector: "env=dev",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid selector is an error",
+			selector: "env in (prod",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configManager := setupConfig(t)
+			viper.Set("clusters", tt.clusters)
+			viper.Set("exclude-clusters", tt.excludes)
+			viper.Set("group", tt.groups)
+			viper.Set("cluster-selector", tt.selector)
+			viper.Set("include-disabled", tt.includeDisabled)
+
+			got, err := Resolve(configManager, testContexts, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnect(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	kubeconfig := api.Config{
+		Clusters:  make(map[string]*api.Cluster),
+		AuthInfos: map[string]*api.AuthInfo{"user": {Token: "token"}},
+		Contexts:  make(map[string]*api.Context),
+	}
+	for _, name := range []string{"prod-west", "staging", "legacy"} {
+		kubeconfig.Clusters[name] = &api.Cluster{Server: "https://" + name + ".example.com:6443"}
+		kubeconfig.Contexts[name] = &api.Context{Cluster: name, AuthInfo: "user"}
+	}
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := clientcmd.WriteToFile(kubeconfig, kubeconfigPath); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		includeDisabled bool
+		want            []string
+	}{
+		{
+			name: "disabled clusters skipped",
+			want: []string{"prod-west", "staging"},
+		},
+		{
+			name:            "include disabled",
+			includeDisabled: true,
+			want:            []string{"legacy", "prod-west", "staging"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.NewContext(context.Background(), setupConfig(t))
+			viper.Set("include-disabled", tt.includeDisabled)
+
+			mgr := cluster.NewManager(config.NewKubeconfigLoader(kubeconfigPath), logger)
+			defer mgr.Close()
+
+			if err := Connect(ctx, mgr, logger); err != nil {
+				t.Fatalf("Connect() error = %v", err)
+			}
+
+			if mgr.Count() != len(tt.want) {
+				t.Errorf("got %d clusters, want %d", mgr.Count(), len(tt.want))
+			}
+			for _, name := range tt.want {
+				if !mgr.HasClient(name) {
+					t.Errorf("expected cluster %q to be connected", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Default circuit breaker settings
+const (
+	DefaultBreakerThreshold = 3
+	DefaultBreakerCooldown  = 2 * time.Minute
+)
+
+// BreakerState is the state of a cluster's circuit
+type BreakerState string
+
+const (
+	// BreakerClosed lets requests through; failures are being counted
+	BreakerClosed BreakerState = "closed"
+
+	// BreakerOpen skips the cluster until the cool-down has passed
+	BreakerOpen BreakerState = "open"
+
+	// BreakerHalfOpen lets a trial request through after the cool-down; its
+	// outcome closes or re-opens the circuit
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// ErrCircuitOpen is matched by errors returned for clusters whose circuit is open
+var ErrCircuitOpen = errors.New("circuit open")
+
+// CircuitOpenError reports a cluster skipped because its circuit is open
+type CircuitOpenError struct {
+	Cluster   string
+	Failures  int
+	LastError string
+	RetryAt   time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	msg := fmt.Sprintf("circuit open after %d consecutive failures, skipping until %s",
+		e.Failures, e.RetryAt.Format(time.TimeOnly))
+	if e.LastError != "" {
+		msg += " (last error: " + e.LastError + ")"
+	}
+	return msg
+}
+
+// Is makes errors.Is(err, ErrCircuitOpen) match
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// BreakerOptions configures a CircuitBreaker; zero values use the defaults
+type BreakerOptions struct {
+	// Threshold is the number of consecutive failures that opens a circuit
+	Threshold int
+
+	// Cooldown is how long a circuit stays open before a trial request
+	Cooldown time.Duration
+
+	// StatePath is the file that shares circuit state between invocations;
+	// empty keeps state in memory only
+	StatePath string
+}
+
+// circuit is the persisted state of one cluster's circuit
+type circuit struct {
+	State     BreakerState `json:"state"`
+	Failures  int          `json:"failures"`
+	OpenedAt  time.Time    `json:"openedAt,omitempty"`
+	LastError string       `json:"lastError,omitempty"`
+
+	// trial marks a half-open trial in flight in this process
+	trial bool
+}
+
+// breakerFile is the layout of the state file
+type breakerFile struct {
+	Clusters map[string]*circuit `json:"clusters"`
+}
+
+// CircuitBreaker tracks consecutive availability failures per cluster and
+// skips clusters that keep failing, so one dead API server doesn't make every
+// command wait for its timeout
+//
+// Only failures that suggest the cluster is unavailable count: timeouts,
+// network errors and 5xx responses. Other errors, such as a missing resource,
+// reset the count like a success. State is written to a small JSON file so
+// that separate CLI invocations share it.
+type CircuitBreaker struct {
+	opts   BreakerOptions
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// NewCircuitBreaker creates a breaker, loading any state saved at opts.StatePath
+// An unreadable state file is logged and ignored.
+func NewCircuitBreaker(opts BreakerOptions, logger *slog.Logger) *CircuitBreaker {
+	if opts.Threshold <= 0 {
+		opts.Threshold = DefaultBreakerThreshold
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = DefaultBreakerCooldown
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	b := &CircuitBreaker{
+		opts:     opts,
+		logger:   logger,
+		now:      time.Now,
+		circuits: make(map[string]*circuit),
+	}
+
+	if opts.StatePath != "" {
+		saved, err := b.readState()
+		if err != nil {
+			logger.Warn("ignoring unreadable circuit breaker state", "file", opts.StatePath, "error", err)
+		} else {
+			b.circuits = saved.Clusters
+		}
+	}
+
+	return b
+}
+
+// State returns a cluster's circuit state
+func (b *CircuitBreaker) State(cluster string) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c, ok := b.circuits[cluster]; ok {
+		return c.State
+	}
+	return BreakerClosed
+}
+
+// Allow returns a *CircuitOpenError if the cluster should be skipped
+// Once the cool-down has passed the circuit goes half-open and one trial
+// request is let through.
+func (b *CircuitBreaker) Allow(cluster string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[cluster]
+	if !ok || c.State == BreakerClosed {
+		return nil
+	}
+
+	retryAt := c.OpenedAt.Add(b.opts.Cooldown)
+	if c.State == BreakerOpen && !b.now().Before(retryAt) {
+		b.logger.Info("circuit half-open, trying cluster again", "cluster", cluster)
+		c.State = BreakerHalfOpen
+		b.persist(cluster, c)
+	}
+	if c.State == BreakerHalfOpen && !c.trial {
+		c.trial = true
+		return nil
+	}
+
+	return &CircuitOpenError{
+		Cluster:   cluster,
+		Failures:  c.Failures,
+		LastError: c.LastError,
+		RetryAt:   retryAt,
+	}
+}
+
+// Record updates a cluster's circuit with the outcome of a request
+func (b *CircuitBreaker) Record(cluster string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[cluster]
+	if err != nil && errors.Is(err, context.Canceled) {
+		// The user gave up; that says nothing about the cluster, but a
+		// cancelled trial must let the next request try again
+		if ok {
+			c.trial = false
+		}
+		return
+	}
+	if err == nil || !countsAsFailure(err) {
+		if ok {
+			if c.State != BreakerClosed {
+				b.logger.Info("circuit closed", "cluster", cluster)
+			}
+			delete(b.circuits, cluster)
+			b.persist(cluster, nil)
+		}
+		return
+	}
+
+	if !ok {
+		c = &circuit{State: BreakerClosed}
+		b.circuits[cluster] = c
+	}
+	c.trial = false
+	c.Failures++
+	c.LastError = err.Error()
+
+	if c.State == BreakerHalfOpen || (c.State == BreakerClosed && c.Failures >= b.opts.Threshold) {
+		c.State = BreakerOpen
+		c.OpenedAt = b.now()
+		b.logger.Warn("circuit opened, skipping cluster",
+			"cluster", cluster,
+			"failures", c.Failures,
+			"cooldown", b.opts.Cooldown)
+	}
+	b.persist(cluster, c)
+}
+
+// countsAsFailure reports whether an error suggests the cluster is unavailable
+func countsAsFailure(err error) bool {
+	switch classifyProbeError(err) {
+	case ErrorClassTimeout, ErrorClassUnreachable, ErrorClassNotReady:
+		return true
+	}
+	return false
+}
+
+// persist writes one cluster's circuit to the state file, leaving entries
+// written by other invocations in place. nil removes the cluster.
+// Callers must hold b.mu.
+func (b *CircuitBreaker) persist(cluster string, c *circuit) {
+	if b.opts.StatePath == "" {
+		return
+	}
+
+	state, err := b.readState()
+	if err != nil {
+		state = &breakerFile{Clusters: make(map[string]*circuit)}
+	}
+	if c == nil {
+		delete(state.Clusters, cluster)
+	} else {
+		state.Clusters[cluster] = c
+	}
+
+	if err := b.writeState(state); err != nil {
+		b.logger.Warn("failed to save circuit breaker state", "file", b.opts.StatePath, "error", err)
+	}
+}
+
+func (b *CircuitBreaker) readState() (*breakerFile, error) {
+	state := &breakerFile{}
+	data, err := os.ReadFile(b.opts.StatePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, state); err != nil {
+			return nil, fmt.Errorf("invalid state file: %w", err)
+		}
+	}
+	if state.Clusters == nil {
+		state.Clusters = make(map[string]*circuit)
+	}
+	return state, nil
+}
+
+// writeState replaces the state file atomically so concurrent invocations
+// never read a partial file
+func (b *CircuitBreaker) writeState(state *breakerFile) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(b.opts.StatePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(b.opts.StatePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), b.opts.StatePath)
+}
@@ -0,0 +1,186 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newTestBreaker(t *testing.T, path string, now *time.Time) *CircuitBreaker {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	b := NewCircuitBreaker(BreakerOptions{Threshold: 2, Cooldown: time.Minute, StatePath: path}, logger)
+	b.now = func() time.Time { return *now }
+	return b
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(t, "", &now)
+	timeout := fmt.Errorf("list pods: %w", context.DeadlineExceeded)
+
+	b.Record("prod", timeout)
+	if err := b.Allow("prod"); err != nil {
+		t.Fatalf("Allow() after one failure = %v, want nil", err)
+	}
+
+	b.Record("prod", timeout)
+	if b.State("prod") != BreakerOpen {
+		t.Fatalf("State() = %q, want %q", b.State("prod"), BreakerOpen)
+	}
+	err := b.Allow("prod")
+	var openErr *CircuitOpenError
+	if !errors.Is(err, ErrCircuitOpen) || !errors.As(err, &openErr) {
+		t.Fatalf("Allow() = %v, want a circuit open error", err)
+	}
+	if openErr.Failures != 2 || !openErr.RetryAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("open error = %+v, want 2 failures retrying after the cool-down", openErr)
+	}
+
+	// After the cool-down exactly one trial is let through
+	now = now.Add(time.Minute)
+	if err := b.Allow("prod"); err != nil {
+		t.Fatalf("Allow() after cool-down = %v, want a trial", err)
+	}
+	if b.State("prod") != BreakerHalfOpen {
+		t.Errorf("State() = %q, want %q", b.State("prod"), BreakerHalfOpen)
+	}
+	if err := b.Allow("prod"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("second Allow() while half-open = %v, want circuit open", err)
+	}
+
+	// A failed trial re-opens the circuit straight away
+	b.Record("prod", timeout)
+	if b.State("prod") != BreakerOpen {
+		t.Fatalf("State() after failed trial = %q, want %q", b.State("prod"), BreakerOpen)
+	}
+
+	// A successful trial closes it
+	now = now.Add(time.Minute)
+	if err := b.Allow("prod"); err != nil {
+		t.Fatalf("Allow() after cool-down = %v, want a trial", err)
+	}
+	b.Record("prod", nil)
+	if b.State("prod") != BreakerClosed || b.Allow("prod") != nil {
+		t.Errorf("State() after successful trial = %q, want %q", b.State("prod"), BreakerClosed)
+	}
+}
+
+func TestCircuitBreaker_IgnoredErrors(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(t, "", &now)
+	timeout := context.DeadlineExceeded
+
+	// Errors from a working API server reset the count
+	b.Record("prod", timeout)
+	b.Record("prod", apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "web"))
+	b.Record("prod", timeout)
+	if b.State("prod") != BreakerClosed {
+		t.Errorf("State() = %q, want a not found error to reset the count", b.State("prod"))
+	}
+
+	// Cancellation says nothing about the cluster
+	b.Record("prod", context.Canceled)
+	if b.State("prod") != BreakerClosed {
+		t.Errorf("State() = %q, want cancellation ignored", b.State("prod"))
+	}
+	b.Record("prod", apierrors.NewServiceUnavailable("etcd down"))
+	if b.State("prod") != BreakerOpen {
+		t.Errorf("State() = %q, want a 503 to count as a failure", b.State("prod"))
+	}
+}
+
+func TestCircuitBreaker_CancelledTrial(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(t, "", &now)
+	b.Record("prod", context.DeadlineExceeded)
+	b.Record("prod", context.DeadlineExceeded)
+
+	now = now.Add(time.Minute)
+	if err := b.Allow("prod"); err != nil {
+		t.Fatalf("Allow() after cool-down = %v, want a trial", err)
+	}
+	b.Record("prod", fmt.Errorf("list pods: %w", context.Canceled))
+
+	// The cancelled trial neither closes nor re-opens the circuit, and the
+	// next request becomes the trial
+	if b.State("prod") != BreakerHalfOpen {
+		t.Errorf("State() = %q, want %q", b.State("prod"), BreakerHalfOpen)
+	}
+	if err := b.Allow("prod"); err != nil {
+		t.Errorf("Allow() after a cancelled trial = %v, want another trial", err)
+	}
+}
+
+func TestCircuitBreaker_SharedState(t *testing.T) {
+	now := time.Now()
+	path := filepath.Join(t.TempDir(), "circuits.json")
+
+	first := newTestBreaker(t, path, &now)
+	first.Record("prod", context.DeadlineExceeded)
+	first.Record("prod", context.DeadlineExceeded)
+	first.Record("staging", context.DeadlineExceeded)
+
+	// A later invocation sees the open circuit
+	second := newTestBreaker(t, path, &now)
+	if !errors.Is(second.Allow("prod"), ErrCircuitOpen) {
+		t.Error("expected the open circuit to be loaded from the state file")
+	}
+
+	// Closing one circuit leaves entries written by others in place
+	second.Record("prod", nil)
+	third := newTestBreaker(t, path, &now)
+	if third.State("prod") != BreakerClosed {
+		t.Errorf("prod state = %q, want closed", third.State("prod"))
+	}
+	third.Record("staging", context.DeadlineExceeded)
+	if third.State("staging") != BreakerOpen {
+		t.Errorf("staging state = %q, want the saved failure counted", third.State("staging"))
+	}
+
+	// A corrupt file is ignored
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if b := newTestBreaker(t, path, &now); b.State("staging") != BreakerClosed {
+		t.Error("expected a corrupt state file to be ignored")
+	}
+}
+
+func TestGuard(t *testing.T) {
+	m := NewManager(nil, nil)
+	client := &Client{Name: "prod"}
+	calls := 0
+	fn := func(ctx context.Context, _ *Client) (int, error) {
+		calls++
+		return 0, context.DeadlineExceeded
+	}
+
+	// Without a breaker the task runs every time
+	for i := 0; i < 3; i++ {
+		Guard(m, fn)(context.Background(), client)
+	}
+	if calls != 3 {
+		t.Fatalf("calls = %d, want 3", calls)
+	}
+
+	now := time.Now()
+	m.SetCircuitBreaker(newTestBreaker(t, "", &now))
+	calls = 0
+	var err error
+	for i := 0; i < 3; i++ {
+		_, err = Guard(m, fn)(context.Background(), client)
+	}
+	if calls != 2 || !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("calls = %d, err = %v, want the third call skipped", calls, err)
+	}
+}
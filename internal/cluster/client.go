@@ -28,7 +28,6 @@ func NewClient(ctx context.Context, name string, contextName string, restConfig
 		Context:    contextName,
 		Clientset:  clientset,
 		RestConfig: restConfig,
-		Healthy:    false, // Will be set by health check
 	}
 
 	logger.Debug("created cluster client",
@@ -66,14 +65,14 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 	// Wait for either result or context cancellation
 	select {
 	case <-healthCtx.Done():
-		c.Healthy = false
+		c.SetHealthy(false)
 		return fmt.Errorf("health check timeout: %w", healthCtx.Err())
 	case res := <-resultCh:
 		if res.err != nil {
-			c.Healthy = false
+			c.SetHealthy(false)
 			return fmt.Errorf("failed to get server version: %w", res.err)
 		}
-		c.Healthy = true
+		c.SetHealthy(true)
 		return nil
 	}
 }
@@ -106,12 +105,17 @@ func (c *Client) GetServerVersion(ctx context.Context) (string, error) {
 	}
 }
 
-// IsHealthy returns the current health status
+// IsHealthy returns the result of the last health check
 func (c *Client) IsHealthy() bool {
-	return c.Healthy
+	return c.healthy.Load()
+}
+
+// SetHealthy records the result of a health check
+func (c *Client) SetHealthy(healthy bool) {
+	c.healthy.Store(healthy)
 }
 
 // String returns a string representation of the client
 func (c *Client) String() string {
-	return fmt.Sprintf("Client{Name: %s, Context: %s, Healthy: %v}", c.Name, c.Context, c.Healthy)
+	return fmt.Sprintf("Client{Name: %s, Context: %s, Healthy: %v}", c.Name, c.Context, c.IsHealthy())
 }
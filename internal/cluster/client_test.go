@@ -73,7 +73,7 @@ func TestNewClient(t *testing.T) {
 				t.Errorf("expected context %s, got %s", tt.contextName, client.Context)
 			}
 
-			if client.Healthy {
+			if client.IsHealthy() {
 				t.Error("expected Healthy to be false initially")
 			}
 		})
@@ -115,7 +115,6 @@ func TestClient_HealthCheck(t *testing.T) {
 					RestConfig: &rest.Config{
 						Host: "https://localhost:6443",
 					},
-					Healthy: false,
 				}
 			},
 			wantErr: false,
@@ -137,7 +136,6 @@ func TestClient_HealthCheck(t *testing.T) {
 					RestConfig: &rest.Config{
 						Host: "https://localhost:6443",
 					},
-					Healthy: false,
 				}
 			},
 			wantErr: true,
@@ -155,7 +153,7 @@ func TestClient_HealthCheck(t *testing.T) {
 				if err == nil {
 					t.Error("expected error, got nil")
 				}
-				if client.Healthy {
+				if client.IsHealthy() {
 					t.Error("expected Healthy to be false after failed check")
 				}
 				return
@@ -166,7 +164,7 @@ func TestClient_HealthCheck(t *testing.T) {
 				return
 			}
 
-			if !client.Healthy {
+			if !client.IsHealthy() {
 				t.Error("expected Healthy to be true after successful check")
 			}
 		})
@@ -185,7 +183,6 @@ func TestClient_HealthCheck_ContextCancellation(t *testing.T) {
 		RestConfig: &rest.Config{
 			Host: "https://localhost:6443",
 		},
-		Healthy: false,
 	}
 
 	// Create a context that's already cancelled
@@ -198,7 +195,7 @@ func TestClient_HealthCheck_ContextCancellation(t *testing.T) {
 		t.Error("expected error from cancelled context")
 	}
 
-	if client.Healthy {
+	if client.IsHealthy() {
 		t.Error("expected Healthy to be false after cancelled context")
 	}
 }
@@ -227,7 +224,6 @@ func TestClient_HealthCheck_Timeout(t *testing.T) {
 		RestConfig: &rest.Config{
 			Host: "https://localhost:6443",
 		},
-		Healthy: false,
 	}
 
 	ctx := context.Background()
@@ -237,7 +233,7 @@ func TestClient_HealthCheck_Timeout(t *testing.T) {
 		t.Error("expected timeout error")
 	}
 
-	if client.Healthy {
+	if client.IsHealthy() {
 		t.Error("expected Healthy to be false after timeout")
 	}
 }
@@ -284,14 +280,14 @@ func TestClient_IsHealthy(t *testing.T) {
 	client := &Client{
 		Name:    "test-cluster",
 		Context: "test-context",
-		Healthy: true,
 	}
+	client.SetHealthy(true)
 
 	if !client.IsHealthy() {
 		t.Error("expected IsHealthy to return true")
 	}
 
-	client.Healthy = false
+	client.SetHealthy(false)
 	if client.IsHealthy() {
 		t.Error("expected IsHealthy to return false")
 	}
@@ -301,8 +297,8 @@ func TestClient_String(t *testing.T) {
 	client := &Client{
 		Name:    "test-cluster",
 		Context: "test-context",
-		Healthy: true,
 	}
+	client.SetHealthy(true)
 
 	str := client.String()
 
@@ -0,0 +1,214 @@
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// Credential types reported in CredentialStatus
+const (
+	CredentialClientCertificate = "client-certificate"
+	CredentialToken             = "token"
+	CredentialExec              = "exec"
+	CredentialAuthProvider      = "auth-provider"
+	CredentialBasic             = "basic"
+	CredentialNone              = "none"
+)
+
+// ComponentCheck is one line of the API server's /readyz?verbose output
+type ComponentCheck struct {
+	Name    string
+	Healthy bool
+
+	// Message is the failure reason, empty for passing checks
+	Message string
+}
+
+// NodeCounts summarises node readiness
+type NodeCounts struct {
+	Total int
+	Ready int
+}
+
+// CredentialStatus describes the credentials used to reach a cluster
+type CredentialStatus struct {
+	// Type is one of the Credential* constants
+	Type string
+
+	// Expiry is when a client certificate or token expires; zero if it does
+	// not expire or cannot be read, as with exec plugins that refresh themselves
+	Expiry time.Time
+}
+
+// inspect adds the /readyz check breakdown and node readiness to a status
+// whose basic health check passed. A failing readyz check marks it unhealthy.
+func (c *Client) inspect(ctx context.Context, status *HealthStatus) {
+	if client, ok := c.restClient(); ok {
+		body, err := client.Get().AbsPath("/readyz").Param("verbose", "").DoRaw(ctx)
+		status.Checks = parseReadyz(body)
+		if err != nil {
+			status.Healthy = false
+			status.ErrorClass = classifyProbeError(err)
+			status.Error = readyzError(status.Checks, err)
+			c.SetHealthy(false)
+		}
+	}
+
+	nodes, err := c.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		// Listing nodes needs cluster-wide read access the user may not have
+		return
+	}
+	status.Nodes = &NodeCounts{Total: len(nodes.Items)}
+	for i := range nodes.Items {
+		if nodeReady(&nodes.Items[i]) {
+			status.Nodes.Ready++
+		}
+	}
+}
+
+// restClient returns the discovery REST client, which fake clientsets leave nil
+func (c *Client) restClient() (rest.Interface, bool) {
+	client := c.Clientset.Discovery().RESTClient()
+	if typed, ok := client.(*rest.RESTClient); client == nil || (ok && typed == nil) {
+		return nil, false
+	}
+	return client, true
+}
+
+// parseReadyz parses verbose health output such as
+//
+//	[+]ping ok
+//	[-]etcd failed: reason withheld
+//	readyz check failed
+func parseReadyz(body []byte) []ComponentCheck {
+	var checks []ComponentCheck
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		var healthy bool
+		switch {
+		case strings.HasPrefix(line, "[+]"):
+			healthy = true
+		case strings.HasPrefix(line, "[-]"):
+		default:
+			continue
+		}
+
+		name, message, _ := strings.Cut(line[3:], " ")
+		check := ComponentCheck{Name: name, Healthy: healthy}
+		if !healthy {
+			check.Message = strings.TrimPrefix(message, "failed: ")
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// readyzError names the failed checks, falling back to the request error
+func readyzError(checks []ComponentCheck, err error) error {
+	var failed []string
+	for _, check := range checks {
+		if !check.Healthy {
+			failed = append(failed, check.Name)
+		}
+	}
+	if len(failed) == 0 {
+		return fmt.Errorf("readyz check failed: %w", err)
+	}
+	return fmt.Errorf("readyz check failed: %s", strings.Join(failed, ", "))
+}
+
+func nodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// Credentials reports how the client authenticates and when its credentials
+// expire, when that can be read locally
+func (c *Client) Credentials() *CredentialStatus {
+	cfg := c.RestConfig
+	if cfg == nil {
+		return nil
+	}
+
+	switch {
+	case cfg.ExecProvider != nil:
+		return &CredentialStatus{Type: CredentialExec}
+	case cfg.AuthProvider != nil:
+		return &CredentialStatus{Type: CredentialAuthProvider}
+	case len(cfg.CertData) > 0 || cfg.CertFile != "":
+		status := &CredentialStatus{Type: CredentialClientCertificate}
+		data := cfg.CertData
+		if len(data) == 0 {
+			data, _ = os.ReadFile(cfg.CertFile)
+		}
+		status.Expiry = certificateExpiry(data)
+		return status
+	case cfg.BearerToken != "" || cfg.BearerTokenFile != "":
+		status := &CredentialStatus{Type: CredentialToken}
+		token := cfg.BearerToken
+		if token == "" {
+			if data, err := os.ReadFile(cfg.BearerTokenFile); err == nil {
+				token = strings.TrimSpace(string(data))
+			}
+		}
+		status.Expiry = tokenExpiry(token)
+		return status
+	case cfg.Username != "":
+		return &CredentialStatus{Type: CredentialBasic}
+	default:
+		return &CredentialStatus{Type: CredentialNone}
+	}
+}
+
+// certificateExpiry returns the NotAfter time of the first PEM certificate,
+// zero if there is none
+func certificateExpiry(data []byte) time.Time {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return time.Time{}
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}
+	}
+	return cert.NotAfter
+}
+
+// tokenExpiry returns the exp claim of a JWT, such as a service account
+// token, zero if the token is opaque or has no expiry
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
@@ -0,0 +1,139 @@
+package cluster
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestParseReadyz(t *testing.T) {
+	body := []byte("[+]ping ok\n[+]poststarthook/start-informers ok\n[-]etcd failed: reason withheld\nreadyz check failed\n")
+
+	want := []ComponentCheck{
+		{Name: "ping", Healthy: true},
+		{Name: "poststarthook/start-informers", Healthy: true},
+		{Name: "etcd", Healthy: false, Message: "reason withheld"},
+	}
+	if got := parseReadyz(body); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseReadyz() = %+v, want %+v", got, want)
+	}
+}
+
+func TestManager_HealthCheckWithStatus_Detail(t *testing.T) {
+	server := newFakeAPIServer(t)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	m := NewManager(nil, logger)
+	client := newTestClient(t, "test", server.URL)
+	m.clients["test"] = client
+
+	status := m.HealthCheckWithStatus(context.Background())[0]
+	if !status.Healthy || status.ServerVersion != "v1.31.0" || status.Latency <= 0 {
+		t.Errorf("status = %+v, want healthy v1.31.0 with a latency", status)
+	}
+	if len(status.Checks) != 2 {
+		t.Errorf("checks = %+v, want ping and etcd", status.Checks)
+	}
+	if status.Nodes == nil || *status.Nodes != (NodeCounts{Total: 2, Ready: 1}) {
+		t.Errorf("nodes = %+v, want 1 of 2 ready", status.Nodes)
+	}
+	if status.Credentials == nil || status.Credentials.Type != CredentialNone {
+		t.Errorf("credentials = %+v, want none", status.Credentials)
+	}
+
+	// A failing readyz check makes the cluster unhealthy
+	server.ready.Store(false)
+	status = m.HealthCheckWithStatus(context.Background())[0]
+	if status.Healthy || status.ErrorClass != ErrorClassNotReady {
+		t.Errorf("status = %+v, want unhealthy and %s", status, ErrorClassNotReady)
+	}
+	if status.Error == nil || status.Error.Error() != "readyz check failed: etcd" {
+		t.Errorf("error = %v, want the failed check named", status.Error)
+	}
+	if client.IsHealthy() {
+		t.Error("expected the client to be marked unhealthy")
+	}
+}
+
+func TestClient_Credentials(t *testing.T) {
+	expiry := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name       string
+		config     *rest.Config
+		wantType   string
+		wantExpiry time.Time
+	}{
+		{
+			name:       "client certificate",
+			config:     &rest.Config{TLSClientConfig: rest.TLSClientConfig{CertData: testCertificate(t, expiry)}},
+			wantType:   CredentialClientCertificate,
+			wantExpiry: expiry,
+		},
+		{
+			name:       "service account token",
+			config:     &rest.Config{BearerToken: testJWT(expiry)},
+			wantType:   CredentialToken,
+			wantExpiry: expiry,
+		},
+		{
+			name:     "opaque token",
+			config:   &rest.Config{BearerToken: "abc123"},
+			wantType: CredentialToken,
+		},
+		{
+			name:     "exec plugin",
+			config:   &rest.Config{ExecProvider: &api.ExecConfig{Command: "aws"}},
+			wantType: CredentialExec,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := (&Client{RestConfig: tt.config}).Credentials()
+			if got.Type != tt.wantType || !got.Expiry.Equal(tt.wantExpiry) {
+				t.Errorf("Credentials() = %+v, want %s expiring %v", got, tt.wantType, tt.wantExpiry)
+			}
+		})
+	}
+}
+
+func testCertificate(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "admin"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func testJWT(exp time.Time) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"none"}`)) + "." +
+		encode([]byte(fmt.Sprintf(`{"sub":"system:serviceaccount:default:fleet","exp":%d}`, exp.Unix()))) + ".sig"
+}
@@ -2,11 +2,17 @@ package cluster
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/inventory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd/api"
 )
 
 // Manager manages connections to multiple Kubernetes clusters
@@ -25,6 +31,25 @@ type Manager struct {
 	// logger for structured logging
 	logger *slog.Logger
 
+	// fleetConfig provides per-cluster settings such as the enabled flag and
+	// connection overrides
+	// nil means every kubeconfig context is treated as enabled
+	fleetConfig *config.FleetConfig
+
+	// includeDisabled makes ConnectAll ignore enabled: false in the fleet config
+	includeDisabled bool
+
+	// inventory holds the clusters discovered by LoadInventory as kubeconfig
+	// contexts; nil until it is called
+	inventory *api.Config
+
+	// monitor probes cluster health in the background; nil until
+	// StartHealthMonitor is called
+	monitor *HealthMonitor
+
+	// breaker skips clusters that keep failing; nil disables it
+	breaker *CircuitBreaker
+
 	// closed indicates if the manager has been closed
 	closed bool
 }
@@ -43,6 +68,17 @@ func NewManager(loader *config.KubeconfigLoader, logger *slog.Logger) *Manager {
 	}
 }
 
+// SetFleetConfig sets the fleet configuration consulted when connecting
+func (m *Manager) SetFleetConfig(cfg *config.FleetConfig) {
+	m.fleetConfig = cfg
+}
+
+// SetIncludeDisabled controls whether ConnectAll also connects to clusters
+// marked enabled: false in the fleet config
+func (m *Manager) SetIncludeDisabled(include bool) {
+	m.includeDisabled = include
+}
+
 // Connect establishes connections to the specified clusters
 // It performs concurrent connection establishment for efficiency
 // Returns an error if any connections fail, but continues trying all clusters
@@ -94,8 +130,9 @@ func (m *Manager) Connect(ctx context.Context, clusterNames []string) error {
 
 			m.logger.Debug("connecting to cluster", "cluster", clusterName)
 
-			// Build REST config for this cluster
-			restConfig, err := m.loader.BuildClientConfig(clusterName)
+			// Build REST config for this cluster, applying fleet config overrides
+			overrides := m.clusterOverrides(clusterName)
+			restConfig, err := m.buildClientConfig(clusterName, overrides)
 			if err != nil {
 				m.logger.Error("failed to build client config",
 					"cluster", clusterName,
@@ -106,6 +143,9 @@ func (m *Manager) Connect(ctx context.Context, clusterNames []string) error {
 				return
 			}
 
+			limiter := m.newRateLimiter(overrides)
+			limiter.Install(restConfig, clusterName, m.logger)
+
 			// Create the client
 			client, err := NewClient(ctx, clusterName, clusterName, restConfig, m.logger)
 			if err != nil {
@@ -117,6 +157,11 @@ func (m *Manager) Connect(ctx context.Context, clusterNames []string) error {
 				mu.Unlock()
 				return
 			}
+			client.RateLimiter = limiter
+			if overrides != nil {
+				client.Namespace = overrides.Namespace
+				client.Timeout = overrides.Timeout
+			}
 
 			// Store the client (thread-safe)
 			m.mu.Lock()
@@ -153,11 +198,151 @@ func (m *Manager) Connect(ctx context.Context, clusterNames []string) error {
 	return nil
 }
 
+// Contexts returns the context names available in the kubeconfig, plus the
+// contexts of fleet config clusters that use a dedicated kubeconfig file and
+// those discovered by LoadInventory
+func (m *Manager) Contexts() ([]string, error) {
+	contexts, err := m.loader.GetContexts()
+	if err != nil {
+		return nil, err
+	}
+
+	if m.fleetConfig != nil {
+		for _, contextName := range m.fleetConfig.DedicatedKubeconfigContexts() {
+			if !slices.Contains(contexts, contextName) {
+				contexts = append(contexts, contextName)
+			}
+		}
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.inventory != nil {
+		for contextName := range m.inventory.Contexts {
+			if !slices.Contains(contexts, contextName) {
+				contexts = append(contexts, contextName)
+			}
+		}
+	}
+
+	return contexts, nil
+}
+
+// LoadInventory discovers clusters from the given sources and makes them
+// available to Contexts and Connect
+//
+// Contexts already in the kubeconfig, or using a dedicated kubeconfig, take
+// precedence over discovered ones. Failing sources are reported in the
+// returned error; clusters from the other sources are still added.
+func (m *Manager) LoadInventory(ctx context.Context, sources []inventory.Source) error {
+	if len(sources) == 0 {
+		return nil
+	}
+
+	known, err := m.Contexts()
+	if err != nil {
+		return fmt.Errorf("failed to get contexts: %w", err)
+	}
+
+	discovered, err := inventory.Merge(ctx, sources, known, m.logger)
+
+	m.mu.Lock()
+	m.inventory = discovered
+	m.mu.Unlock()
+
+	m.logger.Debug("loaded cluster inventory",
+		"sources", len(sources),
+		"clusters", len(discovered.Contexts))
+
+	return err
+}
+
+// LoadConfiguredInventory loads the inventory sources configured in the
+// fleet config; the hub cluster is reached through this manager's kubeconfig
+// A misconfigured hub is reported in the returned error without stopping
+// the other sources.
+func (m *Manager) LoadConfiguredInventory(ctx context.Context, cfg config.InventoryConfig) error {
+	sources, err := inventory.FromConfig(cfg, m.loader)
+	return errors.Join(err, m.LoadInventory(ctx, sources))
+}
+
+// buildClientConfig builds the REST config for a context from the kubeconfig,
+// or from the discovered inventory if that is where the context came from
+func (m *Manager) buildClientConfig(contextName string, overrides *config.ClusterConfig) (*rest.Config, error) {
+	m.mu.RLock()
+	discovered := m.inventory
+	m.mu.RUnlock()
+
+	if discovered != nil && (overrides == nil || overrides.Kubeconfig == "") {
+		if _, ok := discovered.Contexts[contextName]; ok {
+			return config.BuildClientConfigFromKubeconfig(discovered, contextName, overrides)
+		}
+	}
+
+	return m.loader.BuildClientConfigWithOverrides(contextName, overrides)
+}
+
+// MaxTimeout returns the longest effective timeout across connected clients
+// Use it as the overall deadline so per-cluster timeout overrides can take effect
+func (m *Manager) MaxTimeout(fallback time.Duration) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	longest := fallback
+	for _, client := range m.clients {
+		longest = max(longest, client.EffectiveTimeout(fallback))
+	}
+	return longest
+}
+
+// newRateLimiter builds a cluster's rate limiter from its QPS and burst
+// overrides, falling back to the fleet config defaults and then client-go's
+func (m *Manager) newRateLimiter(overrides *config.ClusterConfig) *RateLimiter {
+	qps, burst := float32(rest.DefaultQPS), rest.DefaultBurst
+	adaptive := false
+	if m.fleetConfig != nil {
+		defaults := m.fleetConfig.Defaults
+		if defaults.QPS > 0 {
+			qps = defaults.QPS
+		}
+		if defaults.Burst > 0 {
+			burst = defaults.Burst
+		}
+		adaptive = defaults.AdaptiveQPS
+	}
+
+	if overrides != nil {
+		// A cluster that only raises its QPS gets a burst to match
+		if overrides.QPS > 0 {
+			qps = overrides.QPS
+			burst = max(burst, int(qps))
+		}
+		if overrides.Burst > 0 {
+			burst = overrides.Burst
+		}
+	}
+
+	return NewRateLimiter(qps, burst, adaptive)
+}
+
+// clusterOverrides returns the fleet config settings for a context, nil if none
+func (m *Manager) clusterOverrides(contextName string) *config.ClusterConfig {
+	if m.fleetConfig == nil {
+		return nil
+	}
+	cluster, ok := m.fleetConfig.ClusterForContext(contextName)
+	if !ok {
+		return nil
+	}
+	return cluster
+}
+
 // ConnectAll establishes connections to all available clusters in the kubeconfig
+// Clusters disabled in the fleet config are skipped unless SetIncludeDisabled(true) was called
 func (m *Manager) ConnectAll(ctx context.Context) error {
 	m.logger.Debug("discovering all contexts from kubeconfig")
 
-	contexts, err := m.loader.GetContexts()
+	contexts, err := m.Contexts()
 	if err != nil {
 		return fmt.Errorf("failed to get contexts: %w", err)
 	}
@@ -167,6 +352,18 @@ func (m *Manager) ConnectAll(ctx context.Context) error {
 	}
 
 	m.logger.Info("discovered contexts", "count", len(contexts))
+
+	if m.fleetConfig != nil && !m.includeDisabled {
+		var disabled []string
+		contexts, disabled = m.fleetConfig.EnabledContexts(contexts)
+		for _, contextName := range disabled {
+			m.logger.Info("skipping disabled cluster", "cluster", contextName)
+		}
+		if len(contexts) == 0 {
+			return fmt.Errorf("all contexts are disabled in fleet config (use --include-disabled to override)")
+		}
+	}
+
 	return m.Connect(ctx, contexts)
 }
 
@@ -301,6 +498,8 @@ func (m *Manager) HealthCheck(ctx context.Context) map[string]error {
 }
 
 // HealthCheckWithStatus performs health checks and returns detailed status
+// Reachable clusters also get the /readyz check breakdown, node readiness and
+// credential expiry; a failing readyz check makes a cluster unhealthy.
 func (m *Manager) HealthCheckWithStatus(ctx context.Context) []HealthStatus {
 	m.logger.Debug("starting detailed health checks")
 
@@ -345,15 +544,21 @@ func (m *Manager) HealthCheckWithStatus(ctx context.Context) []HealthStatus {
 			}
 
 			// Perform health check
+			start := time.Now()
 			err := c.HealthCheck(ctx)
+			status.Latency = time.Since(start)
 			status.Error = err
 			status.Healthy = err == nil
+			status.Credentials = c.Credentials()
 
-			// Get server version if healthy
+			// Get server version and component detail if healthy
 			if err == nil {
 				if version, vErr := c.GetServerVersion(ctx); vErr == nil {
 					status.ServerVersion = version
 				}
+				c.inspect(ctx, &status)
+			} else {
+				status.ErrorClass = classifyProbeError(err)
 			}
 
 			mu.Lock()
@@ -367,9 +572,81 @@ func (m *Manager) HealthCheckWithStatus(ctx context.Context) []HealthStatus {
 	return results
 }
 
+// SetCircuitBreaker makes Guard skip clusters whose circuit is open
+func (m *Manager) SetCircuitBreaker(breaker *CircuitBreaker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.breaker = breaker
+}
+
+// CircuitBreaker returns the breaker set with SetCircuitBreaker, or nil
+func (m *Manager) CircuitBreaker() *CircuitBreaker {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.breaker
+}
+
+// Guard wraps a task function with the manager's circuit breaker: while the
+// client's circuit is open it fails immediately with a *CircuitOpenError,
+// which the executor reports as skipped rather than failed, otherwise it runs
+// fn and records the outcome. Without a breaker fn is returned as is.
+func Guard[T any](m *Manager, fn func(ctx context.Context, client *Client) (T, error)) func(ctx context.Context, client *Client) (T, error) {
+	breaker := m.CircuitBreaker()
+	if breaker == nil {
+		return fn
+	}
+
+	return func(ctx context.Context, client *Client) (T, error) {
+		if err := breaker.Allow(client.Name); err != nil {
+			var zero T
+			return zero, err
+		}
+		data, err := fn(ctx, client)
+		breaker.Record(client.Name, err)
+		return data, err
+	}
+}
+
+// StartHealthMonitor starts probing every connected cluster's /livez and
+// /readyz endpoints in the background. It runs until ctx is cancelled or the
+// manager is closed; starting it again replaces the previous monitor.
+func (m *Manager) StartHealthMonitor(ctx context.Context, opts MonitorOptions) *HealthMonitor {
+	monitor := newHealthMonitor(m, opts)
+
+	m.mu.Lock()
+	previous := m.monitor
+	m.monitor = monitor
+	m.mu.Unlock()
+	if previous != nil {
+		previous.Stop()
+	}
+
+	m.logger.Debug("starting health monitor",
+		"interval", monitor.opts.Interval,
+		"history", monitor.opts.History)
+	monitor.start(ctx)
+	return monitor
+}
+
+// HealthMonitor returns the running health monitor, nil if none was started
+func (m *Manager) HealthMonitor() *HealthMonitor {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.monitor
+}
+
 // Close gracefully closes all cluster connections
 // This clears the client map and marks the manager as closed
 func (m *Manager) Close() {
+	// The monitor reads the clients map, so stop it before taking the write lock
+	m.mu.Lock()
+	monitor := m.monitor
+	m.monitor = nil
+	m.mu.Unlock()
+	if monitor != nil {
+		monitor.Stop()
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -379,6 +656,7 @@ func (m *Manager) Close() {
 	}
 
 	m.logger.Info("closing cluster manager", "clients", len(m.clients))
+	m.logThrottleStats()
 
 	// Clear all clients
 	// Note: kubernetes.Clientset doesn't have an explicit Close method
@@ -389,6 +667,34 @@ func (m *Manager) Close() {
 	m.logger.Debug("cluster manager closed")
 }
 
+// logThrottleStats reports each cluster's client-side throttling at debug
+// level, which --verbose enables. Callers must hold m.mu.
+func (m *Manager) logThrottleStats() {
+	names := make([]string, 0, len(m.clients))
+	for name := range m.clients {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		limiter := m.clients[name].RateLimiter
+		if limiter == nil {
+			continue
+		}
+		stats := limiter.Stats()
+		m.logger.Debug("client throttling",
+			"cluster", name,
+			"requests", stats.Requests,
+			"delayed", stats.Delayed,
+			"wait", stats.Wait,
+			"max_wait", stats.MaxWait,
+			"throttled", stats.Throttled,
+			"apf_rejected", stats.APFRejected,
+			"qps", stats.QPS,
+			"min_qps", stats.MinQPS)
+	}
+}
+
 // IsClosed returns true if the manager has been closed
 func (m *Manager) IsClosed() bool {
 	m.mu.RLock()
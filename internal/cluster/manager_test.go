@@ -9,8 +9,10 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/inventory"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/version"
 	fakediscovery "k8s.io/client-go/discovery/fake"
@@ -213,6 +215,218 @@ func TestManager_ConnectAll(t *testing.T) {
 	}
 }
 
+func TestManager_ConnectAll_SkipsDisabled(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	kubeconfigPath := createTestKubeconfig(t, []string{"cluster1", "cluster2", "cluster3"})
+	fleetConfig := &config.FleetConfig{
+		Clusters: map[string]config.ClusterConfig{
+			"cluster1": {Enabled: true},
+			"legacy":   {Context: "cluster2", Enabled: false},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		fleetConfig     *config.FleetConfig
+		includeDisabled bool
+		wantClusters    []string
+	}{
+		{
+			name:         "disabled cluster skipped",
+			fleetConfig:  fleetConfig,
+			wantClusters: []string{"cluster1", "cluster3"},
+		},
+		{
+			name:            "include disabled",
+			fleetConfig:     fleetConfig,
+			includeDisabled: true,
+			wantClusters:    []string{"cluster1", "cluster2", "cluster3"},
+		},
+		{
+			name:         "no fleet config",
+			wantClusters: []string{"cluster1", "cluster2", "cluster3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := config.NewKubeconfigLoader(kubeconfigPath)
+			manager := NewManager(loader, logger)
+			defer manager.Close()
+
+			manager.SetFleetConfig(tt.fleetConfig)
+			manager.SetIncludeDisabled(tt.includeDisabled)
+
+			if err := manager.ConnectAll(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if manager.Count() != len(tt.wantClusters) {
+				t.Errorf("expected %d connected clusters, got %d", len(tt.wantClusters), manager.Count())
+			}
+			for _, name := range tt.wantClusters {
+				if !manager.HasClient(name) {
+					t.Errorf("expected cluster %q to be connected", name)
+				}
+			}
+		})
+	}
+}
+
+func TestManager_Connect_AppliesOverrides(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	kubeconfigPath := createTestKubeconfig(t, []string{"cluster1", "cluster2"})
+	dedicatedPath := createTestKubeconfig(t, []string{"airgap"})
+
+	manager := NewManager(config.NewKubeconfigLoader(kubeconfigPath), logger)
+	defer manager.Close()
+	manager.SetFleetConfig(&config.FleetConfig{
+		Clusters: map[string]config.ClusterConfig{
+			"slow": {
+				Context:     "cluster1",
+				Enabled:     true,
+				Namespace:   "platform",
+				Timeout:     5 * time.Minute,
+				Impersonate: &config.ImpersonateConfig{User: "fleet-operator"},
+			},
+			"airgap": {Enabled: true, Kubeconfig: dedicatedPath},
+		},
+	})
+
+	if err := manager.ConnectAll(context.Background()); err != nil {
+		t.Fatalf("ConnectAll() error = %v", err)
+	}
+
+	if manager.Count() != 3 {
+		t.Fatalf("expected 3 clients including the dedicated kubeconfig, got %v", manager.GetClientNames())
+	}
+
+	slow, err := manager.GetClient("cluster1")
+	if err != nil {
+		t.Fatalf("GetClient() error = %v", err)
+	}
+	if slow.Namespace != "platform" {
+		t.Errorf("Namespace = %q, want platform", slow.Namespace)
+	}
+	if slow.Timeout != 5*time.Minute || slow.RestConfig.Timeout != 5*time.Minute {
+		t.Errorf("Timeout = %v (rest %v), want 5m", slow.Timeout, slow.RestConfig.Timeout)
+	}
+	if slow.RestConfig.Impersonate.UserName != "fleet-operator" {
+		t.Errorf("Impersonate.UserName = %q, want fleet-operator", slow.RestConfig.Impersonate.UserName)
+	}
+
+	plain, err := manager.GetClient("cluster2")
+	if err != nil {
+		t.Fatalf("GetClient() error = %v", err)
+	}
+	if plain.Namespace != "" || plain.Timeout != 0 {
+		t.Errorf("unexpected overrides on cluster2: namespace %q, timeout %v", plain.Namespace, plain.Timeout)
+	}
+
+	if got := manager.MaxTimeout(30 * time.Second); got != 5*time.Minute {
+		t.Errorf("MaxTimeout() = %v, want 5m", got)
+	}
+	if got := plain.EffectiveTimeout(30 * time.Second); got != 30*time.Second {
+		t.Errorf("EffectiveTimeout() = %v, want 30s", got)
+	}
+}
+
+// inventorySource serves a fixed kubeconfig as discovered clusters
+type inventorySource struct {
+	config *api.Config
+	err    error
+}
+
+func (s *inventorySource) Name() string { return "test" }
+
+func (s *inventorySource) Load(ctx context.Context) (*api.Config, error) {
+	return s.config, s.err
+}
+
+func TestManager_LoadInventory(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	kubeconfigPath := createTestKubeconfig(t, []string{"cluster1"})
+	manager := NewManager(config.NewKubeconfigLoader(kubeconfigPath), logger)
+	defer manager.Close()
+	manager.SetFleetConfig(&config.FleetConfig{
+		Clusters: map[string]config.ClusterConfig{
+			"edge": {Enabled: true, Namespace: "edge-apps", QPS: 7},
+		},
+	})
+
+	discovered := api.NewConfig()
+	discovered.Clusters["edge"] = &api.Cluster{Server: "https://edge.example.com"}
+	discovered.AuthInfos["edge"] = &api.AuthInfo{Token: "edge-token"}
+	discovered.Contexts["edge"] = &api.Context{Cluster: "edge", AuthInfo: "edge"}
+	// A discovered context that shadows a kubeconfig one is ignored
+	discovered.Clusters["shadow"] = &api.Cluster{Server: "https://shadow.example.com"}
+	discovered.Contexts["cluster1"] = &api.Context{Cluster: "shadow"}
+
+	sources := []inventory.Source{
+		&inventorySource{config: discovered},
+		&inventorySource{err: fmt.Errorf("hub unreachable")},
+	}
+	if err := manager.LoadInventory(context.Background(), sources); err == nil {
+		t.Error("LoadInventory() expected the failing source to be reported")
+	}
+
+	contexts, err := manager.Contexts()
+	if err != nil {
+		t.Fatalf("Contexts() error = %v", err)
+	}
+	if len(contexts) != 2 {
+		t.Fatalf("Contexts() = %v, want cluster1 and edge", contexts)
+	}
+
+	if err := manager.ConnectAll(context.Background()); err != nil {
+		t.Fatalf("ConnectAll() error = %v", err)
+	}
+
+	edge, err := manager.GetClient("edge")
+	if err != nil {
+		t.Fatalf("GetClient() error = %v", err)
+	}
+	if edge.RestConfig.Host != "https://edge.example.com" || edge.RestConfig.BearerToken != "edge-token" {
+		t.Errorf("edge rest config = %s (token %q), want the discovered cluster", edge.RestConfig.Host, edge.RestConfig.BearerToken)
+	}
+	if edge.Namespace != "edge-apps" || edge.RestConfig.QPS != 7 {
+		t.Errorf("edge overrides = namespace %q, qps %v, want fleet config overrides applied", edge.Namespace, edge.RestConfig.QPS)
+	}
+
+	kubeconfigCluster, err := manager.GetClient("cluster1")
+	if err != nil {
+		t.Fatalf("GetClient() error = %v", err)
+	}
+	if strings.Contains(kubeconfigCluster.RestConfig.Host, "shadow") {
+		t.Error("kubeconfig context was replaced by a discovered one")
+	}
+}
+
+func TestManager_ConnectAll_AllDisabled(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	kubeconfigPath := createTestKubeconfig(t, []string{"cluster1"})
+	loader := config.NewKubeconfigLoader(kubeconfigPath)
+	manager := NewManager(loader, logger)
+	manager.SetFleetConfig(&config.FleetConfig{
+		Clusters: map[string]config.ClusterConfig{
+			"cluster1": {Enabled: false},
+		},
+	})
+
+	err := manager.ConnectAll(context.Background())
+	if err == nil {
+		t.Fatal("expected error when every context is disabled")
+	}
+
+	if !strings.Contains(err.Error(), "disabled") {
+		t.Errorf("expected disabled error, got: %v", err)
+	}
+}
+
 func TestManager_GetClient(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 	loader := config.NewKubeconfigLoader("")
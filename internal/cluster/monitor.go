@@ -0,0 +1,385 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Default health monitor settings
+const (
+	DefaultMonitorInterval = 30 * time.Second
+	DefaultProbeTimeout    = 10 * time.Second
+	DefaultMonitorHistory  = 20
+
+	// subscriberBuffer is how many transitions a slow subscriber can fall behind
+	// before transitions are dropped for it
+	subscriberBuffer = 16
+)
+
+// HealthState is a cluster's health as seen by the monitor
+type HealthState string
+
+const (
+	// HealthUnknown means the cluster has not been probed yet
+	HealthUnknown HealthState = "unknown"
+
+	// HealthHealthy means the last probe found the API server live and ready
+	HealthHealthy HealthState = "healthy"
+
+	// HealthUnhealthy means the last probe failed
+	HealthUnhealthy HealthState = "unhealthy"
+)
+
+// ErrorClass groups probe failures by likely cause
+type ErrorClass string
+
+const (
+	ErrorClassNone         ErrorClass = ""
+	ErrorClassTimeout      ErrorClass = "timeout"
+	ErrorClassUnreachable  ErrorClass = "unreachable"
+	ErrorClassUnauthorized ErrorClass = "unauthorized"
+	ErrorClassNotReady     ErrorClass = "not-ready"
+	ErrorClassUnknown      ErrorClass = "unknown"
+)
+
+// ProbeResult is the outcome of one /livez and /readyz probe
+type ProbeResult struct {
+	// Time is when the probe started
+	Time time.Time
+
+	// Latency is the round trip time of the /readyz request
+	Latency time.Duration
+
+	// Live and Ready report whether /livez and /readyz returned ok
+	Live  bool
+	Ready bool
+
+	// Version is the server's git version, empty if it could not be read
+	Version string
+
+	// ErrorClass and Error describe the first failure, if any
+	ErrorClass ErrorClass
+	Error      error
+}
+
+// Healthy reports whether the API server was both live and ready
+func (r ProbeResult) Healthy() bool {
+	return r.Live && r.Ready
+}
+
+// State returns the health state the result represents
+func (r ProbeResult) State() HealthState {
+	if r.Healthy() {
+		return HealthHealthy
+	}
+	return HealthUnhealthy
+}
+
+// Transition is published when a cluster's health state changes
+type Transition struct {
+	Cluster string
+	From    HealthState
+	To      HealthState
+	Result  ProbeResult
+}
+
+// MonitorOptions configures a HealthMonitor; zero values use the defaults
+type MonitorOptions struct {
+	// Interval between probe rounds
+	Interval time.Duration
+
+	// Timeout for each cluster's probe
+	Timeout time.Duration
+
+	// History is how many results are kept per cluster
+	History int
+}
+
+// Probe checks the API server's /livez and /readyz endpoints and reads its
+// version, recording the result in the client's health status
+func (c *Client) Probe(ctx context.Context) ProbeResult {
+	result := ProbeResult{Time: time.Now()}
+	rest, ok := c.restClient()
+	if !ok {
+		result.ErrorClass, result.Error = ErrorClassUnknown, errors.New("client cannot reach health endpoints")
+		c.SetHealthy(false)
+		return result
+	}
+
+	_, err := rest.Get().AbsPath("/livez").DoRaw(ctx)
+	result.Live = err == nil
+	if err != nil {
+		result.ErrorClass, result.Error = classifyProbeError(err), err
+	}
+
+	start := time.Now()
+	_, err = rest.Get().AbsPath("/readyz").DoRaw(ctx)
+	result.Latency = time.Since(start)
+	result.Ready = err == nil
+	if err != nil && result.Error == nil {
+		result.ErrorClass, result.Error = classifyProbeError(err), err
+	}
+
+	if result.Live || result.Ready {
+		if info, err := c.Clientset.Discovery().ServerVersion(); err == nil {
+			result.Version = info.GitVersion
+		}
+	}
+
+	c.SetHealthy(result.Healthy())
+	return result
+}
+
+// classifyProbeError maps a probe error to an ErrorClass
+func classifyProbeError(err error) ErrorClass {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return ErrorClassTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	case apierrors.IsUnauthorized(err), apierrors.IsForbidden(err):
+		return ErrorClassUnauthorized
+	case errors.As(err, &netErr):
+		return ErrorClassUnreachable
+	}
+
+	// A failing health endpoint answers 500 with the checks that failed
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Code >= 500 {
+		return ErrorClassNotReady
+	}
+	return ErrorClassUnknown
+}
+
+// probeHistory is a fixed-size ring buffer of probe results
+type probeHistory struct {
+	results []ProbeResult
+	next    int
+	full    bool
+}
+
+func newProbeHistory(size int) *probeHistory {
+	return &probeHistory{results: make([]ProbeResult, size)}
+}
+
+func (h *probeHistory) add(result ProbeResult) {
+	h.results[h.next] = result
+	h.next = (h.next + 1) % len(h.results)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// snapshot returns the results oldest first
+func (h *probeHistory) snapshot() []ProbeResult {
+	if !h.full {
+		return append([]ProbeResult(nil), h.results[:h.next]...)
+	}
+	out := make([]ProbeResult, 0, len(h.results))
+	out = append(out, h.results[h.next:]...)
+	return append(out, h.results[:h.next]...)
+}
+
+// HealthMonitor probes a manager's clusters in the background, keeping a
+// history of results per cluster and publishing state changes to subscribers
+//
+// It is safe for concurrent use. Clusters connected after the monitor starts
+// are picked up on the next round.
+type HealthMonitor struct {
+	manager *Manager
+	opts    MonitorOptions
+	logger  *slog.Logger
+
+	mu          sync.RWMutex
+	histories   map[string]*probeHistory
+	states      map[string]HealthState
+	subscribers map[int]chan Transition
+	nextID      int
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// newHealthMonitor creates a monitor with defaults filled in; call start to run it
+func newHealthMonitor(manager *Manager, opts MonitorOptions) *HealthMonitor {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultMonitorInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultProbeTimeout
+	}
+	if opts.History <= 0 {
+		opts.History = DefaultMonitorHistory
+	}
+
+	return &HealthMonitor{
+		manager:     manager,
+		opts:        opts,
+		logger:      manager.logger,
+		histories:   make(map[string]*probeHistory),
+		states:      make(map[string]HealthState),
+		subscribers: make(map[int]chan Transition),
+		done:        make(chan struct{}),
+	}
+}
+
+// start probes immediately and then on every interval until ctx is done or Stop is called
+func (hm *HealthMonitor) start(ctx context.Context) {
+	ctx, hm.cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(hm.done)
+
+		ticker := time.NewTicker(hm.opts.Interval)
+		defer ticker.Stop()
+
+		for {
+			hm.probeAll(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops probing, waits for the current round to finish and closes all
+// subscriber channels
+func (hm *HealthMonitor) Stop() {
+	hm.cancel()
+	<-hm.done
+
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	for id, ch := range hm.subscribers {
+		close(ch)
+		delete(hm.subscribers, id)
+	}
+}
+
+// probeAll probes every connected cluster concurrently
+func (hm *HealthMonitor) probeAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, client := range hm.manager.GetAllClients() {
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+
+			probeCtx, cancel := context.WithTimeout(ctx, hm.opts.Timeout)
+			defer cancel()
+
+			result := c.Probe(probeCtx)
+			if ctx.Err() != nil {
+				// Stopped mid-probe; the result says nothing about the cluster
+				return
+			}
+			hm.record(c.Name, result)
+		}(client)
+	}
+	wg.Wait()
+}
+
+// record stores a result and publishes a transition if the state changed
+func (hm *HealthMonitor) record(cluster string, result ProbeResult) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	history, ok := hm.histories[cluster]
+	if !ok {
+		history = newProbeHistory(hm.opts.History)
+		hm.histories[cluster] = history
+	}
+	history.add(result)
+
+	from, ok := hm.states[cluster]
+	if !ok {
+		from = HealthUnknown
+	}
+	to := result.State()
+	hm.states[cluster] = to
+	if from == to {
+		return
+	}
+
+	hm.logger.Debug("cluster health changed",
+		"cluster", cluster,
+		"from", from,
+		"to", to,
+		"error_class", result.ErrorClass)
+
+	transition := Transition{Cluster: cluster, From: from, To: to, Result: result}
+	for _, ch := range hm.subscribers {
+		select {
+		case ch <- transition:
+		default:
+			hm.logger.Warn("dropping health transition for slow subscriber", "cluster", cluster)
+		}
+	}
+}
+
+// Subscribe returns a channel receiving every state transition and a function
+// that unsubscribes. The channel is closed on unsubscribe or Stop.
+func (hm *HealthMonitor) Subscribe() (<-chan Transition, func()) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	id := hm.nextID
+	hm.nextID++
+	ch := make(chan Transition, subscriberBuffer)
+	hm.subscribers[id] = ch
+
+	return ch, func() {
+		hm.mu.Lock()
+		defer hm.mu.Unlock()
+		if ch, ok := hm.subscribers[id]; ok {
+			close(ch)
+			delete(hm.subscribers, id)
+		}
+	}
+}
+
+// State returns a cluster's current health state
+func (hm *HealthMonitor) State(cluster string) HealthState {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	if state, ok := hm.states[cluster]; ok {
+		return state
+	}
+	return HealthUnknown
+}
+
+// History returns a cluster's recent probe results, oldest first
+func (hm *HealthMonitor) History(cluster string) []ProbeResult {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	history, ok := hm.histories[cluster]
+	if !ok {
+		return nil
+	}
+	return history.snapshot()
+}
+
+// Flaps counts the state changes within a cluster's history, a measure of how
+// unstable it has been recently
+func (hm *HealthMonitor) Flaps(cluster string) int {
+	results := hm.History(cluster)
+
+	flaps := 0
+	for i := 1; i < len(results); i++ {
+		if results[i].State() != results[i-1].State() {
+			flaps++
+		}
+	}
+	return flaps
+}
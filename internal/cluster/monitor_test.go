@@ -0,0 +1,201 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+// fakeAPIServer serves /livez, /readyz, /version and two nodes; ready toggles /readyz
+type fakeAPIServer struct {
+	*httptest.Server
+	ready atomic.Bool
+}
+
+func newFakeAPIServer(t *testing.T) *fakeAPIServer {
+	t.Helper()
+
+	s := &fakeAPIServer{}
+	s.ready.Store(true)
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/livez":
+			w.Write([]byte("ok"))
+		case "/readyz":
+			verbose := r.URL.Query().Has("verbose")
+			if !s.ready.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				if verbose {
+					w.Write([]byte("[+]ping ok\n[-]etcd failed: reason withheld\n"))
+				}
+				w.Write([]byte("readyz check failed"))
+				return
+			}
+			if verbose {
+				w.Write([]byte("[+]ping ok\n[+]etcd ok\nreadyz check passed"))
+				return
+			}
+			w.Write([]byte("ok"))
+		case "/version":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"major":"1","minor":"31","gitVersion":"v1.31.0"}`))
+		case "/api/v1/nodes":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"kind":"NodeList","apiVersion":"v1","items":[
+				{"metadata":{"name":"node-1"},"status":{"conditions":[{"type":"Ready","status":"True"}]}},
+				{"metadata":{"name":"node-2"},"status":{"conditions":[{"type":"Ready","status":"False"}]}}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestClient(t *testing.T, name, host string) *Client {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	client, err := NewClient(context.Background(), name, name, &rest.Config{Host: host}, logger)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestClient_Probe(t *testing.T) {
+	server := newFakeAPIServer(t)
+	client := newTestClient(t, "test", server.URL)
+
+	result := client.Probe(context.Background())
+	if !result.Healthy() || result.Version != "v1.31.0" || result.Error != nil {
+		t.Errorf("healthy probe = %+v", result)
+	}
+	if !client.IsHealthy() {
+		t.Error("expected the client to be marked healthy")
+	}
+
+	server.ready.Store(false)
+	result = client.Probe(context.Background())
+	if result.Healthy() || !result.Live || result.ErrorClass != ErrorClassNotReady {
+		t.Errorf("not ready probe = %+v, want live but %s", result, ErrorClassNotReady)
+	}
+	if client.IsHealthy() {
+		t.Error("expected the client to be marked unhealthy")
+	}
+
+	server.Close()
+	result = client.Probe(context.Background())
+	if result.Live || result.ErrorClass != ErrorClassUnreachable {
+		t.Errorf("closed server probe = %+v, want %s", result, ErrorClassUnreachable)
+	}
+}
+
+func TestClassifyProbeError(t *testing.T) {
+	resource := schema.GroupResource{Resource: "readyz"}
+
+	tests := []struct {
+		err  error
+		want ErrorClass
+	}{
+		{err: context.DeadlineExceeded, want: ErrorClassTimeout},
+		{err: fmt.Errorf("probe: %w", context.DeadlineExceeded), want: ErrorClassTimeout},
+		{err: apierrors.NewUnauthorized("expired token"), want: ErrorClassUnauthorized},
+		{err: apierrors.NewForbidden(resource, "", errors.New("denied")), want: ErrorClassUnauthorized},
+		{err: apierrors.NewInternalError(errors.New("etcd failed")), want: ErrorClassNotReady},
+		{err: errors.New("something else"), want: ErrorClassUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := classifyProbeError(tt.err); got != tt.want {
+			t.Errorf("classifyProbeError(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestProbeHistory(t *testing.T) {
+	history := newProbeHistory(3)
+	if got := history.snapshot(); len(got) != 0 {
+		t.Fatalf("empty history = %v", got)
+	}
+
+	for i := 1; i <= 5; i++ {
+		history.add(ProbeResult{Latency: time.Duration(i)})
+	}
+
+	got := history.snapshot()
+	if len(got) != 3 || got[0].Latency != 3 || got[2].Latency != 5 {
+		t.Errorf("history = %v, want the last three results oldest first", got)
+	}
+}
+
+func TestHealthMonitor(t *testing.T) {
+	server := newFakeAPIServer(t)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	m := NewManager(nil, logger)
+	m.clients["test"] = newTestClient(t, "test", server.URL)
+
+	monitor := m.StartHealthMonitor(context.Background(), MonitorOptions{
+		Interval: 10 * time.Millisecond,
+		History:  4,
+	})
+	if m.HealthMonitor() != monitor {
+		t.Fatal("HealthMonitor() did not return the started monitor")
+	}
+
+	waitFor(t, func() bool { return monitor.State("test") == HealthHealthy })
+
+	transitions, _ := monitor.Subscribe()
+	server.ready.Store(false)
+
+	select {
+	case tr := <-transitions:
+		if tr.Cluster != "test" || tr.From != HealthHealthy || tr.To != HealthUnhealthy {
+			t.Errorf("transition = %+v, want test healthy -> unhealthy", tr)
+		}
+		if tr.Result.ErrorClass != ErrorClassNotReady {
+			t.Errorf("error class = %q, want %q", tr.Result.ErrorClass, ErrorClassNotReady)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a transition")
+	}
+
+	waitFor(t, func() bool { return len(monitor.History("test")) == 4 })
+	if flaps := monitor.Flaps("test"); flaps > 1 {
+		t.Errorf("Flaps() = %d, want at most 1", flaps)
+	}
+	if monitor.State("missing") != HealthUnknown {
+		t.Error("expected an unprobed cluster to be unknown")
+	}
+
+	// Closing the manager stops the monitor and closes subscriptions
+	m.Close()
+	for range transitions {
+	}
+	if m.HealthMonitor() != nil {
+		t.Error("expected the monitor to be cleared on Close")
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
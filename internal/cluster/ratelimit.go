@@ -0,0 +1,227 @@
+package cluster
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// adjustInterval is the minimum time between two adaptive QPS changes, so a
+	// burst of rejections halves the rate once rather than once per request
+	adjustInterval = time.Second
+
+	// minAdaptiveQPS is the floor adaptive mode backs off to
+	minAdaptiveQPS = 1
+
+	// delayThreshold is how long a request must wait for a token to count as delayed
+	delayThreshold = time.Millisecond
+
+	// apfFlowSchemaHeader is set by API Priority and Fairness on requests it handled;
+	// on a 429 it marks an APF rejection rather than a max-in-flight one
+	apfFlowSchemaHeader = "X-Kubernetes-PF-FlowSchema-UID"
+)
+
+// ThrottleStats summarises client-side rate limiting for one cluster
+type ThrottleStats struct {
+	// Requests is the number of API responses received
+	Requests int `json:"requests" yaml:"requests"`
+
+	// Throttled is the number of 429 Too Many Requests responses
+	Throttled int `json:"throttled" yaml:"throttled"`
+
+	// APFRejected is the subset of Throttled rejected by API Priority and Fairness
+	APFRejected int `json:"apfRejected" yaml:"apfRejected"`
+
+	// Delayed is the number of requests that waited for a rate limiter token
+	Delayed int `json:"delayed" yaml:"delayed"`
+
+	// Wait is the total time requests spent waiting for tokens
+	Wait time.Duration `json:"wait" yaml:"wait"`
+
+	// MaxWait is the longest single wait for a token
+	MaxWait time.Duration `json:"maxWait" yaml:"maxWait"`
+
+	// QPS is the current rate; below the configured rate while adaptive mode is backing off
+	QPS float32 `json:"qps" yaml:"qps"`
+
+	// MinQPS is the lowest rate adaptive mode backed off to
+	MinQPS float32 `json:"minQPS" yaml:"minQPS"`
+}
+
+// RateLimiter is the client-side rate limiter for one cluster
+//
+// It implements flowcontrol.RateLimiter with a token bucket and records how
+// long requests wait for tokens. In adaptive mode it halves its QPS when the
+// API server answers 429 Too Many Requests, including API Priority and
+// Fairness rejections, and climbs back towards the configured QPS as requests
+// succeed. Every clientset built from the same REST config shares the limiter.
+type RateLimiter struct {
+	mu       sync.Mutex
+	bucket   flowcontrol.RateLimiter
+	maxQPS   float32
+	maxBurst int
+	adaptive bool
+
+	// lastAdjust is when the QPS last changed in adaptive mode
+	lastAdjust time.Time
+	now        func() time.Time
+
+	stats ThrottleStats
+}
+
+// NewRateLimiter creates a rate limiter allowing qps requests per second with
+// bursts of up to burst requests
+func NewRateLimiter(qps float32, burst int, adaptive bool) *RateLimiter {
+	return &RateLimiter{
+		bucket:   flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+		maxQPS:   qps,
+		maxBurst: burst,
+		adaptive: adaptive,
+		now:      time.Now,
+		stats:    ThrottleStats{QPS: qps, MinQPS: qps},
+	}
+}
+
+// Install makes restConfig use the limiter and report responses to it
+func (l *RateLimiter) Install(restConfig *rest.Config, clusterName string, logger *slog.Logger) {
+	restConfig.QPS = l.maxQPS
+	restConfig.Burst = l.maxBurst
+	restConfig.RateLimiter = l
+	restConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &throttleTransport{next: rt, limiter: l, cluster: clusterName, logger: logger}
+	})
+}
+
+// TryAccept takes a token if one is available without waiting
+func (l *RateLimiter) TryAccept() bool {
+	return l.current().TryAccept()
+}
+
+// Accept waits for a token
+func (l *RateLimiter) Accept() {
+	start := l.now()
+	l.current().Accept()
+	l.recordWait(l.now().Sub(start))
+}
+
+// Wait waits for a token until ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	start := l.now()
+	err := l.current().Wait(ctx)
+	l.recordWait(l.now().Sub(start))
+	return err
+}
+
+// Stop stops the limiter
+func (l *RateLimiter) Stop() {
+	l.current().Stop()
+}
+
+// QPS returns the current rate
+func (l *RateLimiter) QPS() float32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats.QPS
+}
+
+// Stats returns a snapshot of the throttling counters
+func (l *RateLimiter) Stats() ThrottleStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// current returns the token bucket in use; adaptive mode replaces it when the rate changes
+func (l *RateLimiter) current() flowcontrol.RateLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.bucket
+}
+
+func (l *RateLimiter) recordWait(wait time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if wait < delayThreshold {
+		return
+	}
+	l.stats.Delayed++
+	l.stats.Wait += wait
+	l.stats.MaxWait = max(l.stats.MaxWait, wait)
+}
+
+// observe records a response and, in adaptive mode, adjusts the rate
+// It returns the new QPS and whether it changed.
+func (l *RateLimiter) observe(resp *http.Response) (float32, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stats.Requests++
+	throttled := resp.StatusCode == http.StatusTooManyRequests
+	if throttled {
+		l.stats.Throttled++
+		if resp.Header.Get(apfFlowSchemaHeader) != "" {
+			l.stats.APFRejected++
+		}
+	}
+
+	if !l.adaptive {
+		return l.stats.QPS, false
+	}
+
+	now := l.now()
+	if now.Sub(l.lastAdjust) < adjustInterval {
+		return l.stats.QPS, false
+	}
+
+	// Multiplicative decrease on rejection, additive increase on success
+	qps := l.stats.QPS
+	switch {
+	case throttled:
+		qps = max(minAdaptiveQPS, qps/2)
+	case qps < l.maxQPS:
+		qps = min(l.maxQPS, qps+max(1, l.maxQPS/10))
+	}
+	if qps == l.stats.QPS {
+		return qps, false
+	}
+
+	burst := max(1, int(float32(l.maxBurst)*qps/l.maxQPS))
+	l.bucket = flowcontrol.NewTokenBucketRateLimiter(qps, burst)
+	l.lastAdjust = now
+	l.stats.QPS = qps
+	l.stats.MinQPS = min(l.stats.MinQPS, qps)
+	return qps, true
+}
+
+// throttleTransport reports every API response to the cluster's rate limiter
+type throttleTransport struct {
+	next    http.RoundTripper
+	limiter *RateLimiter
+	cluster string
+	logger  *slog.Logger
+}
+
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if qps, changed := t.limiter.observe(resp); changed {
+		if resp.StatusCode == http.StatusTooManyRequests {
+			t.logger.Debug("API server is throttling requests, lowering client QPS",
+				"cluster", t.cluster,
+				"qps", qps)
+		} else {
+			t.logger.Debug("raising client QPS", "cluster", t.cluster, "qps", qps)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,177 @@
+package cluster
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// response builds a response with the given status and headers
+func response(status int, headers ...string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: http.Header{}}
+	for i := 0; i+1 < len(headers); i += 2 {
+		resp.Header.Set(headers[i], headers[i+1])
+	}
+	return resp
+}
+
+func TestRateLimiter_Stats(t *testing.T) {
+	limiter := NewRateLimiter(50, 100, false)
+
+	limiter.observe(response(http.StatusOK))
+	limiter.observe(response(http.StatusTooManyRequests))
+	limiter.observe(response(http.StatusTooManyRequests, apfFlowSchemaHeader, "uid"))
+
+	stats := limiter.Stats()
+	if stats.Requests != 3 || stats.Throttled != 2 || stats.APFRejected != 1 {
+		t.Errorf("stats = %+v, want 3 requests, 2 throttled, 1 APF rejection", stats)
+	}
+	if stats.QPS != 50 {
+		t.Errorf("QPS = %v, want 50 without adaptive mode", stats.QPS)
+	}
+}
+
+func TestRateLimiter_Adaptive(t *testing.T) {
+	now := time.Now()
+	limiter := NewRateLimiter(40, 80, true)
+	limiter.now = func() time.Time { return now }
+
+	// A rejection halves the rate; further rejections in the same interval don't
+	if qps, changed := limiter.observe(response(http.StatusTooManyRequests)); !changed || qps != 20 {
+		t.Fatalf("after 429: qps = %v, changed = %v, want 20", qps, changed)
+	}
+	if _, changed := limiter.observe(response(http.StatusTooManyRequests)); changed {
+		t.Error("QPS changed twice within one interval")
+	}
+
+	now = now.Add(adjustInterval)
+	limiter.observe(response(http.StatusTooManyRequests))
+	if got := limiter.QPS(); got != 10 {
+		t.Fatalf("QPS = %v, want 10 after a second rejection", got)
+	}
+
+	// Successes raise the rate by a tenth of the configured QPS per interval
+	for range 10 {
+		now = now.Add(adjustInterval)
+		limiter.observe(response(http.StatusOK))
+	}
+	stats := limiter.Stats()
+	if stats.QPS != 40 {
+		t.Errorf("QPS = %v, want it capped at the configured 40", stats.QPS)
+	}
+	if stats.MinQPS != 10 {
+		t.Errorf("MinQPS = %v, want 10", stats.MinQPS)
+	}
+
+	// The rate never drops below the floor
+	for range 10 {
+		now = now.Add(adjustInterval)
+		limiter.observe(response(http.StatusTooManyRequests))
+	}
+	if got := limiter.QPS(); got != minAdaptiveQPS {
+		t.Errorf("QPS = %v, want the floor %v", got, minAdaptiveQPS)
+	}
+}
+
+func TestRateLimiter_Install(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// Reject the first request the way APF does; client-go retries it
+		if calls.Add(1) == 1 {
+			w.Header().Set(apfFlowSchemaHeader, "uid")
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"TooManyRequests","code":429}`))
+			return
+		}
+		w.Write([]byte(`{"major":"1","minor":"31","gitVersion":"v1.31.0"}`))
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	restConfig := &rest.Config{Host: server.URL}
+	limiter := NewRateLimiter(100, 200, true)
+	limiter.Install(restConfig, "test", logger)
+
+	if restConfig.QPS != 100 || restConfig.Burst != 200 {
+		t.Errorf("rest config QPS/Burst = %v/%d, want 100/200", restConfig.QPS, restConfig.Burst)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		t.Fatalf("failed to create clientset: %v", err)
+	}
+	if _, err := clientset.Discovery().RESTClient().Get().AbsPath("/version").Do(context.Background()).Raw(); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	stats := limiter.Stats()
+	if stats.Requests != 2 || stats.Throttled != 1 || stats.APFRejected != 1 {
+		t.Errorf("stats = %+v, want 2 requests with 1 APF rejection", stats)
+	}
+	if stats.QPS != 50 {
+		t.Errorf("QPS = %v, want 50 after backing off", stats.QPS)
+	}
+}
+
+func TestManager_NewRateLimiter(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name      string
+		defaults  *config.DefaultsConfig
+		overrides *config.ClusterConfig
+		wantQPS   float32
+		wantBurst int
+	}{
+		{
+			name:      "client-go defaults",
+			wantQPS:   rest.DefaultQPS,
+			wantBurst: rest.DefaultBurst,
+		},
+		{
+			name:      "fleet defaults",
+			defaults:  &config.DefaultsConfig{QPS: 50, Burst: 100},
+			wantQPS:   50,
+			wantBurst: 100,
+		},
+		{
+			name:      "cluster QPS raises the burst",
+			defaults:  &config.DefaultsConfig{QPS: 20, Burst: 40},
+			overrides: &config.ClusterConfig{QPS: 200},
+			wantQPS:   200,
+			wantBurst: 200,
+		},
+		{
+			name:      "cluster QPS and burst",
+			defaults:  &config.DefaultsConfig{QPS: 20, Burst: 40},
+			overrides: &config.ClusterConfig{QPS: 200, Burst: 300},
+			wantQPS:   200,
+			wantBurst: 300,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager(nil, logger)
+			if tt.defaults != nil {
+				m.SetFleetConfig(&config.FleetConfig{Defaults: *tt.defaults})
+			}
+
+			limiter := m.newRateLimiter(tt.overrides)
+			if limiter.maxQPS != tt.wantQPS || limiter.maxBurst != tt.wantBurst {
+				t.Errorf("QPS/Burst = %v/%d, want %v/%d", limiter.maxQPS, limiter.maxBurst, tt.wantQPS, tt.wantBurst)
+			}
+		})
+	}
+}
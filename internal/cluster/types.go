@@ -1,6 +1,9 @@
 package cluster
 
 import (
+	"sync/atomic"
+	"time"
+
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
@@ -19,8 +22,26 @@ type Client struct {
 	// RestConfig is the underlying REST configuration
 	RestConfig *rest.Config
 
-	// Healthy indicates if the last health check passed
-	Healthy bool
+	// healthy records whether the last health check passed; it is written by
+	// concurrent health checks, so use IsHealthy and SetHealthy
+	healthy atomic.Bool
+
+	// Namespace is the default namespace from the fleet config, empty if unset
+	Namespace string
+
+	// Timeout overrides the default operation timeout for this cluster, zero if unset
+	Timeout time.Duration
+
+	// RateLimiter throttles requests to the cluster, nil if the client-go default is used
+	RateLimiter *RateLimiter
+}
+
+// EffectiveTimeout returns the cluster's timeout override, or fallback when none is set
+func (c *Client) EffectiveTimeout(fallback time.Duration) time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return fallback
 }
 
 // HealthStatus represents the health status of a cluster
@@ -36,4 +57,19 @@ type HealthStatus struct {
 
 	// ServerVersion is the Kubernetes server version (if healthy)
 	ServerVersion string
+
+	// Latency is the round trip time of the version request
+	Latency time.Duration
+
+	// ErrorClass groups Error by likely cause
+	ErrorClass ErrorClass
+
+	// Checks is the API server's /readyz check breakdown, empty if unavailable
+	Checks []ComponentCheck
+
+	// Nodes counts ready nodes; nil if they could not be listed
+	Nodes *NodeCounts
+
+	// Credentials describes how the client authenticates, nil if unknown
+	Credentials *CredentialStatus
 }
@@ -1,17 +1,27 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
+	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
 )
 
 const (
 	defaultConfigName = ".fleet"
 	defaultConfigDir  = ".fleet"
+	defaultConfigFile = "config.yaml"
 )
 
 // Manager handles fleet configuration
@@ -19,6 +29,44 @@ type Manager struct {
 	configPath string
 	config     *FleetConfig
 	viper      *viper.Viper
+
+	// doc is the config file as parsed; Set and SetClusterConfig edit it in
+	// place and Save writes it back, so comments, key order and values the
+	// user never set survive
+	doc *yaml.Node
+
+	// sources records where each overridable default came from
+	sources map[string]string
+
+	// unversioned is set when the file had no apiVersion and was stamped
+	// with the current one on load
+	unversioned bool
+}
+
+// Sources of an effective setting, from highest to lowest precedence
+const (
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceFile    = "file"
+	SourceDefault = "default"
+)
+
+// overridableKeys pairs the global flags that can override the config file
+// with the defaults keys they override
+var overridableKeys = []struct {
+	flag string
+	key  string
+}{
+	{flag: "timeout", key: "defaults.timeout"},
+	{flag: "parallel", key: "defaults.parallel"},
+	{flag: "output", key: "defaults.outputFormat"},
+	{flag: "no-color", key: "defaults.noColor"},
+	{flag: "qps", key: "defaults.qps"},
+	{flag: "burst", key: "defaults.burst"},
+	{flag: "adaptive-qps", key: "defaults.adaptiveQPS"},
+	{flag: "retries", key: "defaults.retries"},
+	{flag: "deadline", key: "defaults.deadline"},
+	{flag: "adaptive-parallel", key: "defaults.adaptiveParallel"},
 }
 
 // NewManager creates a new configuration manager
@@ -31,69 +79,162 @@ func NewManager(configPath string) *Manager {
 }
 
 // Load loads the fleet configuration from file
+// Without an explicit path it uses the first existing file among
+// ~/.fleet/config.yaml and ~/.fleet.yaml, which is also where Save writes
 func (m *Manager) Load() (*FleetConfig, error) {
+	// Initialize config to ensure defaults are set even for empty configs
+	m.config = &FleetConfig{}
+	m.doc = nil
+
 	// Set up config file path
-	if m.configPath != "" {
-		m.viper.SetConfigFile(m.configPath)
-	} else {
-		// Try multiple locations
-		home, err := os.UserHomeDir()
+	if m.configPath == "" {
+		path, err := findConfigFile()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get home directory: %w", err)
+			return nil, err
 		}
 
-		// Check ~/.fleet/config.yaml
-		m.viper.AddConfigPath(filepath.Join(home, defaultConfigDir))
-		// Check ~/.fleet.yaml
-		m.viper.AddConfigPath(home)
-		m.viper.SetConfigName(defaultConfigName)
-		m.viper.SetConfigType("yaml")
+		if path == "" {
+			// No config file anywhere, use defaults
+			m.config = m.withDefaults(m.config)
+			return m.config, nil
+		}
+		m.configPath = path
 	}
+	m.viper.SetConfigFile(m.configPath)
 
 	// Set environment variable support
 	m.viper.SetEnvPrefix("FLEET")
 	m.viper.AutomaticEnv()
 
-	// Initialize config to ensure defaults are set even for empty configs
-	m.config = &FleetConfig{}
-
 	// Read config file
-	if err := m.viper.ReadInConfig(); err != nil {
+	data, err := os.ReadFile(m.configPath)
+	if err != nil {
 		// It's okay if config file doesn't exist, we'll use defaults
-		// Check for both ConfigFileNotFoundError and os.IsNotExist
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok && !os.IsNotExist(err) {
+		if !os.IsNotExist(err) {
 			return nil, fmt.Errorf("failed to read config file: %w", err)
 		}
 		// File doesn't exist, apply defaults and return
-		m.applyDefaults()
+		m.config = m.withDefaults(m.config)
 		return m.config, nil
 	}
 
-	// Unmarshal into config struct
-	if err := m.viper.Unmarshal(m.config); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	// Unversioned files get their header in memory; the file is only
+	// rewritten by "fleet config migrate" or Save
+	data, m.unversioned, err = Migrate(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", m.configPath, err)
+	}
+	if m.unversioned {
+		slog.Debug("config file has no apiVersion, reading it as the current schema",
+			"file", m.configPath, "apiVersion", APIVersion)
 	}
 
-	// Apply defaults
-	m.applyDefaults()
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	m.doc = doc
+
+	if err := m.decode(data); err != nil {
+		return nil, err
+	}
 
 	return m.config, nil
 }
 
+// decode reads the effective config from a config document
+func (m *Manager) decode(data []byte) error {
+	m.viper.SetConfigType("yaml")
+	if err := m.viper.ReadConfig(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// Unmarshal into config struct
+	file := &FleetConfig{}
+	if err := m.viper.Unmarshal(file); err != nil {
+		return fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	// Apply defaults to a copy, so nothing written back picks them up
+	m.config = m.withDefaults(file)
+
+	return nil
+}
+
+// ApplyOverrides layers flag and environment values from v over the loaded
+// defaults, so the resulting config holds the effective settings.
+//
+// Precedence, highest first: flag > env (FLEET_*) > config file > built-in default.
+// v is expected to have the timeout, parallel, output, no-color, qps, burst,
+// adaptive-qps, retries, deadline and adaptive-parallel flags bound.
+// flags is used to tell which of them were set on the command line and may be nil.
+func (m *Manager) ApplyOverrides(v *viper.Viper, flags *pflag.FlagSet) {
+	defaults := &m.config.Defaults
+
+	m.sources = make(map[string]string, len(overridableKeys))
+	for _, o := range overridableKeys {
+		m.sources[o.key] = m.sourceOf(o.flag, o.key, flags)
+	}
+
+	// Config file values (already backed by built-in defaults) become viper
+	// defaults, which rank below explicitly set flags and environment variables
+	v.SetDefault("timeout", defaults.Timeout)
+	v.SetDefault("parallel", defaults.Parallel)
+	v.SetDefault("output", defaults.OutputFormat)
+	v.SetDefault("no-color", defaults.NoColor)
+	v.SetDefault("qps", defaults.QPS)
+	v.SetDefault("burst", defaults.Burst)
+	v.SetDefault("adaptive-qps", defaults.AdaptiveQPS)
+	v.SetDefault("retries", defaults.Retries)
+	v.SetDefault("deadline", defaults.Deadline)
+	v.SetDefault("adaptive-parallel", defaults.AdaptiveParallel)
+
+	defaults.Timeout = v.GetDuration("timeout")
+	defaults.Parallel = v.GetInt("parallel")
+	defaults.OutputFormat = v.GetString("output")
+	defaults.NoColor = v.GetBool("no-color")
+	defaults.QPS = float32(v.GetFloat64("qps"))
+	defaults.Burst = v.GetInt("burst")
+	defaults.AdaptiveQPS = v.GetBool("adaptive-qps")
+	defaults.Retries = v.GetInt("retries")
+	defaults.Deadline = v.GetDuration("deadline")
+	defaults.AdaptiveParallel = v.GetBool("adaptive-parallel")
+}
+
+// Sources returns where each overridable default came from, keyed by config key
+// (e.g. "defaults.timeout": "env"). Empty until ApplyOverrides has been called.
+func (m *Manager) Sources() map[string]string {
+	return m.sources
+}
+
+// sourceOf reports which layer supplies the value for a defaults key
+func (m *Manager) sourceOf(flag, key string, flags *pflag.FlagSet) string {
+	if flags != nil && flags.Changed(flag) {
+		return SourceFlag
+	}
+
+	envName := "FLEET_" + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+	if os.Getenv(envName) != "" {
+		return SourceEnv
+	}
+
+	if m.viper.InConfig(key) {
+		return SourceFile
+	}
+
+	return SourceDefault
+}
+
 // Save saves the current configuration to file
+// It writes back to the file that was loaded, or ~/.fleet/config.yaml if none
+// was, changing only what Set, SetClusterConfig and RemoveClusterConfig did
 func (m *Manager) Save() error {
 	if m.configPath == "" {
-		home, err := os.UserHomeDir()
+		paths, err := defaultConfigPaths()
 		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
-		}
-
-		configDir := filepath.Join(home, defaultConfigDir)
-		if err := os.MkdirAll(configDir, 0755); err != nil {
-			return fmt.Errorf("failed to create config directory: %w", err)
+			return err
 		}
-
-		m.configPath = filepath.Join(configDir, "config.yaml")
+		m.configPath = paths[0]
 	}
 
 	// Ensure directory exists
@@ -102,14 +243,35 @@ func (m *Manager) Save() error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
+	// Saved files always use the current schema
+	root := m.document()
+	setHeader(root, "apiVersion", APIVersion)
+	setHeader(root, "kind", Kind)
+
+	data, err := encodeDocument(m.doc)
+	if err != nil {
+		return err
+	}
+
 	// Write config to file
-	if err := m.viper.WriteConfigAs(m.configPath); err != nil {
+	if err := os.WriteFile(m.configPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
 	return nil
 }
 
+// Unversioned reports whether the loaded config file had no apiVersion
+func (m *Manager) Unversioned() bool {
+	return m.unversioned
+}
+
+// ConfigPath returns the path of the config file being used
+// Empty if no file was given and none was found
+func (m *Manager) ConfigPath() string {
+	return m.configPath
+}
+
 // GetConfig returns the current configuration
 func (m *Manager) GetConfig() *FleetConfig {
 	return m.config
@@ -126,23 +288,18 @@ func (m *Manager) GetClusterConfig(name string) (*ClusterConfig, bool) {
 }
 
 // SetClusterConfig sets or updates configuration for a cluster
-func (m *Manager) SetClusterConfig(name string, config ClusterConfig) {
-	if m.config.Clusters == nil {
-		m.config.Clusters = make(map[string]ClusterConfig)
+// Only the fields set in config are written; other clusters are untouched.
+func (m *Manager) SetClusterConfig(name string, config ClusterConfig) error {
+	if err := m.setValue([]string{"clusters", name}, config); err != nil {
+		return err
 	}
-
-	m.config.Clusters[name] = config
-	m.viper.Set("clusters", m.config.Clusters)
+	return m.reload()
 }
 
 // RemoveClusterConfig removes configuration for a cluster
-func (m *Manager) RemoveClusterConfig(name string) {
-	if m.config.Clusters == nil {
-		return
-	}
-
-	delete(m.config.Clusters, name)
-	m.viper.Set("clusters", m.config.Clusters)
+func (m *Manager) RemoveClusterConfig(name string) error {
+	m.removeValue("clusters", name)
+	return m.reload()
 }
 
 // GetEnabledClusters returns a list of enabled cluster names
@@ -181,37 +338,141 @@ func (m *Manager) GetClustersByLabel(labels map[string]string) []string {
 	return matching
 }
 
-// applyDefaults sets default values for configuration
-func (m *Manager) applyDefaults() {
-	if m.config == nil {
-		return
+// GetClustersBySelector returns clusters whose labels match a Kubernetes-style
+// label selector, e.g. "env=prod,region in (us-east-1,us-west-2),!deprecated"
+// Disabled clusters are skipped unless includeDisabled is set
+// Names are returned in sorted order
+func (m *Manager) GetClustersBySelector(selector string, includeDisabled bool) ([]string, error) {
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster selector %q: %w", selector, err)
+	}
+
+	matching := make([]string, 0)
+	for name, cluster := range m.config.Clusters {
+		if !cluster.Enabled && !includeDisabled {
+			continue
+		}
+
+		if sel.Matches(labels.Set(cluster.Labels)) {
+			matching = append(matching, name)
+		}
+	}
+
+	sort.Strings(matching)
+	return matching, nil
+}
+
+// ResolveContext returns the kubeconfig context for a configured cluster name or alias
+// Falls back to the name itself when the cluster is unknown or has no context set
+func (m *Manager) ResolveContext(name string) string {
+	if cluster, ok := m.config.Clusters[name]; ok {
+		return cluster.ContextName(name)
 	}
+	for clusterName, cluster := range m.config.Clusters {
+		if cluster.Alias == name {
+			return cluster.ContextName(clusterName)
+		}
+	}
+	return name
+}
+
+// defaultConfigPaths returns the locations searched for the fleet config, in order
+func defaultConfigPaths() ([]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return []string{
+		filepath.Join(home, defaultConfigDir, defaultConfigFile),
+		filepath.Join(home, defaultConfigName+".yaml"),
+	}, nil
+}
+
+// StateDir returns the directory for state shared between invocations, such
+// as circuit breaker state: ~/.fleet
+func StateDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, defaultConfigDir), nil
+}
+
+// findConfigFile returns the first existing default config file, or "" if none exist
+func findConfigFile() (string, error) {
+	paths, err := defaultConfigPaths()
+	if err != nil {
+		return "", err
+	}
+
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", nil
+}
+
+// withDefaults returns a copy of file, the config as read from the file,
+// with default values filled in
+// The copy is what commands read; file is left as the user wrote it.
+func (m *Manager) withDefaults(file *FleetConfig) *FleetConfig {
+	config := *file
+	config.Clusters = maps.Clone(file.Clusters)
+
+	// The in-memory config is always the current schema version
+	config.APIVersion = APIVersion
+	config.Kind = Kind
 
 	// Set default timeout
-	if m.config.Defaults.Timeout == 0 {
-		m.config.Defaults.Timeout = 30 * time.Second
+	if config.Defaults.Timeout == 0 {
+		config.Defaults.Timeout = 30 * time.Second
 	}
 
 	// Set default parallel workers
-	if m.config.Defaults.Parallel == 0 {
-		m.config.Defaults.Parallel = 5
+	if config.Defaults.Parallel == 0 {
+		config.Defaults.Parallel = 5
 	}
 
 	// Set default output format
-	if m.config.Defaults.OutputFormat == "" {
-		m.config.Defaults.OutputFormat = "table"
+	if config.Defaults.OutputFormat == "" {
+		config.Defaults.OutputFormat = "table"
+	}
+
+	// Match client-go's rate limits unless configured
+	if config.Defaults.QPS == 0 {
+		config.Defaults.QPS = rest.DefaultQPS
+	}
+	if config.Defaults.Burst == 0 {
+		config.Defaults.Burst = rest.DefaultBurst
+	}
+
+	// Circuit breaker defaults
+	if config.CircuitBreaker.Threshold == 0 {
+		config.CircuitBreaker.Threshold = 3
+	}
+	if config.CircuitBreaker.Cooldown == 0 {
+		config.CircuitBreaker.Cooldown = 2 * time.Minute
 	}
 
 	// Enable all clusters by default if not specified
-	for name, cluster := range m.config.Clusters {
+	for name, cluster := range config.Clusters {
 		// If enabled field is not explicitly set, default to true
-		// This is a bit tricky with zero values, but we assume if someone
-		// adds a cluster to config, they want it enabled
+		// We assume if someone adds a cluster to config, they want it enabled;
+		// only an explicit "enabled: false" takes it out of operations
+		if !m.viper.IsSet("clusters." + name + ".enabled") {
+			cluster.Enabled = true
+		}
 		if cluster.Alias == "" {
 			cluster.Alias = name
 		}
-		m.config.Clusters[name] = cluster
+		config.Clusters[name] = cluster
 	}
+
+	return &config
 }
 
 // matchesLabels checks if cluster labels match the required labels
@@ -232,6 +493,11 @@ func matchesLabels(clusterLabels, requiredLabels map[string]string) bool {
 
 // MergeClusterInfo merges fleet config data into cluster info from kubeconfig
 func (m *Manager) MergeClusterInfo(clusters []ClusterInfo) []ClusterInfo {
+	groups := m.GroupsByContext()
+	for i := range clusters {
+		clusters[i].Groups = groups[clusters[i].Context]
+	}
+
 	if m.config.Clusters == nil {
 		return clusters
 	}
@@ -1,10 +1,16 @@
 package config
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 )
 
 func TestManager_Load(t *testing.T) {
@@ -256,6 +262,8 @@ clusters:
   cluster-3:
     context: context-3
     enabled: true
+  cluster-4:
+    context: context-4
 `
 
 	tmpDir := t.TempDir()
@@ -272,8 +280,9 @@ clusters:
 
 	enabled := manager.GetEnabledClusters()
 
-	if len(enabled) != 2 {
-		t.Errorf("got %d enabled clusters, want 2", len(enabled))
+	// cluster-4 omits the enabled field and defaults to enabled
+	if len(enabled) != 3 {
+		t.Errorf("got %d enabled clusters, want 3", len(enabled))
 	}
 
 	// Check that cluster-2 is not in the list
@@ -385,6 +394,193 @@ clusters:
 	}
 }
 
+func TestManager_GetClustersBySelector(t *testing.T) {
+	configContent := `
+clusters:
+  cluster-1:
+    context: context-1
+    enabled: true
+    labels:
+      env: prod
+      region: us-east-1
+  cluster-2:
+    context: context-2
+    enabled: true
+    labels:
+      env: prod
+      region: eu-west-1
+  cluster-3:
+    context: context-3
+    enabled: true
+    labels:
+      env: prod
+      region: us-west-2
+      deprecated: "true"
+  cluster-4:
+    context: context-4
+    enabled: true
+    labels:
+      env: staging
+      region: us-west-2
+  cluster-5:
+    context: context-5
+    enabled: false
+    labels:
+      env: prod
+      region: us-east-1
+`
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".fleet.yaml")
+
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	manager := NewManager(configPath)
+	if _, err := manager.Load(); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		selector        string
+		includeDisabled bool
+		wantNames       []string
+		wantErr         bool
+	}{
+		{
+			name:      "equality",
+			selector:  "env=prod",
+			wantNames: []string{"cluster-1", "cluster-2", "cluster-3"},
+		},
+		{
+			name:            "include disabled",
+			selector:        "env=prod,region=us-east-1",
+			includeDisabled: true,
+			wantNames:       []string{"cluster-1", "cluster-5"},
+		},
+		{
+			name:      "set-based in",
+			selector:  "env=prod,region in (us-east-1,us-west-2)",
+			wantNames: []string{"cluster-1", "cluster-3"},
+		},
+		{
+			name:      "does not exist",
+			selector:  "env=prod,region in (us-east-1,us-west-2),!deprecated",
+			wantNames: []string{"cluster-1"},
+		},
+		{
+			name:      "not equal",
+			selector:  "env!=prod",
+			wantNames: []string{"cluster-4"},
+		},
+		{
+			name:      "notin",
+			selector:  "region notin (us-east-1,us-west-2)",
+			wantNames: []string{"cluster-2"},
+		},
+		{
+			name:      "empty selector matches all enabled",
+			selector:  "",
+			wantNames: []string{"cluster-1", "cluster-2", "cluster-3", "cluster-4"},
+		},
+		{
+			name:      "no matches",
+			selector:  "env=development",
+			wantNames: []string{},
+		},
+		{
+			name:     "invalid selector",
+			selector: "region in (us-east-1",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusters, err := manager.GetClustersBySelector(tt.selector, tt.includeDisabled)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetClustersBySelector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(clusters) != len(tt.wantNames) {
+				t.Fatalf("got clusters %v, want %v", clusters, tt.wantNames)
+			}
+			for i := range clusters {
+				if clusters[i] != tt.wantNames[i] {
+					t.Errorf("got clusters %v, want %v", clusters, tt.wantNames)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestFleetConfig_DisabledContexts(t *testing.T) {
+	cfg := &FleetConfig{
+		Clusters: map[string]ClusterConfig{
+			"prod":    {Context: "arn:aws:eks:us-east-1:123456789012:cluster/prod", Enabled: true},
+			"legacy":  {Context: "old-context", Enabled: false},
+			"sandbox": {Enabled: false},
+		},
+	}
+
+	disabled := cfg.DisabledContexts()
+
+	if len(disabled) != 2 {
+		t.Fatalf("got %d disabled contexts, want 2: %v", len(disabled), disabled)
+	}
+	if !disabled["old-context"] || !disabled["sandbox"] {
+		t.Errorf("unexpected disabled contexts: %v", disabled)
+	}
+}
+
+func TestFleetConfig_EnabledContexts(t *testing.T) {
+	cfg := &FleetConfig{
+		Clusters: map[string]ClusterConfig{
+			"legacy":  {Context: "old-context", Enabled: false},
+			"sandbox": {Enabled: false},
+		},
+	}
+
+	enabled, disabled := cfg.EnabledContexts([]string{"sandbox", "prod", "old-context", "dev"})
+
+	if !reflect.DeepEqual(enabled, []string{"prod", "dev"}) {
+		t.Errorf("enabled = %v, want [prod dev]", enabled)
+	}
+	if !reflect.DeepEqual(disabled, []string{"sandbox", "old-context"}) {
+		t.Errorf("disabled = %v, want [sandbox old-context]", disabled)
+	}
+}
+
+func TestManager_ResolveContext(t *testing.T) {
+	manager := NewManager("")
+	manager.SetClusterConfig("prod", ClusterConfig{Context: "arn:aws:eks:us-east-1:123456789012:cluster/prod", Alias: "production"})
+	manager.SetClusterConfig("staging", ClusterConfig{})
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "prod", want: "arn:aws:eks:us-east-1:123456789012:cluster/prod"},
+		{name: "production", want: "arn:aws:eks:us-east-1:123456789012:cluster/prod"},
+		{name: "staging", want: "staging"},
+		{name: "unknown", want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := manager.ResolveContext(tt.name); got != tt.want {
+				t.Errorf("ResolveContext(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestManager_MergeClusterInfo(t *testing.T) {
 	configContent := `
 clusters:
@@ -500,3 +696,372 @@ func TestManager_Save(t *testing.T) {
 		t.Errorf("got alias %q, want %q", cluster.Alias, "test")
 	}
 }
+
+func TestManager_DefaultConfigPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	// Nothing on disk yet: defaults apply and Save creates ~/.fleet/config.yaml
+	manager := NewManager("")
+	if _, err := manager.Load(); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if manager.ConfigPath() != "" {
+		t.Errorf("got config path %q, want empty", manager.ConfigPath())
+	}
+
+	manager.SetClusterConfig("test-cluster", ClusterConfig{Context: "test-context", Enabled: true})
+	if err := manager.Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	wantPath := filepath.Join(home, ".fleet", "config.yaml")
+	if manager.ConfigPath() != wantPath {
+		t.Errorf("saved to %q, want %q", manager.ConfigPath(), wantPath)
+	}
+
+	// A fresh manager must find what was just saved
+	manager2 := NewManager("")
+	config, err := manager2.Load()
+	if err != nil {
+		t.Fatalf("failed to load saved config: %v", err)
+	}
+	if _, found := config.Clusters["test-cluster"]; !found {
+		t.Error("test-cluster not found in reloaded config")
+	}
+}
+
+func TestManager_SaveWritesLoadedFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	legacyPath := filepath.Join(home, ".fleet.yaml")
+	if err := os.WriteFile(legacyPath, []byte("clusters:\n  a:\n    context: a\n    enabled: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	manager := NewManager("")
+	if _, err := manager.Load(); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	manager.RemoveClusterConfig("a")
+	if err := manager.Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	if manager.ConfigPath() != legacyPath {
+		t.Errorf("saved to %q, want %q", manager.ConfigPath(), legacyPath)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".fleet", "config.yaml")); !os.IsNotExist(err) {
+		t.Error("expected no new config file to be created")
+	}
+}
+
+func TestManager_SaveKeepsFile(t *testing.T) {
+	configContent := `apiVersion: fleet/v1
+kind: FleetConfig
+# Shared settings, reviewed in git
+defaults:
+  outputFormat: json # machine-readable by default
+clusters:
+  prod-east:
+    context: prod-east-ctx
+groups:
+  prod:
+    - prod-east
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	manager := NewManager(configPath)
+	if _, err := manager.Load(); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if err := manager.SetClusterConfig("staging", ClusterConfig{Context: "staging-ctx", Enabled: true}); err != nil {
+		t.Fatalf("SetClusterConfig() error = %v", err)
+	}
+	if err := manager.Set("defaults.parallel", "8"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := manager.Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `apiVersion: fleet/v1
+kind: FleetConfig
+# Shared settings, reviewed in git
+defaults:
+  outputFormat: json # machine-readable by default
+  parallel: 8
+clusters:
+  prod-east:
+    context: prod-east-ctx
+  staging:
+    context: staging-ctx
+    enabled: true
+groups:
+  prod:
+    - prod-east
+`
+	if string(data) != want {
+		t.Errorf("saved file:\n%s\nwant only the changes, with keys, comments and unset values kept:\n%s", data, want)
+	}
+
+	// Defaults still apply to what was left unset
+	cluster, _ := manager.GetClusterConfig("prod-east")
+	if !cluster.Enabled || cluster.Alias != "prod-east" {
+		t.Errorf("prod-east = %+v, want it enabled with its name as alias", cluster)
+	}
+}
+
+func TestManager_WithDefaults(t *testing.T) {
+	file := &FleetConfig{
+		Clusters: map[string]ClusterConfig{"prod": {Context: "prod-ctx"}},
+	}
+
+	config := NewManager("").withDefaults(file)
+	if cluster := config.Clusters["prod"]; !cluster.Enabled || cluster.Alias != "prod" {
+		t.Errorf("prod = %+v, want defaults applied", cluster)
+	}
+	if config.Defaults.Timeout != 30*time.Second {
+		t.Errorf("Timeout = %v, want 30s", config.Defaults.Timeout)
+	}
+
+	// The config as read from the file keeps only what the user set
+	if cluster := file.Clusters["prod"]; cluster.Enabled || cluster.Alias != "" || file.Defaults.Timeout != 0 || file.APIVersion != "" {
+		t.Errorf("file config = %+v, want it left unchanged", file)
+	}
+}
+
+func TestManager_ApplyOverrides(t *testing.T) {
+	fileConfig := `defaults:
+  timeout: 1m
+  parallel: 10
+  outputFormat: json
+  qps: 20
+  retries: 2
+  deadline: 10m
+  adaptiveParallel: true
+`
+
+	tests := []struct {
+		name         string
+		configFile   string
+		env          map[string]string
+		flags        []string
+		wantTimeout  time.Duration
+		wantParallel int
+		wantOutput   string
+		wantNoColor  bool
+		wantQPS      float32
+		wantRetries  int
+		wantDeadline time.Duration
+		wantAdaptive bool
+		wantSource   string
+	}{
+		{
+			name:         "built-in defaults",
+			wantTimeout:  30 * time.Second,
+			wantParallel: 5,
+			wantOutput:   "table",
+			wantQPS:      5,
+			wantSource:   SourceDefault,
+		},
+		{
+			name:         "config file overrides built-in defaults",
+			configFile:   fileConfig,
+			wantTimeout:  time.Minute,
+			wantParallel: 10,
+			wantOutput:   "json",
+			wantQPS:      20,
+			wantRetries:  2,
+			wantDeadline: 10 * time.Minute,
+			wantAdaptive: true,
+			wantSource:   SourceFile,
+		},
+		{
+			name:         "env overrides config file",
+			configFile:   fileConfig,
+			env:          map[string]string{"FLEET_TIMEOUT": "2m", "FLEET_NO_COLOR": "true", "FLEET_QPS": "30", "FLEET_RETRIES": "3", "FLEET_ADAPTIVE_PARALLEL": "false"},
+			wantTimeout:  2 * time.Minute,
+			wantParallel: 10,
+			wantOutput:   "json",
+			wantNoColor:  true,
+			wantQPS:      30,
+			wantRetries:  3,
+			wantDeadline: 10 * time.Minute,
+			wantSource:   SourceEnv,
+		},
+		{
+			name:         "flag overrides env",
+			configFile:   fileConfig,
+			env:          map[string]string{"FLEET_TIMEOUT": "2m", "FLEET_OUTPUT": "yaml"},
+			flags:        []string{"--timeout=5m", "--parallel=3", "--qps=50", "--retries=4", "--deadline=15m", "--adaptive-parallel"},
+			wantTimeout:  5 * time.Minute,
+			wantParallel: 3,
+			wantOutput:   "yaml",
+			wantQPS:      50,
+			wantRetries:  4,
+			wantDeadline: 15 * time.Minute,
+			wantAdaptive: true,
+			wantSource:   SourceFlag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			t.Setenv("HOME", tmpDir)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			configPath := ""
+			if tt.configFile != "" {
+				configPath = filepath.Join(tmpDir, "config.yaml")
+				if err := os.WriteFile(configPath, []byte(tt.configFile), 0644); err != nil {
+					t.Fatalf("failed to write config: %v", err)
+				}
+			}
+
+			// Mirror the flags bound by the root command
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flags.Duration("timeout", 30*time.Second, "")
+			flags.Int("parallel", 5, "")
+			flags.String("output", "", "")
+			flags.Bool("no-color", false, "")
+			flags.Float32("qps", 5, "")
+			flags.Int("burst", 10, "")
+			flags.Bool("adaptive-qps", false, "")
+			flags.Int("retries", 0, "")
+			flags.Duration("deadline", 0, "")
+			flags.Bool("adaptive-parallel", false, "")
+			if err := flags.Parse(tt.flags); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+
+			v := viper.New()
+			v.SetEnvPrefix("FLEET")
+			v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+			v.AutomaticEnv()
+			if err := v.BindPFlags(flags); err != nil {
+				t.Fatalf("failed to bind flags: %v", err)
+			}
+
+			m := NewManager(configPath)
+			if _, err := m.Load(); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			m.ApplyOverrides(v, flags)
+
+			defaults := m.GetConfig().Defaults
+			if defaults.Timeout != tt.wantTimeout {
+				t.Errorf("Timeout = %v, want %v", defaults.Timeout, tt.wantTimeout)
+			}
+			if defaults.Parallel != tt.wantParallel {
+				t.Errorf("Parallel = %d, want %d", defaults.Parallel, tt.wantParallel)
+			}
+			if defaults.OutputFormat != tt.wantOutput {
+				t.Errorf("OutputFormat = %q, want %q", defaults.OutputFormat, tt.wantOutput)
+			}
+			if defaults.NoColor != tt.wantNoColor {
+				t.Errorf("NoColor = %v, want %v", defaults.NoColor, tt.wantNoColor)
+			}
+			if defaults.QPS != tt.wantQPS {
+				t.Errorf("QPS = %v, want %v", defaults.QPS, tt.wantQPS)
+			}
+			if defaults.Retries != tt.wantRetries {
+				t.Errorf("Retries = %d, want %d", defaults.Retries, tt.wantRetries)
+			}
+			if defaults.Deadline != tt.wantDeadline {
+				t.Errorf("Deadline = %v, want %v", defaults.Deadline, tt.wantDeadline)
+			}
+			if defaults.AdaptiveParallel != tt.wantAdaptive {
+				t.Errorf("AdaptiveParallel = %v, want %v", defaults.AdaptiveParallel, tt.wantAdaptive)
+			}
+			if defaults.Burst != 10 {
+				t.Errorf("Burst = %d, want the built-in 10", defaults.Burst)
+			}
+			if got := m.Sources()["defaults.timeout"]; got != tt.wantSource {
+				t.Errorf("timeout source = %q, want %q", got, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	m := NewManager("")
+	ctx := NewContext(context.Background(), m)
+
+	got, err := FromContext(ctx)
+	if err != nil {
+		t.Fatalf("FromContext() error = %v", err)
+	}
+	if got != m {
+		t.Error("FromContext() did not return the stored manager")
+	}
+}
+
+func TestManager_LoadClusterOverrides(t *testing.T) {
+	configContent := `
+clusters:
+  airgap:
+    context: airgap-ctx
+    kubeconfig: ~/.kube/airgap
+    namespace: platform
+    timeout: 5m
+    qps: 20
+    burst: 40
+    proxyURL: socks5://bastion:1080
+    impersonate:
+      user: fleet-operator
+      groups:
+        - ops
+        - auditors
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	m := NewManager(configPath)
+	cfg, err := m.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	cluster, ok := cfg.ClusterForContext("airgap-ctx")
+	if !ok {
+		t.Fatal("ClusterForContext() did not find airgap-ctx")
+	}
+
+	if cluster.Kubeconfig != "~/.kube/airgap" {
+		t.Errorf("Kubeconfig = %q", cluster.Kubeconfig)
+	}
+	if cluster.Namespace != "platform" {
+		t.Errorf("Namespace = %q, want platform", cluster.Namespace)
+	}
+	if cluster.Timeout != 5*time.Minute {
+		t.Errorf("Timeout = %v, want 5m", cluster.Timeout)
+	}
+	if cluster.QPS != 20 || cluster.Burst != 40 {
+		t.Errorf("QPS/Burst = %v/%d, want 20/40", cluster.QPS, cluster.Burst)
+	}
+	if cluster.ProxyURL != "socks5://bastion:1080" {
+		t.Errorf("ProxyURL = %q", cluster.ProxyURL)
+	}
+	if cluster.Impersonate == nil || cluster.Impersonate.User != "fleet-operator" || len(cluster.Impersonate.Groups) != 2 {
+		t.Errorf("Impersonate = %+v", cluster.Impersonate)
+	}
+
+	if got := cfg.DedicatedKubeconfigContexts(); len(got) != 1 || got[0] != "airgap-ctx" {
+		t.Errorf("DedicatedKubeconfigContexts() = %v, want [airgap-ctx]", got)
+	}
+}
@@ -0,0 +1,32 @@
+// Package configtest provides helpers for tests that need a fleet config file
+package configtest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aryankumar/fleet/internal/config"
+)
+
+// Write writes content to config.yaml in a temporary directory and returns its path
+func Write(t testing.TB, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+// Load writes content to a temporary config file and returns a manager that has loaded it
+func Load(t testing.TB, content string) *config.Manager {
+	t.Helper()
+
+	m := config.NewManager(Write(t, content))
+	if _, err := m.Load(); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	return m
+}
@@ -0,0 +1,25 @@
+package config
+
+import "context"
+
+// contextKey is the private key type for storing the manager in a context
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the given configuration manager
+func NewContext(ctx context.Context, m *Manager) context.Context {
+	return context.WithValue(ctx, contextKey{}, m)
+}
+
+// FromContext returns the configuration manager stored in ctx
+// If none is stored, the config is loaded from the default locations
+func FromContext(ctx context.Context) (*Manager, error) {
+	if m, ok := ctx.Value(contextKey{}).(*Manager); ok && m != nil {
+		return m, nil
+	}
+
+	m := NewManager("")
+	if _, err := m.Load(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// document returns the root mapping of the config file document, starting
+// an empty one if no file was loaded
+func (m *Manager) document() *yaml.Node {
+	if m.doc == nil || len(m.doc.Content) == 0 || m.doc.Content[0].Kind != yaml.MappingNode {
+		m.doc = &yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+	return m.doc.Content[0]
+}
+
+// setValue stores value at path in the config document, adding mappings
+// that are missing; everything else in the file is left as it was
+// Existing keys match case-insensitively, as viper reads them; new struct
+// fields are named as in FleetConfig's yaml tags.
+func (m *Manager) setValue(path []string, value interface{}) error {
+	var encoded yaml.Node
+	if err := encoded.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode %q: %w", strings.Join(path, "."), err)
+	}
+
+	node := m.document()
+	t := reflect.TypeOf(FleetConfig{})
+
+	for i, segment := range path {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		key := segment
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := structField(t, segment)
+			if !ok {
+				return fmt.Errorf("unknown key %q", strings.Join(path[:i+1], "."))
+			}
+			key, t = yamlName(field), field.Type
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return fmt.Errorf("unknown key %q", strings.Join(path[:i+1], "."))
+		}
+
+		at := mappingIndex(node, key)
+		if i == len(path)-1 {
+			if at < 0 {
+				node.Content = append(node.Content, keyNode(key), &encoded)
+				return nil
+			}
+			old := node.Content[at+1]
+			encoded.HeadComment, encoded.LineComment, encoded.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			node.Content[at+1] = &encoded
+			return nil
+		}
+
+		if at < 0 {
+			child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, keyNode(key), child)
+			node = child
+			continue
+		}
+		if child := node.Content[at+1]; child.Kind != yaml.MappingNode {
+			// An empty section such as "defaults:" parses as null
+			child.Kind, child.Tag, child.Value, child.Style = yaml.MappingNode, "!!map", "", 0
+		}
+		node = node.Content[at+1]
+	}
+
+	return nil
+}
+
+// removeValue deletes key from the map under section in the config document
+func (m *Manager) removeValue(section, key string) {
+	node := mappingValue(m.document(), section)
+	if node == nil {
+		return
+	}
+	if at := mappingIndex(node, key); at >= 0 {
+		node.Content = append(node.Content[:at], node.Content[at+2:]...)
+	}
+}
+
+// reload re-reads the effective config from the edited document
+func (m *Manager) reload() error {
+	data, err := encodeDocument(m.doc)
+	if err != nil {
+		return err
+	}
+	return m.decode(data)
+}
+
+// mappingIndex returns the index of key in a mapping's Content, matched
+// case-insensitively like mappingValue, or -1
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if strings.EqualFold(node.Content[i].Value, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// keyNode returns a mapping key node
+func keyNode(key string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+}
+
+// encodeDocument writes a config document the way Migrate and Save do
+func encodeDocument(doc *yaml.Node) ([]byte, error) {
+	if doc == nil {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// ExportContexts builds a standalone kubeconfig holding only the given
+// contexts and the clusters and users they reference.
+//
+// Contexts are taken from the merged kubeconfig, or from the dedicated
+// kubeconfig of the fleet config cluster using them. fleetConfig may be nil.
+// With flatten, certificate and key files are inlined so the result can be
+// used on another machine.
+func (l *KubeconfigLoader) ExportContexts(contexts []string, fleetConfig *FleetConfig, flatten bool) (*api.Config, error) {
+	merged, err := l.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	exported := api.NewConfig()
+	for _, contextName := range contexts {
+		source := merged
+		if _, ok := merged.Contexts[contextName]; !ok && fleetConfig != nil {
+			if cluster, ok := fleetConfig.ClusterForContext(contextName); ok && cluster.Kubeconfig != "" {
+				source, err = NewKubeconfigLoader(cluster.Kubeconfig).Load()
+				if err != nil {
+					return nil, fmt.Errorf("context %q: %w", contextName, err)
+				}
+			}
+		}
+
+		pruned, err := PruneKubeconfig(source, []string{contextName})
+		if err != nil {
+			return nil, err
+		}
+		MergeContext(exported, pruned, contextName)
+	}
+
+	exported.CurrentContext = merged.CurrentContext
+	if _, ok := exported.Contexts[exported.CurrentContext]; !ok && len(contexts) > 0 {
+		exported.CurrentContext = contexts[0]
+	}
+
+	if flatten {
+		if err := api.FlattenConfig(exported); err != nil {
+			return nil, fmt.Errorf("failed to inline certificate files: %w", err)
+		}
+	}
+
+	return exported, nil
+}
+
+// PruneKubeconfig returns a copy of config with only the given contexts and
+// the clusters and users they reference. The current context is kept if it
+// is among them, otherwise the first given context becomes current.
+func PruneKubeconfig(config *api.Config, contexts []string) (*api.Config, error) {
+	pruned := api.NewConfig()
+	pruned.Preferences = *config.Preferences.DeepCopy()
+
+	for _, contextName := range contexts {
+		context, ok := config.Contexts[contextName]
+		if !ok {
+			return nil, fmt.Errorf("context %q not found in kubeconfig", contextName)
+		}
+		pruned.Contexts[contextName] = context.DeepCopy()
+
+		if context.Cluster != "" {
+			cluster, ok := config.Clusters[context.Cluster]
+			if !ok {
+				return nil, fmt.Errorf("context %q refers to missing cluster %q", contextName, context.Cluster)
+			}
+			pruned.Clusters[context.Cluster] = cluster.DeepCopy()
+		}
+
+		if context.AuthInfo != "" {
+			authInfo, ok := config.AuthInfos[context.AuthInfo]
+			if !ok {
+				return nil, fmt.Errorf("context %q refers to missing user %q", contextName, context.AuthInfo)
+			}
+			pruned.AuthInfos[context.AuthInfo] = authInfo.DeepCopy()
+		}
+	}
+
+	if slices.Contains(contexts, config.CurrentContext) {
+		pruned.CurrentContext = config.CurrentContext
+	} else if len(contexts) > 0 {
+		pruned.CurrentContext = contexts[0]
+	}
+
+	return pruned, nil
+}
+
+// MergeContext copies a context from src into dst along with its cluster and
+// user. A cluster or user whose name is already taken by a different entry in
+// dst is renamed after the context, since separate kubeconfig files often
+// reuse names like "admin".
+func MergeContext(dst, src *api.Config, contextName string) {
+	context := src.Contexts[contextName].DeepCopy()
+
+	if cluster, ok := src.Clusters[context.Cluster]; ok {
+		if existing, taken := dst.Clusters[context.Cluster]; taken && !clustersEqual(existing, cluster) {
+			context.Cluster = contextName
+		}
+		dst.Clusters[context.Cluster] = cluster
+	}
+
+	if authInfo, ok := src.AuthInfos[context.AuthInfo]; ok {
+		if existing, taken := dst.AuthInfos[context.AuthInfo]; taken && !authInfosEqual(existing, authInfo) {
+			context.AuthInfo = contextName
+		}
+		dst.AuthInfos[context.AuthInfo] = authInfo
+	}
+
+	dst.Contexts[contextName] = context
+}
+
+// clustersEqual compares two kubeconfig clusters, ignoring where they were loaded from
+func clustersEqual(a, b *api.Cluster) bool {
+	a, b = a.DeepCopy(), b.DeepCopy()
+	a.LocationOfOrigin, b.LocationOfOrigin = "", ""
+	return reflect.DeepEqual(a, b)
+}
+
+// authInfosEqual compares two kubeconfig users, ignoring where they were loaded from
+func authInfosEqual(a, b *api.AuthInfo) bool {
+	a, b = a.DeepCopy(), b.DeepCopy()
+	a.LocationOfOrigin, b.LocationOfOrigin = "", ""
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const exportTestKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: prod-cluster
+  cluster:
+    server: https://prod.example.com
+    certificate-authority: ca.crt
+- name: staging-cluster
+  cluster:
+    server: https://staging.example.com
+- name: dev-cluster
+  cluster:
+    server: https://dev.example.com
+contexts:
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: admin
+- name: staging
+  context:
+    cluster: staging-cluster
+    user: ci
+- name: dev
+  context:
+    cluster: dev-cluster
+    user: admin
+current-context: dev
+users:
+- name: admin
+  user:
+    token: admin-token
+- name: ci
+  user:
+    token: ci-token
+`
+
+const exportTestDedicatedKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: prod-cluster
+  cluster:
+    server: https://airgap.internal
+contexts:
+- name: airgap
+  context:
+    cluster: prod-cluster
+    user: admin
+current-context: airgap
+users:
+- name: admin
+  user:
+    token: airgap-token
+`
+
+func writeExportKubeconfigs(t *testing.T) (string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"config":      exportTestKubeconfig,
+		"airgap.yaml": exportTestDedicatedKubeconfig,
+		"ca.crt":      "test-ca-data",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	return filepath.Join(dir, "config"), filepath.Join(dir, "airgap.yaml")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestPruneKubeconfig(t *testing.T) {
+	kubeconfigPath, _ := writeExportKubeconfigs(t)
+	merged, err := NewKubeconfigLoader(kubeconfigPath).Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		contexts     []string
+		wantClusters []string
+		wantUsers    []string
+		wantCurrent  string
+		wantErr      bool
+	}{
+		{
+			name:         "single context",
+			contexts:     []string{"staging"},
+			wantClusters: []string{"staging-cluster"},
+			wantUsers:    []string{"ci"},
+			wantCurrent:  "staging",
+		},
+		{
+			name:         "shared user and current context kept",
+			contexts:     []string{"prod", "dev"},
+			wantClusters: []string{"dev-cluster", "prod-cluster"},
+			wantUsers:    []string{"admin"},
+			wantCurrent:  "dev",
+		},
+		{
+			name:     "unknown context",
+			contexts: []string{"missing"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pruned, err := PruneKubeconfig(merged, tt.contexts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PruneKubeconfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			want := append([]string(nil), tt.contexts...)
+			sort.Strings(want)
+			if got := sortedKeys(pruned.Contexts); !reflect.DeepEqual(got, want) {
+				t.Errorf("contexts = %v, want %v", got, want)
+			}
+			if got := sortedKeys(pruned.Clusters); !reflect.DeepEqual(got, tt.wantClusters) {
+				t.Errorf("clusters = %v, want %v", got, tt.wantClusters)
+			}
+			if got := sortedKeys(pruned.AuthInfos); !reflect.DeepEqual(got, tt.wantUsers) {
+				t.Errorf("users = %v, want %v", got, tt.wantUsers)
+			}
+			if pruned.CurrentContext != tt.wantCurrent {
+				t.Errorf("current context = %q, want %q", pruned.CurrentContext, tt.wantCurrent)
+			}
+		})
+	}
+
+	// The source config is left untouched
+	if len(merged.Contexts) != 3 {
+		t.Errorf("source contexts = %d, want 3", len(merged.Contexts))
+	}
+}
+
+func TestKubeconfigLoader_ExportContexts(t *testing.T) {
+	kubeconfigPath, airgapPath := writeExportKubeconfigs(t)
+	fleetConfig := &FleetConfig{
+		Clusters: map[string]ClusterConfig{
+			"airgap": {Kubeconfig: airgapPath, Enabled: true},
+		},
+	}
+
+	t.Run("dedicated kubeconfig with clashing names", func(t *testing.T) {
+		exported, err := NewKubeconfigLoader(kubeconfigPath).ExportContexts([]string{"prod", "airgap"}, fleetConfig, false)
+		if err != nil {
+			t.Fatalf("ExportContexts() error = %v", err)
+		}
+
+		airgap := exported.Contexts["airgap"]
+		if airgap == nil {
+			t.Fatal("airgap context missing from export")
+		}
+		if airgap.Cluster != "airgap" || airgap.AuthInfo != "airgap" {
+			t.Errorf("airgap context = %s/%s, want entries renamed to airgap", airgap.Cluster, airgap.AuthInfo)
+		}
+		if got := exported.Clusters["airgap"].Server; got != "https://airgap.internal" {
+			t.Errorf("airgap server = %q, want https://airgap.internal", got)
+		}
+		if got := exported.Clusters["prod-cluster"].Server; got != "https://prod.example.com" {
+			t.Errorf("prod server = %q, want https://prod.example.com", got)
+		}
+		if got := exported.AuthInfos["admin"].Token; got != "admin-token" {
+			t.Errorf("admin token = %q, want admin-token", got)
+		}
+		if exported.CurrentContext != "prod" {
+			t.Errorf("current context = %q, want prod", exported.CurrentContext)
+		}
+	})
+
+	t.Run("flatten", func(t *testing.T) {
+		exported, err := NewKubeconfigLoader(kubeconfigPath).ExportContexts([]string{"prod"}, nil, true)
+		if err != nil {
+			t.Fatalf("ExportContexts() error = %v", err)
+		}
+
+		cluster := exported.Clusters["prod-cluster"]
+		if cluster.CertificateAuthority != "" {
+			t.Errorf("certificate-authority = %q, want it inlined", cluster.CertificateAuthority)
+		}
+		if string(cluster.CertificateAuthorityData) != "test-ca-data" {
+			t.Errorf("certificate-authority-data = %q, want test-ca-data", cluster.CertificateAuthorityData)
+		}
+	})
+
+	t.Run("unknown context", func(t *testing.T) {
+		if _, err := NewKubeconfigLoader(kubeconfigPath).ExportContexts([]string{"nope"}, fleetConfig, false); err == nil {
+			t.Error("ExportContexts() expected error for an unknown context")
+		}
+	})
+}
+
+func TestMergeContext_SameEntriesShared(t *testing.T) {
+	cluster := &api.Cluster{Server: "https://shared.example.com"}
+	dst := api.NewConfig()
+	dst.Clusters["shared"] = cluster
+
+	src := api.NewConfig()
+	src.Clusters["shared"] = &api.Cluster{Server: "https://shared.example.com", LocationOfOrigin: "/other"}
+	src.Contexts["b"] = &api.Context{Cluster: "shared"}
+
+	MergeContext(dst, src, "b")
+
+	if got := dst.Contexts["b"].Cluster; got != "shared" {
+		t.Errorf("cluster = %q, want identical entries to be shared", got)
+	}
+	if len(dst.Clusters) != 1 {
+		t.Errorf("clusters = %v, want one shared entry", sortedKeys(dst.Clusters))
+	}
+}
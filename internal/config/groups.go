@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GroupSelectorPrefix marks a group member that is a label selector
+// rather than a cluster or group name, e.g. "selector:env=prod"
+const GroupSelectorPrefix = "selector:"
+
+// GetGroupNames returns the names of all configured groups, sorted
+func (m *Manager) GetGroupNames() []string {
+	names := make([]string, 0, len(m.config.Groups))
+	for name := range m.config.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetClustersByGroups returns the sorted union of cluster names in the given groups
+//
+// Group members may be cluster names, other group names or label selectors
+// prefixed with "selector:". Nested groups are expanded recursively. Clusters
+// marked enabled: false are skipped unless includeDisabled is set.
+func (m *Manager) GetClustersByGroups(groups []string, includeDisabled bool) ([]string, error) {
+	members := make(map[string]bool)
+	for _, group := range groups {
+		if err := m.expandGroup(group, includeDisabled, make(map[string]bool), members); err != nil {
+			return nil, err
+		}
+	}
+
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// GroupsByContext maps each kubeconfig context to the sorted groups containing it
+// Disabled clusters are included so membership reflects the config as written.
+// Groups that fail to resolve are left out.
+func (m *Manager) GroupsByContext() map[string][]string {
+	byContext := make(map[string][]string)
+	for _, group := range m.GetGroupNames() {
+		names, err := m.GetClustersByGroups([]string{group}, true)
+		if err != nil {
+			continue
+		}
+		for _, name := range names {
+			contextName := m.ResolveContext(name)
+			byContext[contextName] = append(byContext[contextName], group)
+		}
+	}
+	return byContext
+}
+
+// expandGroup adds the clusters of a group to members
+// path holds the groups currently being expanded, to detect cycles
+func (m *Manager) expandGroup(group string, includeDisabled bool, path, members map[string]bool) error {
+	entries, ok := m.config.Groups[group]
+	if !ok {
+		return fmt.Errorf("group %q not found in fleet config", group)
+	}
+	if path[group] {
+		return fmt.Errorf("group %q is part of a cycle", group)
+	}
+
+	path[group] = true
+	defer delete(path, group)
+
+	for _, entry := range entries {
+		switch {
+		case strings.HasPrefix(entry, GroupSelectorPrefix):
+			selector := strings.TrimPrefix(entry, GroupSelectorPrefix)
+			names, err := m.GetClustersBySelector(selector, includeDisabled)
+			if err != nil {
+				return fmt.Errorf("group %q: %w", group, err)
+			}
+			for _, name := range names {
+				members[name] = true
+			}
+
+		case m.isGroup(entry):
+			if err := m.expandGroup(entry, includeDisabled, path, members); err != nil {
+				return err
+			}
+
+		default:
+			if cluster, ok := m.config.Clusters[entry]; ok && !cluster.Enabled && !includeDisabled {
+				continue
+			}
+			members[entry] = true
+		}
+	}
+
+	return nil
+}
+
+// isGroup reports whether name refers to a configured group
+func (m *Manager) isGroup(name string) bool {
+	_, ok := m.config.Groups[name]
+	return ok
+}
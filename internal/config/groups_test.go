@@ -0,0 +1,147 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/config/configtest"
+)
+
+const groupsTestConfig = `
+clusters:
+  prod-east:
+    context: prod-east-ctx
+    enabled: true
+    labels:
+      env: prod
+  prod-west:
+    enabled: true
+    labels:
+      env: prod
+  canary:
+    enabled: true
+    labels:
+      env: canary
+  legacy:
+    enabled: false
+    labels:
+      env: prod
+groups:
+  prod:
+    - prod-east
+    - prod-west
+    - legacy
+  labelled:
+    - selector:env=prod
+  rollout:
+    - canary
+    - prod
+  loop-a:
+    - loop-b
+  loop-b:
+    - loop-a
+  bad-selector:
+    - selector:env in (prod
+`
+
+func TestManager_GetClustersByGroups(t *testing.T) {
+	m := configtest.Load(t, groupsTestConfig)
+
+	tests := []struct {
+		name            string
+		groups          []string
+		includeDisabled bool
+		want            []string
+		wantErr         bool
+	}{
+		{
+			name:   "plain cluster names",
+			groups: []string{"prod"},
+			want:   []string{"prod-east", "prod-west"},
+		},
+		{
+			name:            "include disabled",
+			groups:          []string{"prod"},
+			includeDisabled: true,
+			want:            []string{"legacy", "prod-east", "prod-west"},
+		},
+		{
+			name:   "label selector member",
+			groups: []string{"labelled"},
+			want:   []string{"prod-east", "prod-west"},
+		},
+		{
+			name:   "nested group",
+			groups: []string{"rollout"},
+			want:   []string{"canary", "prod-east", "prod-west"},
+		},
+		{
+			name:   "union of groups",
+			groups: []string{"prod", "labelled"},
+			want:   []string{"prod-east", "prod-west"},
+		},
+		{
+			name:    "unknown group",
+			groups:  []string{"missing"},
+			wantErr: true,
+		},
+		{
+			name:    "cycle",
+			groups:  []string{"loop-a"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid selector",
+			groups:  []string{"bad-selector"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.GetClustersByGroups(tt.groups, tt.includeDisabled)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetClustersByGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetClustersByGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_GroupsByContext(t *testing.T) {
+	m := configtest.Load(t, groupsTestConfig)
+
+	got := m.GroupsByContext()
+
+	want := map[string][]string{
+		"prod-east-ctx": {"labelled", "prod", "rollout"},
+		"prod-west":     {"labelled", "prod", "rollout"},
+		"legacy":        {"labelled", "prod", "rollout"},
+		"canary":        {"rollout"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupsByContext() = %v, want %v", got, want)
+	}
+}
+
+func TestManager_MergeClusterInfoGroups(t *testing.T) {
+	m := configtest.Load(t, groupsTestConfig)
+
+	clusters := m.MergeClusterInfo([]config.ClusterInfo{
+		{Name: "east", Context: "prod-east-ctx"},
+		{Name: "other", Context: "other"},
+	})
+
+	if want := []string{"labelled", "prod", "rollout"}; !reflect.DeepEqual(clusters[0].Groups, want) {
+		t.Errorf("Groups = %v, want %v", clusters[0].Groups, want)
+	}
+	if len(clusters[1].Groups) != 0 {
+		t.Errorf("expected no groups for unconfigured context, got %v", clusters[1].Groups)
+	}
+}
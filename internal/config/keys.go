@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Get returns the effective value at a dotted key such as "defaults.timeout"
+// or "clusters.prod.alias". Keys match the config file names case-insensitively.
+func (m *Manager) Get(key string) (interface{}, error) {
+	// Round-trip through YAML so keys and durations look as they do in the file
+	data, err := yaml.Marshal(m.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	for _, segment := range splitKey(key) {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("key %q not found", key)
+		}
+
+		found := false
+		for name, v := range fields {
+			if strings.EqualFold(name, segment) {
+				value, found = v, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("key %q not found", key)
+		}
+	}
+
+	return value, nil
+}
+
+// Set parses value according to the type of key and stores it in the config
+// Only single values can be set; lists take a comma-separated value.
+// Call Save to write the change to the config file.
+func (m *Manager) Set(key, value string) error {
+	path := splitKey(key)
+	t, err := fieldType(path)
+	if err != nil {
+		return err
+	}
+
+	if err := checkScalar(t, path, value); err != nil {
+		return fmt.Errorf("invalid value for %q: %w", key, err)
+	}
+
+	parsed, err := parseValue(t, value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %q: %w", key, err)
+	}
+
+	if err := m.setValue(path, parsed); err != nil {
+		return err
+	}
+	if err := m.reload(); err != nil {
+		return fmt.Errorf("failed to apply %q: %w", key, err)
+	}
+
+	return nil
+}
+
+// splitKey splits a dotted config key into its segments
+func splitKey(key string) []string {
+	if key == "" {
+		return nil
+	}
+	return strings.Split(key, ".")
+}
+
+// fieldType returns the Go type of the config value at path
+// Struct fields are matched by their YAML name, case-insensitively; map
+// entries accept any key.
+func fieldType(path []string) (reflect.Type, error) {
+	t := reflect.TypeOf(FleetConfig{})
+
+	for i, segment := range path {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := structField(t, segment)
+			if !ok {
+				return nil, fmt.Errorf("unknown key %q", strings.Join(path[:i+1], "."))
+			}
+			t = field.Type
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return nil, fmt.Errorf("unknown key %q", strings.Join(path[:i+1], "."))
+		}
+	}
+
+	return t, nil
+}
+
+// structField finds a struct field by its YAML name, case-insensitively
+func structField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if strings.EqualFold(yamlName(field), name) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// yamlName returns the name a struct field has in the config file
+func yamlName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// parseValue converts a command-line string into a value of type t
+func parseValue(t reflect.Type, value string) (interface{}, error) {
+	if t == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		return d.String(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return value, nil
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+	case reflect.Int:
+		return strconv.Atoi(value)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(value, 64)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			if value == "" {
+				return []string{}, nil
+			}
+			return strings.Split(value, ","), nil
+		}
+	}
+
+	return nil, fmt.Errorf("not a single value; set its fields individually")
+}
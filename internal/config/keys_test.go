@@ -0,0 +1,133 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/config/configtest"
+)
+
+const keysTestConfig = `defaults:
+  timeout: 45s
+  parallel: 3
+clusters:
+  prod-east:
+    context: prod-east-ctx
+    alias: east
+    enabled: true
+    labels:
+      env: prod
+groups:
+  prod:
+    - prod-east
+`
+
+func TestManager_Get(t *testing.T) {
+	m := configtest.Load(t, keysTestConfig)
+
+	tests := []struct {
+		key     string
+		want    interface{}
+		wantErr bool
+	}{
+		{key: "defaults.timeout", want: "45s"},
+		{key: "defaults.parallel", want: 3},
+		{key: "Defaults.OutputFormat", want: "table"},
+		{key: "clusters.prod-east.alias", want: "east"},
+		{key: "clusters.prod-east.labels", want: map[string]interface{}{"env": "prod"}},
+		{key: "groups.prod", want: []interface{}{"prod-east"}},
+		{key: "defaults.missing", wantErr: true},
+		{key: "defaults.timeout.seconds", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := m.Get(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get(%q) = %#v, want %#v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		check   func(t *testing.T, cfg *config.FleetConfig)
+		wantErr bool
+	}{
+		{
+			name:  "duration",
+			key:   "defaults.timeout",
+			value: "2m",
+			check: func(t *testing.T, cfg *config.FleetConfig) {
+				if cfg.Defaults.Timeout != 2*time.Minute {
+					t.Errorf("Timeout = %v, want 2m", cfg.Defaults.Timeout)
+				}
+			},
+		},
+		{
+			name:  "cluster field",
+			key:   "clusters.prod-east.alias",
+			value: "production",
+			check: func(t *testing.T, cfg *config.FleetConfig) {
+				if got := cfg.Clusters["prod-east"].Alias; got != "production" {
+					t.Errorf("Alias = %q, want production", got)
+				}
+				if got := cfg.Clusters["prod-east"].Context; got != "prod-east-ctx" {
+					t.Errorf("Context = %q, want prod-east-ctx", got)
+				}
+			},
+		},
+		{
+			name:  "list",
+			key:   "groups.canary",
+			value: "staging,prod-east",
+			check: func(t *testing.T, cfg *config.FleetConfig) {
+				want := []string{"staging", "prod-east"}
+				if got := cfg.Groups["canary"]; !reflect.DeepEqual(got, want) {
+					t.Errorf("Groups[canary] = %v, want %v", got, want)
+				}
+			},
+		},
+		{name: "duration without unit", key: "defaults.timeout", value: "30", wantErr: true},
+		{name: "zero parallel", key: "defaults.parallel", value: "0", wantErr: true},
+		{name: "bad output format", key: "defaults.outputFormat", value: "xml", wantErr: true},
+		{name: "unknown key", key: "defaults.paralel", value: "5", wantErr: true},
+		{name: "not a single value", key: "clusters.prod-east", value: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := configtest.Load(t, keysTestConfig)
+
+			err := m.Set(tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			tt.check(t, m.GetConfig())
+
+			// The change must survive a save and reload
+			if err := m.Save(); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			reloaded := config.NewManager(m.ConfigPath())
+			reloadedConfig, err := reloaded.Load()
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			tt.check(t, reloadedConfig)
+		})
+	}
+}
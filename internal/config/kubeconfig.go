@@ -32,7 +32,7 @@ func NewKubeconfigLoader(explicitPath string) *KubeconfigLoader {
 
 	// Priority 1: Explicit path from flag
 	if explicitPath != "" {
-		if expandedPath, err := expandPath(explicitPath); err == nil {
+		if expandedPath, err := ExpandPath(explicitPath); err == nil {
 			loader.paths = append(loader.paths, expandedPath)
 		}
 		return loader
@@ -50,7 +50,7 @@ func NewKubeconfigLoader(explicitPath string) *KubeconfigLoader {
 			if path == "" {
 				continue
 			}
-			if expandedPath, err := expandPath(path); err == nil {
+			if expandedPath, err := ExpandPath(path); err == nil {
 				loader.paths = append(loader.paths, expandedPath)
 			}
 		}
@@ -130,6 +130,12 @@ func (l *KubeconfigLoader) GetClusters() ([]ClusterInfo, error) {
 		return nil, err
 	}
 
+	return ClusterInfos(config), nil
+}
+
+// ClusterInfos describes every context of a kubeconfig
+// Contexts whose cluster entry is missing are skipped.
+func ClusterInfos(config *api.Config) []ClusterInfo {
 	currentContext := config.CurrentContext
 	clusters := make([]ClusterInfo, 0, len(config.Contexts))
 
@@ -160,7 +166,7 @@ func (l *KubeconfigLoader) GetClusters() ([]ClusterInfo, error) {
 		clusters = append(clusters, info)
 	}
 
-	return clusters, nil
+	return clusters
 }
 
 // GetClusterInfo returns information about a specific context
@@ -198,39 +204,178 @@ func (l *KubeconfigLoader) GetClusterInfo(contextName string) (*ClusterInfo, err
 
 // BuildClientConfig creates a rest.Config for a specific context
 func (l *KubeconfigLoader) BuildClientConfig(contextName string) (*rest.Config, error) {
-	if len(l.paths) == 0 {
+	return l.BuildClientConfigWithOverrides(contextName, nil)
+}
+
+// BuildClientConfigWithOverrides builds a REST config for a context and applies
+// the per-cluster overrides from the fleet config (nil means none)
+//
+// A dedicated kubeconfig path replaces the loader's paths for this cluster.
+// Namespace and timeout are applied by the cluster client, not here.
+func (l *KubeconfigLoader) BuildClientConfigWithOverrides(contextName string, overrides *ClusterConfig) (*rest.Config, error) {
+	paths := l.paths
+	if overrides != nil && overrides.Kubeconfig != "" {
+		path, err := ExpandPath(overrides.Kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig path for context %q: %w", contextName, err)
+		}
+		paths = []string{path}
+	}
+
+	if len(paths) == 0 {
 		return nil, fmt.Errorf("no kubeconfig paths available")
 	}
 
 	loadingRules := &clientcmd.ClientConfigLoadingRules{
-		Precedence: l.paths,
+		Precedence: paths,
 	}
 
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules,
+		clientConfigOverrides(contextName, overrides),
+	)
+
+	return buildRESTConfig(clientConfig, contextName, overrides)
+}
+
+// BuildClientConfigFromKubeconfig builds a REST config for a context of an
+// in-memory kubeconfig, such as one assembled from discovered clusters, and
+// applies the same per-cluster overrides as BuildClientConfigWithOverrides
+func BuildClientConfigFromKubeconfig(kubeconfig *api.Config, contextName string, overrides *ClusterConfig) (*rest.Config, error) {
+	clientConfig := clientcmd.NewNonInteractiveClientConfig(
+		*kubeconfig,
+		contextName,
+		clientConfigOverrides(contextName, overrides),
+		nil,
+	)
+
+	return buildRESTConfig(clientConfig, contextName, overrides)
+}
+
+// clientConfigOverrides converts fleet config overrides into clientcmd overrides
+func clientConfigOverrides(contextName string, overrides *ClusterConfig) *clientcmd.ConfigOverrides {
 	configOverrides := &clientcmd.ConfigOverrides{}
 	if contextName != "" {
 		configOverrides.CurrentContext = contextName
 	}
+	if overrides != nil {
+		if overrides.Impersonate != nil {
+			configOverrides.AuthInfo.Impersonate = overrides.Impersonate.User
+			configOverrides.AuthInfo.ImpersonateGroups = overrides.Impersonate.Groups
+		}
+		configOverrides.ClusterInfo.ProxyURL = overrides.ProxyURL
+		if overrides.Timeout > 0 {
+			configOverrides.Timeout = overrides.Timeout.String()
+		}
+	}
+	return configOverrides
+}
 
-	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		loadingRules,
-		configOverrides,
-	)
-
+// buildRESTConfig resolves clientConfig and applies the rate limit overrides,
+// which have no clientcmd equivalent
+func buildRESTConfig(clientConfig clientcmd.ClientConfig, contextName string, overrides *ClusterConfig) (*rest.Config, error) {
 	restConfig, err := clientConfig.ClientConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client config for context %q: %w", contextName, err)
 	}
 
+	if overrides != nil {
+		if overrides.QPS > 0 {
+			restConfig.QPS = overrides.QPS
+		}
+		if overrides.Burst > 0 {
+			restConfig.Burst = overrides.Burst
+		}
+		// client-go rejects a QPS without a burst
+		if restConfig.QPS > 0 && restConfig.Burst == 0 {
+			restConfig.Burst = max(rest.DefaultBurst, int(restConfig.QPS))
+		}
+	}
+
 	return restConfig, nil
 }
 
+// SetCurrentContext updates current-context in the kubeconfig file that owns it
+// and returns the path of the file that was modified.
+//
+// Like kubectl, the file chosen is the explicit --kubeconfig path if set,
+// otherwise the first file in the precedence list that already sets a
+// current-context, falling back to the first file that exists.
+func (l *KubeconfigLoader) SetCurrentContext(contextName string) (string, error) {
+	merged, err := l.Load()
+	if err != nil {
+		return "", err
+	}
+
+	if _, exists := merged.Contexts[contextName]; !exists {
+		return "", fmt.Errorf("context %q not found in kubeconfig", contextName)
+	}
+
+	path, err := l.currentContextFile()
+	if err != nil {
+		return "", err
+	}
+
+	fileConfig, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+	}
+
+	fileConfig.CurrentContext = contextName
+	if err := clientcmd.WriteToFile(*fileConfig, path); err != nil {
+		return "", fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+	}
+
+	// Invalidate the cached merged config
+	l.loadedConfig = nil
+	l.configLoaded = false
+
+	return path, nil
+}
+
+// currentContextFile picks the kubeconfig file whose current-context takes effect
+func (l *KubeconfigLoader) currentContextFile() (string, error) {
+	if len(l.paths) == 0 {
+		return "", fmt.Errorf("no kubeconfig paths available")
+	}
+
+	if l.explicitPath != "" {
+		return l.paths[0], nil
+	}
+
+	firstExisting := ""
+	for _, path := range l.paths {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		if firstExisting == "" {
+			firstExisting = path
+		}
+
+		fileConfig, err := clientcmd.LoadFromFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to load kubeconfig %s: %w", path, err)
+		}
+		if fileConfig.CurrentContext != "" {
+			return path, nil
+		}
+	}
+
+	if firstExisting == "" {
+		return "", fmt.Errorf("no kubeconfig file found in %s", strings.Join(l.paths, ", "))
+	}
+
+	return firstExisting, nil
+}
+
 // GetPaths returns the kubeconfig paths being used
 func (l *KubeconfigLoader) GetPaths() []string {
 	return l.paths
 }
 
-// expandPath expands ~ to home directory and evaluates environment variables
-func expandPath(path string) (string, error) {
+// ExpandPath expands ~ to the home directory and evaluates environment variables
+func ExpandPath(path string) (string, error) {
 	// Expand environment variables
 	path = os.ExpandEnv(path)
 
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
@@ -289,6 +290,162 @@ func TestKubeconfigLoader_BuildClientConfig(t *testing.T) {
 	}
 }
 
+func TestKubeconfigLoader_BuildClientConfigWithOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	kubeconfigPath := filepath.Join(tmpDir, "config")
+	if err := clientcmd.WriteToFile(*createTestKubeconfig(), kubeconfigPath); err != nil {
+		t.Fatalf("failed to write test kubeconfig: %v", err)
+	}
+
+	// A dedicated kubeconfig holding a context the main file does not have
+	dedicated := &api.Config{
+		Clusters:  map[string]*api.Cluster{"bastion": {Server: "https://bastion:6443"}},
+		AuthInfos: map[string]*api.AuthInfo{"bastion": {Token: "bastion-token"}},
+		Contexts:  map[string]*api.Context{"airgap": {Cluster: "bastion", AuthInfo: "bastion"}},
+	}
+	dedicatedPath := filepath.Join(tmpDir, "airgap")
+	if err := clientcmd.WriteToFile(*dedicated, dedicatedPath); err != nil {
+		t.Fatalf("failed to write dedicated kubeconfig: %v", err)
+	}
+
+	loader := NewKubeconfigLoader(kubeconfigPath)
+
+	restConfig, err := loader.BuildClientConfigWithOverrides("test-context-1", &ClusterConfig{
+		Timeout:  2 * time.Minute,
+		QPS:      50,
+		Burst:    100,
+		ProxyURL: "socks5://localhost:1080",
+		Impersonate: &ImpersonateConfig{
+			User:   "fleet-operator",
+			Groups: []string{"system:masters"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BuildClientConfigWithOverrides() error = %v", err)
+	}
+
+	if restConfig.Timeout != 2*time.Minute {
+		t.Errorf("Timeout = %v, want 2m", restConfig.Timeout)
+	}
+	if restConfig.QPS != 50 || restConfig.Burst != 100 {
+		t.Errorf("QPS/Burst = %v/%d, want 50/100", restConfig.QPS, restConfig.Burst)
+	}
+	if restConfig.Impersonate.UserName != "fleet-operator" {
+		t.Errorf("Impersonate.UserName = %q, want fleet-operator", restConfig.Impersonate.UserName)
+	}
+	if len(restConfig.Impersonate.Groups) != 1 || restConfig.Impersonate.Groups[0] != "system:masters" {
+		t.Errorf("Impersonate.Groups = %v, want [system:masters]", restConfig.Impersonate.Groups)
+	}
+	if restConfig.Proxy == nil {
+		t.Error("expected proxy to be configured")
+	}
+
+	// Without overrides the client-go defaults are left alone
+	restConfig, err = loader.BuildClientConfigWithOverrides("test-context-1", nil)
+	if err != nil {
+		t.Fatalf("BuildClientConfigWithOverrides() error = %v", err)
+	}
+	if restConfig.Timeout != 0 || restConfig.Impersonate.UserName != "" || restConfig.Proxy != nil {
+		t.Errorf("unexpected overrides applied: %+v", restConfig)
+	}
+
+	// A dedicated kubeconfig replaces the loader's paths
+	restConfig, err = loader.BuildClientConfigWithOverrides("airgap", &ClusterConfig{Kubeconfig: dedicatedPath})
+	if err != nil {
+		t.Fatalf("BuildClientConfigWithOverrides() with dedicated kubeconfig error = %v", err)
+	}
+	if restConfig.Host != "https://bastion:6443" {
+		t.Errorf("Host = %q, want https://bastion:6443", restConfig.Host)
+	}
+}
+
+func TestKubeconfigLoader_SetCurrentContext(t *testing.T) {
+	writeConfig := func(t *testing.T, path string, config *api.Config) {
+		t.Helper()
+		if err := clientcmd.WriteToFile(*config, path); err != nil {
+			t.Fatalf("failed to write test kubeconfig: %v", err)
+		}
+	}
+
+	t.Run("explicit path", func(t *testing.T) {
+		kubeconfigPath := filepath.Join(t.TempDir(), "config")
+		writeConfig(t, kubeconfigPath, createTestKubeconfig())
+
+		loader := NewKubeconfigLoader(kubeconfigPath)
+		path, err := loader.SetCurrentContext("test-context-2")
+		if err != nil {
+			t.Fatalf("SetCurrentContext() error = %v", err)
+		}
+		if path != kubeconfigPath {
+			t.Errorf("modified %q, want %q", path, kubeconfigPath)
+		}
+
+		current, err := loader.GetCurrentContext()
+		if err != nil {
+			t.Fatalf("failed to get current context: %v", err)
+		}
+		if current != "test-context-2" {
+			t.Errorf("got current context %q, want %q", current, "test-context-2")
+		}
+	})
+
+	t.Run("rewrites the file that sets current-context", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		// First file has contexts but no current-context
+		first := createTestKubeconfig()
+		first.CurrentContext = ""
+		firstPath := filepath.Join(tmpDir, "first")
+		writeConfig(t, firstPath, first)
+
+		// Second file owns current-context
+		second := &api.Config{
+			CurrentContext: "test-context-1",
+			Clusters:       map[string]*api.Cluster{"other-cluster": {Server: "https://other:6443"}},
+			Contexts:       map[string]*api.Context{"other-context": {Cluster: "other-cluster"}},
+		}
+		secondPath := filepath.Join(tmpDir, "second")
+		writeConfig(t, secondPath, second)
+
+		t.Setenv("KUBECONFIG", firstPath+string(os.PathListSeparator)+secondPath)
+
+		loader := NewKubeconfigLoader("")
+		path, err := loader.SetCurrentContext("other-context")
+		if err != nil {
+			t.Fatalf("SetCurrentContext() error = %v", err)
+		}
+		if path != secondPath {
+			t.Errorf("modified %q, want %q", path, secondPath)
+		}
+
+		reloaded, err := clientcmd.LoadFromFile(firstPath)
+		if err != nil {
+			t.Fatalf("failed to reload first kubeconfig: %v", err)
+		}
+		if reloaded.CurrentContext != "" {
+			t.Errorf("first kubeconfig should be untouched, got current context %q", reloaded.CurrentContext)
+		}
+
+		current, err := loader.GetCurrentContext()
+		if err != nil {
+			t.Fatalf("failed to get current context: %v", err)
+		}
+		if current != "other-context" {
+			t.Errorf("got current context %q, want %q", current, "other-context")
+		}
+	})
+
+	t.Run("unknown context", func(t *testing.T) {
+		kubeconfigPath := filepath.Join(t.TempDir(), "config")
+		writeConfig(t, kubeconfigPath, createTestKubeconfig())
+
+		loader := NewKubeconfigLoader(kubeconfigPath)
+		if _, err := loader.SetCurrentContext("non-existent"); err == nil {
+			t.Error("expected error for non-existent context, got nil")
+		}
+	})
+}
+
 func TestExpandPath(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -318,7 +475,7 @@ func TestExpandPath(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := expandPath(tt.input)
+			result, err := ExpandPath(tt.input)
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// IsClusterPattern reports whether s is a glob or regex rather than a literal name
+// Regexes are written between slashes, e.g. "/^prod-(east|west)$/".
+func IsClusterPattern(s string) bool {
+	return isRegexPattern(s) || strings.ContainsAny(s, "*?[")
+}
+
+// MatchContexts returns the contexts selected by a --clusters style pattern
+//
+// The pattern may be a literal, a shell-style glob ("prod-*") or a regex
+// between slashes ("/^prod-/"). It is matched against each context name and
+// against the name and alias of the fleet config cluster using that context.
+// Contexts of disabled clusters only match globs and regexes when
+// includeDisabled is set; literals always match.
+//
+// contexts lists the available kubeconfig contexts. Contexts of configured
+// clusters are considered even if missing from it. The result is sorted.
+func (m *Manager) MatchContexts(pattern string, contexts []string, includeDisabled bool) ([]string, error) {
+	match, err := compileClusterPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	isPattern := IsClusterPattern(pattern)
+
+	var matched []string
+	for _, contextName := range m.knownContexts(contexts) {
+		names := []string{contextName}
+		disabled := false
+		for name, cluster := range m.config.Clusters {
+			if cluster.ContextName(name) != contextName {
+				continue
+			}
+			names = append(names, name)
+			if cluster.Alias != "" {
+				names = append(names, cluster.Alias)
+			}
+			disabled = disabled || !cluster.Enabled
+		}
+
+		if isPattern && disabled && !includeDisabled {
+			continue
+		}
+
+		for _, name := range names {
+			if match(name) {
+				matched = append(matched, contextName)
+				break
+			}
+		}
+	}
+
+	return matched, nil
+}
+
+// knownContexts returns the given contexts plus those of configured clusters, sorted
+func (m *Manager) knownContexts(contexts []string) []string {
+	seen := make(map[string]bool, len(contexts)+len(m.config.Clusters))
+	for _, contextName := range contexts {
+		seen[contextName] = true
+	}
+	for name, cluster := range m.config.Clusters {
+		seen[cluster.ContextName(name)] = true
+	}
+
+	all := make([]string, 0, len(seen))
+	for contextName := range seen {
+		all = append(all, contextName)
+	}
+	sort.Strings(all)
+	return all
+}
+
+// isRegexPattern reports whether s is a regex written between slashes
+func isRegexPattern(s string) bool {
+	return len(s) > 2 && strings.HasPrefix(s, "/") && strings.HasSuffix(s, "/")
+}
+
+// compileClusterPattern returns a matcher for a literal, glob or regex pattern
+func compileClusterPattern(pattern string) (func(string) bool, error) {
+	if isRegexPattern(pattern) {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster regex %q: %w", pattern, err)
+		}
+		return re.MatchString, nil
+	}
+
+	if IsClusterPattern(pattern) {
+		re, err := globToRegexp(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster glob %q: %w", pattern, err)
+		}
+		return re.MatchString, nil
+	}
+
+	return func(name string) bool { return name == pattern }, nil
+}
+
+// globToRegexp converts a shell-style glob into an anchored regex
+// Unlike path.Match, "*" also matches "/" so globs work on EKS ARN contexts.
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class")
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsClusterPattern(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "prod-east", want: false},
+		{input: "arn:aws:eks:us-east-1:123456789012:cluster/prod", want: false},
+		{input: "prod-*", want: true},
+		{input: "prod-?", want: true},
+		{input: "prod-[ew]*", want: true},
+		{input: "/^prod/", want: true},
+		{input: "/", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := IsClusterPattern(tt.input); got != tt.want {
+				t.Errorf("IsClusterPattern(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob    string
+		input   string
+		want    bool
+		wantErr bool
+	}{
+		{glob: "prod-*", input: "prod-east", want: true},
+		{glob: "prod-*", input: "staging", want: false},
+		{glob: "*prod", input: "arn:aws:eks:us-east-1:1:cluster/prod", want: true},
+		{glob: "prod-?", input: "prod-1", want: true},
+		{glob: "prod-?", input: "prod-10", want: false},
+		{glob: "prod-[ew]*", input: "prod-west", want: true},
+		{glob: "prod-[!ew]*", input: "prod-west", want: false},
+		{glob: "prod.east", input: "prodXeast", want: false},
+		{glob: "prod-[ew", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob+"/"+tt.input, func(t *testing.T) {
+			re, err := globToRegexp(tt.glob)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("globToRegexp(%q) error = %v, wantErr %v", tt.glob, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := re.MatchString(tt.input); got != tt.want {
+				t.Errorf("%q matching %q = %v, want %v", tt.glob, tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_MatchContexts(t *testing.T) {
+	manager := NewManager("")
+	manager.SetClusterConfig("prod-east", ClusterConfig{
+		Context: "arn:aws:eks:us-east-1:123456789012:cluster/prod-east",
+		Alias:   "production",
+		Enabled: true,
+	})
+	manager.SetClusterConfig("old", ClusterConfig{Context: "old-prod", Enabled: false})
+
+	contexts := []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east", "kind-prod", "old-prod"}
+
+	tests := []struct {
+		name            string
+		pattern         string
+		includeDisabled bool
+		want            []string
+		wantErr         bool
+	}{
+		{
+			name:    "alias",
+			pattern: "production",
+			want:    []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east"},
+		},
+		{
+			name:    "glob over names and contexts",
+			pattern: "*prod*",
+			want:    []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east", "kind-prod"},
+		},
+		{
+			name:            "glob with disabled",
+			pattern:         "*prod*",
+			includeDisabled: true,
+			want:            []string{"arn:aws:eks:us-east-1:123456789012:cluster/prod-east", "kind-prod", "old-prod"},
+		},
+		{
+			name:    "literal disabled",
+			pattern: "old",
+			want:    []string{"old-prod"},
+		},
+		{
+			name:    "regex",
+			pattern: "/^kind-/",
+			want:    []string{"kind-prod"},
+		},
+		{
+			name:    "no match",
+			pattern: "staging",
+			want:    nil,
+		},
+		{
+			name:    "invalid regex",
+			pattern: "/(/",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := manager.MatchContexts(tt.pattern, contexts, tt.includeDisabled)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MatchContexts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchContexts(%q) = %v, want %v", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// APIVersion is the config schema version this build reads and writes
+	APIVersion = "fleet/v1"
+
+	// Kind identifies a fleet config file
+	Kind = "FleetConfig"
+)
+
+// Migrate adds the apiVersion and kind header to a config document that has
+// no apiVersion
+//
+// Files written before apiVersion existed already use the fleet/v1 layout, so
+// only the header changes; comments and key order are kept. It returns the
+// stamped document and true, or the input unchanged and false if it already
+// declares APIVersion or is empty. Other apiVersions and kinds are rejected.
+func Migrate(data []byte) ([]byte, bool, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, false, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, false, nil
+	}
+	root := doc.Content[0]
+
+	if kind := mappingValue(root, "kind"); kind != nil && kind.Value != Kind {
+		return nil, false, fmt.Errorf("config file has kind %q, expected %q", kind.Value, Kind)
+	}
+
+	if node := mappingValue(root, "apiVersion"); node != nil && node.Value != "" {
+		if node.Value != APIVersion {
+			return nil, false, fmt.Errorf("config file has unsupported apiVersion %q (this fleet supports %s); upgrade fleet to read it",
+				node.Value, APIVersion)
+		}
+		return data, false, nil
+	}
+
+	setHeader(root, "apiVersion", APIVersion)
+	setHeader(root, "kind", Kind)
+
+	stamped, err := encodeDocument(&doc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return stamped, true, nil
+}
+
+// setHeader sets a top-level key, adding it at the start of the document if
+// missing. apiVersion is kept ahead of kind, as in Kubernetes manifests.
+func setHeader(root *yaml.Node, key, value string) {
+	if node := mappingValue(root, key); node != nil {
+		node.Kind, node.Tag, node.Style, node.Value = yaml.ScalarNode, "!!str", 0, value
+		return
+	}
+
+	pair := []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+	}
+
+	at := 0
+	if key != "apiVersion" && mappingValue(root, "apiVersion") != nil {
+		at = 2
+	}
+
+	root.Content = slices.Insert(root.Content, at, pair...)
+}
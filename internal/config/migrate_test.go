@@ -0,0 +1,168 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const legacyConfig = `# Team fleet config
+
+# Production clusters first
+clusters:
+  prod-east:
+    alias: east # primary region
+    enabled: true
+defaults:
+  timeout: 45s
+`
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		wantChanged bool
+		wantErr     string
+	}{
+		{
+			name:        "unversioned file",
+			config:      legacyConfig,
+			wantChanged: true,
+		},
+		{
+			name:        "empty apiVersion",
+			config:      "apiVersion: \"\"\nclusters: {}\n",
+			wantChanged: true,
+		},
+		{
+			name:   "current version",
+			config: "apiVersion: fleet/v1\nkind: FleetConfig\n",
+		},
+		{
+			name:   "empty file",
+			config: "",
+		},
+		{
+			name:    "newer version",
+			config:  "apiVersion: fleet/v2\nkind: FleetConfig\n",
+			wantErr: `unsupported apiVersion "fleet/v2"`,
+		},
+		{
+			name:    "unknown version",
+			config:  "apiVersion: fleet/v1alpha1\nclusters: {}\n",
+			wantErr: `unsupported apiVersion "fleet/v1alpha1"`,
+		},
+		{
+			name:    "other kind",
+			config:  "apiVersion: v1\nkind: Config\n",
+			wantErr: `kind "Config"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, changed, err := Migrate([]byte(tt.config))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Migrate() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Migrate() error = %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("Migrate() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !changed && string(out) != tt.config {
+				t.Errorf("Migrate() changed a current file:\n%s", out)
+			}
+			if changed && !strings.Contains(string(out), "apiVersion: fleet/v1\nkind: FleetConfig\n") {
+				t.Errorf("Migrate() output is missing the header:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestMigrate_PreservesComments(t *testing.T) {
+	out, _, err := Migrate([]byte(legacyConfig))
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	// The file comment stays above the new header
+	if !strings.HasPrefix(string(out), "# Team fleet config\n\napiVersion: fleet/v1\n") {
+		t.Errorf("migrated config does not keep the file comment first:\n%s", out)
+	}
+
+	for _, want := range []string{
+		"# Production clusters first\nclusters:",
+		"alias: east # primary region",
+		"timeout: 45s",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("migrated config missing %q:\n%s", want, out)
+		}
+	}
+
+	// Migrating again is a no-op
+	if _, changed, err := Migrate(out); err != nil || changed {
+		t.Errorf("second Migrate() changed = %v, err = %v, want no change", changed, err)
+	}
+}
+
+func TestManager_LoadLegacyConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(legacyConfig), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	m := NewManager(configPath)
+	config, err := m.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if !m.Unversioned() {
+		t.Error("Unversioned() = false, want true")
+	}
+	if config.APIVersion != APIVersion || config.Kind != Kind {
+		t.Errorf("config header = %s/%s, want %s/%s", config.APIVersion, config.Kind, APIVersion, Kind)
+	}
+	if got := config.Clusters["prod-east"].Alias; got != "east" {
+		t.Errorf("Alias = %q, want east", got)
+	}
+
+	// Loading does not touch the file
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	if string(data) != legacyConfig {
+		t.Error("Load() rewrote the config file")
+	}
+
+	// Saving writes the current schema
+	if err := m.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	reloaded := NewManager(configPath)
+	if _, err := reloaded.Load(); err != nil {
+		t.Fatalf("Load() after Save() error = %v", err)
+	}
+	if reloaded.Unversioned() {
+		t.Error("Unversioned() after Save() = true, want the header written")
+	}
+}
+
+func TestManager_LoadUnsupportedVersion(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("apiVersion: fleet/v9\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	if _, err := NewManager(configPath).Load(); err == nil {
+		t.Error("Load() expected error for an unsupported apiVersion")
+	}
+}
@@ -1,17 +1,71 @@
 package config
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // FleetConfig represents the fleet configuration file structure
 type FleetConfig struct {
+	// APIVersion is the schema version of the file, see APIVersion
+	APIVersion string `yaml:"apiVersion,omitempty" json:"apiVersion,omitempty"`
+
+	// Kind is always FleetConfig
+	Kind string `yaml:"kind,omitempty" json:"kind,omitempty"`
+
 	// DefaultContext is the default kubeconfig context to use
 	DefaultContext string `yaml:"defaultContext,omitempty" json:"defaultContext,omitempty"`
 
 	// Clusters is a map of cluster aliases to their configurations
 	Clusters map[string]ClusterConfig `yaml:"clusters,omitempty" json:"clusters,omitempty"`
 
+	// Groups maps a group name to its members: cluster names, other group
+	// names, or label selectors prefixed with "selector:"
+	Groups map[string][]string `yaml:"groups,omitempty" json:"groups,omitempty"`
+
 	// Defaults contains default settings for operations
 	Defaults DefaultsConfig `yaml:"defaults,omitempty" json:"defaults,omitempty"`
+
+	// Inventory lists extra places to discover clusters beyond the kubeconfig
+	Inventory InventoryConfig `yaml:"inventory,omitempty" json:"inventory,omitempty"`
+
+	// CircuitBreaker configures skipping of clusters that keep failing
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuitBreaker,omitempty" json:"circuitBreaker,omitempty"`
+}
+
+// CircuitBreakerConfig configures the per-cluster circuit breaker
+type CircuitBreakerConfig struct {
+	// Disabled turns the circuit breaker off
+	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+
+	// Threshold is the number of consecutive failures that opens a circuit
+	Threshold int `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+
+	// Cooldown is how long an open circuit skips its cluster before a retry
+	Cooldown time.Duration `yaml:"cooldown,omitempty" json:"cooldown,omitempty"`
+}
+
+// InventoryConfig configures cluster discovery sources
+type InventoryConfig struct {
+	// Directories are kubeconfig.d style directories; every file in them is loaded
+	Directories []string `yaml:"directories,omitempty" json:"directories,omitempty"`
+
+	// Hub reads kubeconfig Secrets from a management cluster
+	Hub *HubConfig `yaml:"hub,omitempty" json:"hub,omitempty"`
+}
+
+// HubConfig identifies the hub cluster whose Secrets describe other clusters
+type HubConfig struct {
+	// Context is the kubeconfig context of the hub cluster
+	Context string `yaml:"context" json:"context"`
+
+	// Namespace limits the search for Secrets; empty means all namespaces
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// AllowedExecCommands lists the credential plugin commands, such as
+	// "aws", that Secrets may have fleet run locally; Secrets whose
+	// credentials run any other command are skipped
+	AllowedExecCommands []string `yaml:"allowedExecCommands,omitempty" json:"allowedExecCommands,omitempty"`
 }
 
 // ClusterConfig represents configuration for a single cluster
@@ -27,6 +81,91 @@ type ClusterConfig struct {
 
 	// Enabled indicates if this cluster should be included in operations
 	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// Kubeconfig is a dedicated kubeconfig file for this cluster
+	Kubeconfig string `yaml:"kubeconfig,omitempty" json:"kubeconfig,omitempty"`
+
+	// Namespace is the default namespace used when -n is not given
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// Timeout overrides defaults.timeout for operations on this cluster
+	Timeout time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	// QPS and Burst override the client-side rate limits
+	QPS   float32 `yaml:"qps,omitempty" json:"qps,omitempty"`
+	Burst int     `yaml:"burst,omitempty" json:"burst,omitempty"`
+
+	// Impersonate makes every request act as another user and groups
+	Impersonate *ImpersonateConfig `yaml:"impersonate,omitempty" json:"impersonate,omitempty"`
+
+	// ProxyURL routes API requests through a proxy (http, https or socks5)
+	ProxyURL string `yaml:"proxyURL,omitempty" json:"proxyURL,omitempty"`
+}
+
+// ImpersonateConfig identifies the user and groups to impersonate
+type ImpersonateConfig struct {
+	// User is the username to act as
+	User string `yaml:"user" json:"user"`
+
+	// Groups are the groups to act as
+	Groups []string `yaml:"groups,omitempty" json:"groups,omitempty"`
+}
+
+// ContextName returns the kubeconfig context for a cluster configured under name
+// The name itself is used when no context is set
+func (c ClusterConfig) ContextName(name string) string {
+	if c.Context != "" {
+		return c.Context
+	}
+	return name
+}
+
+// ClusterForContext returns the configured cluster that uses the given kubeconfig context
+func (c *FleetConfig) ClusterForContext(contextName string) (*ClusterConfig, bool) {
+	for name, cluster := range c.Clusters {
+		if cluster.ContextName(name) == contextName {
+			return &cluster, true
+		}
+	}
+	return nil, false
+}
+
+// DedicatedKubeconfigContexts returns the contexts of clusters with their own kubeconfig file, sorted
+func (c *FleetConfig) DedicatedKubeconfigContexts() []string {
+	var contexts []string
+	for name, cluster := range c.Clusters {
+		if cluster.Kubeconfig != "" {
+			contexts = append(contexts, cluster.ContextName(name))
+		}
+	}
+	sort.Strings(contexts)
+	return contexts
+}
+
+// DisabledContexts returns the kubeconfig contexts of clusters marked enabled: false
+func (c *FleetConfig) DisabledContexts() map[string]bool {
+	disabled := make(map[string]bool)
+	for name, cluster := range c.Clusters {
+		if !cluster.Enabled {
+			disabled[cluster.ContextName(name)] = true
+		}
+	}
+	return disabled
+}
+
+// EnabledContexts splits contexts into those not marked enabled: false in the
+// fleet config and those that are, keeping their order
+func (c *FleetConfig) EnabledContexts(contexts []string) (enabled, disabled []string) {
+	off := c.DisabledContexts()
+	enabled = make([]string, 0, len(contexts))
+	for _, contextName := range contexts {
+		if off[contextName] {
+			disabled = append(disabled, contextName)
+			continue
+		}
+		enabled = append(enabled, contextName)
+	}
+	return enabled, disabled
 }
 
 // DefaultsConfig contains default configuration values
@@ -37,11 +176,33 @@ type DefaultsConfig struct {
 	// Parallel is the number of concurrent operations
 	Parallel int `yaml:"parallel,omitempty" json:"parallel,omitempty"`
 
-	// OutputFormat is the default output format (table, json, yaml)
+	// OutputFormat is the default output format (table, json, yaml, ndjson)
 	OutputFormat string `yaml:"outputFormat,omitempty" json:"outputFormat,omitempty"`
 
 	// NoColor disables colored output
 	NoColor bool `yaml:"noColor,omitempty" json:"noColor,omitempty"`
+
+	// QPS and Burst are the client-side rate limits for each cluster; clusters
+	// can override them
+	QPS   float32 `yaml:"qps,omitempty" json:"qps,omitempty"`
+	Burst int     `yaml:"burst,omitempty" json:"burst,omitempty"`
+
+	// AdaptiveQPS lowers a cluster's QPS when its API server rejects requests
+	// with 429 Too Many Requests and raises it again as requests succeed
+	AdaptiveQPS bool `yaml:"adaptiveQPS,omitempty" json:"adaptiveQPS,omitempty"`
+
+	// Retries is how many times a cluster operation that failed with a
+	// transient error (timeout, 429, 5xx, connection reset) is retried
+	Retries int `yaml:"retries,omitempty" json:"retries,omitempty"`
+
+	// Deadline bounds a whole command run, on top of each cluster's own
+	// timeout; zero means no overall deadline
+	Deadline time.Duration `yaml:"deadline,omitempty" json:"deadline,omitempty"`
+
+	// AdaptiveParallel treats Parallel as a ceiling and moves the number of
+	// concurrent operations up while cluster latencies stay flat and down when
+	// latencies or errors climb
+	AdaptiveParallel bool `yaml:"adaptiveParallel,omitempty" json:"adaptiveParallel,omitempty"`
 }
 
 // ClusterInfo represents information about a cluster from kubeconfig
@@ -69,4 +230,7 @@ type ClusterInfo struct {
 
 	// Labels from fleet config
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Groups from fleet config that include this cluster
+	Groups []string `json:"groups,omitempty"`
 }
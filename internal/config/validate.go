@@ -0,0 +1,342 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Issue is a problem found in a fleet config file
+type Issue struct {
+	// Line is the 1-based line in the file, 0 if unknown
+	Line int `json:"line"`
+
+	// Key is the dotted config key the problem relates to
+	Key string `json:"key"`
+
+	// Message describes the problem
+	Message string `json:"message"`
+}
+
+// String formats the issue as "line N: key: message"
+func (i Issue) String() string {
+	return fmt.Sprintf("line %d: %s: %s", i.Line, i.Key, i.Message)
+}
+
+// Validate checks the contents of a fleet config file
+//
+// It reports an unsupported apiVersion or kind, unknown keys, values of the
+// wrong type, bad durations, duplicate aliases, group members that refer to
+// nothing, cluster and hub contexts missing from the kubeconfig, and missing
+// inventory directories. contexts lists the available kubeconfig contexts;
+// nil skips the context check. Clusters with their own kubeconfig file are
+// checked against that file. An error is returned only if data is not valid
+// YAML.
+func Validate(data []byte, contexts []string) ([]Issue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	v := &validator{}
+	root := doc.Content[0]
+	v.checkHeader(root)
+	v.checkNode(root, reflect.TypeOf(FleetConfig{}), nil)
+
+	clusters := v.checkClusters(mappingValue(root, "clusters"), contexts)
+	v.checkGroups(mappingValue(root, "groups"), clusters, contexts)
+	v.checkInventory(mappingValue(root, "inventory"), contexts)
+
+	return v.issues, nil
+}
+
+// validator collects issues while walking a config document
+type validator struct {
+	issues []Issue
+}
+
+func (v *validator) add(node *yaml.Node, path []string, format string, args ...interface{}) {
+	v.issues = append(v.issues, Issue{
+		Line:    node.Line,
+		Key:     strings.Join(path, "."),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// checkNode checks that node matches type t, recursing into mappings and sequences
+func (v *validator) checkNode(node *yaml.Node, t reflect.Type, path []string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// Null values leave the field unset
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	switch {
+	case t == durationType:
+		if node.Kind != yaml.ScalarNode {
+			v.add(node, path, "expected a duration")
+			return
+		}
+		if err := checkScalar(t, path, node.Value); err != nil {
+			v.add(node, path, "%v", err)
+		}
+
+	case t.Kind() == reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			v.add(node, path, "expected a mapping")
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			fieldPath := append(slices.Clone(path), key.Value)
+			field, ok := structField(t, key.Value)
+			if !ok {
+				v.add(key, fieldPath, "unknown key")
+				continue
+			}
+			v.checkNode(value, field.Type, fieldPath)
+		}
+
+	case t.Kind() == reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			v.add(node, path, "expected a mapping")
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			v.checkNode(value, t.Elem(), append(slices.Clone(path), key.Value))
+		}
+
+	case t.Kind() == reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			v.add(node, path, "expected a list")
+			return
+		}
+		for _, item := range node.Content {
+			v.checkNode(item, t.Elem(), path)
+		}
+
+	default:
+		if node.Kind != yaml.ScalarNode {
+			v.add(node, path, "expected a single value")
+			return
+		}
+		if err := checkScalar(t, path, node.Value); err != nil {
+			v.add(node, path, "%v", err)
+		}
+	}
+}
+
+// checkHeader reports an unsupported apiVersion or a kind other than FleetConfig
+func (v *validator) checkHeader(root *yaml.Node) {
+	if node := mappingValue(root, "apiVersion"); node != nil && node.Value != APIVersion {
+		v.add(node, []string{"apiVersion"}, "unsupported apiVersion %q (supported: %s)", node.Value, APIVersion)
+	}
+	if node := mappingValue(root, "kind"); node != nil && node.Value != Kind {
+		v.add(node, []string{"kind"}, "kind must be %q", Kind)
+	}
+}
+
+// validatedCluster is what later checks need to know about a configured cluster
+type validatedCluster struct {
+	name    string
+	context string
+}
+
+// checkClusters reports duplicate aliases and contexts missing from the kubeconfig
+func (v *validator) checkClusters(node *yaml.Node, contexts []string) []validatedCluster {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var (
+		clusters []validatedCluster
+		aliases  = make(map[string]string)
+	)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		name := key.Value
+		path := []string{"clusters", name}
+
+		contextName := name
+		if contextNode := mappingValue(value, "context"); contextNode != nil && contextNode.Value != "" {
+			contextName = contextNode.Value
+		}
+		clusters = append(clusters, validatedCluster{name: name, context: contextName})
+
+		if aliasNode := mappingValue(value, "alias"); aliasNode != nil && aliasNode.Value != "" {
+			alias := aliasNode.Value
+			if other, ok := aliases[alias]; ok {
+				v.add(aliasNode, append(path, "alias"), "alias %q is already used by cluster %q", alias, other)
+			} else {
+				aliases[alias] = name
+			}
+		}
+
+		available := contexts
+		if kubeconfigNode := mappingValue(value, "kubeconfig"); kubeconfigNode != nil && kubeconfigNode.Value != "" {
+			loader := NewKubeconfigLoader(kubeconfigNode.Value)
+			dedicated, err := loader.GetContexts()
+			if err == nil && len(loader.GetPaths()) > 0 {
+				_, err = os.Stat(loader.GetPaths()[0])
+			}
+			if err != nil {
+				v.add(kubeconfigNode, append(path, "kubeconfig"), "cannot read kubeconfig: %v", err)
+				continue
+			}
+			available = dedicated
+		}
+
+		if available != nil && !slices.Contains(available, contextName) {
+			v.add(key, path, "context %q not found in kubeconfig", contextName)
+		}
+	}
+
+	return clusters
+}
+
+// checkGroups reports invalid selectors and members that are not a cluster, group or context
+func (v *validator) checkGroups(node *yaml.Node, clusters []validatedCluster, contexts []string) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+
+	known := make(map[string]bool)
+	for _, cluster := range clusters {
+		known[cluster.name] = true
+		known[cluster.context] = true
+	}
+	for _, contextName := range contexts {
+		known[contextName] = true
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		known[node.Content[i].Value] = true
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if value.Kind != yaml.SequenceNode {
+			continue
+		}
+		path := []string{"groups", key.Value}
+
+		for _, member := range value.Content {
+			if selector, ok := strings.CutPrefix(member.Value, GroupSelectorPrefix); ok {
+				if _, err := labels.Parse(selector); err != nil {
+					v.add(member, path, "invalid label selector %q: %v", selector, err)
+				}
+				continue
+			}
+			// Without the kubeconfig a member may be a context we cannot see
+			if contexts != nil && !known[member.Value] {
+				v.add(member, path, "member %q is not a cluster, group or kubeconfig context", member.Value)
+			}
+		}
+	}
+}
+
+// checkInventory reports missing kubeconfig directories and an unknown hub context
+func (v *validator) checkInventory(node *yaml.Node, contexts []string) {
+	if dirs := mappingValue(node, "directories"); dirs != nil && dirs.Kind == yaml.SequenceNode {
+		for _, dir := range dirs.Content {
+			path, err := ExpandPath(dir.Value)
+			if err == nil {
+				_, err = os.Stat(path)
+			}
+			if err != nil {
+				v.add(dir, []string{"inventory", "directories"}, "cannot read directory %q: %v", dir.Value, err)
+			}
+		}
+	}
+
+	hub := mappingValue(node, "hub")
+	if hubContext := mappingValue(hub, "context"); hubContext != nil && contexts != nil && !slices.Contains(contexts, hubContext.Value) {
+		v.add(hubContext, []string{"inventory", "hub", "context"}, "context %q not found in kubeconfig", hubContext.Value)
+	}
+}
+
+// checkScalar validates a single value for a field of type t at path
+func checkScalar(t reflect.Type, path []string, value string) error {
+	if t == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q (use a unit, e.g. 30s or 5m)", value)
+		}
+		if d < 0 {
+			return fmt.Errorf("duration must not be negative")
+		}
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		if n < 0 || (n == 0 && lastSegmentIs(path, "parallel")) {
+			return fmt.Errorf("must be a positive number")
+		}
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		if f < 0 {
+			return fmt.Errorf("must not be negative")
+		}
+	case reflect.String:
+		if lastSegmentIs(path, "outputFormat") && value != "" {
+			if !slices.Contains([]string{"table", "json", "yaml", "ndjson"}, value) {
+				return fmt.Errorf("unsupported output format %q (supported: table, json, yaml, ndjson)", value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// lastSegmentIs reports whether the final key segment equals name, case-insensitively
+func lastSegmentIs(path []string, name string) bool {
+	return len(path) > 0 && strings.EqualFold(path[len(path)-1], name)
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if strings.EqualFold(node.Content[i].Value, key) {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// ValidateFile reads and validates the config file at path
+func ValidateFile(path string, contexts []string) ([]Issue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return Validate(data, contexts)
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	contexts := []string{"prod-east-ctx", "prod-west", "staging"}
+
+	tests := []struct {
+		name     string
+		config   string
+		contexts []string
+		want     []Issue
+	}{
+		{
+			name: "valid config",
+			config: `apiVersion: fleet/v1
+kind: FleetConfig
+defaults:
+  timeout: 30s
+  parallel: 5
+  outputFormat: table
+clusters:
+  prod-east:
+    context: prod-east-ctx
+    alias: east
+    enabled: true
+  prod-west:
+    enabled: true
+groups:
+  prod:
+    - prod-east
+    - selector:env=prod
+  everything:
+    - prod
+    - staging
+`,
+			contexts: contexts,
+		},
+		{
+			name: "unknown key and bad duration",
+			config: `defaults:
+  timeout: 30
+  paralel: 5
+`,
+			contexts: contexts,
+			want: []Issue{
+				{Line: 2, Key: "defaults.timeout", Message: `invalid duration "30" (use a unit, e.g. 30s or 5m)`},
+				{Line: 3, Key: "defaults.paralel", Message: "unknown key"},
+			},
+		},
+		{
+			name: "wrong types",
+			config: `defaults:
+  parallel: many
+  noColor: maybe
+  outputFormat: xml
+clusters:
+  staging:
+    labels: [a, b]
+`,
+			contexts: contexts,
+			want: []Issue{
+				{Line: 2, Key: "defaults.parallel", Message: `invalid integer "many"`},
+				{Line: 3, Key: "defaults.noColor", Message: `invalid boolean "maybe"`},
+				{Line: 4, Key: "defaults.outputFormat", Message: `unsupported output format "xml" (supported: table, json, yaml, ndjson)`},
+				{Line: 7, Key: "clusters.staging.labels", Message: "expected a mapping"},
+			},
+		},
+		{
+			name: "duplicate alias and missing context",
+			config: `clusters:
+  prod-east:
+    context: prod-east-ctx
+    alias: prod
+  prod-west:
+    alias: prod
+  gone:
+    enabled: true
+`,
+			contexts: contexts,
+			want: []Issue{
+				{Line: 6, Key: "clusters.prod-west.alias", Message: `alias "prod" is already used by cluster "prod-east"`},
+				{Line: 7, Key: "clusters.gone", Message: `context "gone" not found in kubeconfig`},
+			},
+		},
+		{
+			name: "bad header",
+			config: `apiVersion: fleet/v9
+kind: Config
+`,
+			contexts: contexts,
+			want: []Issue{
+				{Line: 1, Key: "apiVersion", Message: `unsupported apiVersion "fleet/v9" (supported: fleet/v1)`},
+				{Line: 2, Key: "kind", Message: `kind must be "FleetConfig"`},
+			},
+		},
+		{
+			name: "missing context not checked without kubeconfig",
+			config: `clusters:
+  gone:
+    enabled: true
+groups:
+  all:
+    - elsewhere
+`,
+		},
+		{
+			name: "inventory",
+			config: `inventory:
+  directories:
+    - /nonexistent/kubeconfig.d
+  hub:
+    context: missing-hub
+`,
+			contexts: contexts,
+			want: []Issue{
+				{Line: 3, Key: "inventory.directories", Message: `cannot read directory "/nonexistent/kubeconfig.d": stat /nonexistent/kubeconfig.d: no such file or directory`},
+				{Line: 5, Key: "inventory.hub.context", Message: `context "missing-hub" not found in kubeconfig`},
+			},
+		},
+		{
+			name: "bad group members",
+			config: `clusters:
+  staging:
+    enabled: true
+groups:
+  broken:
+    - staging
+    - nowhere
+    - selector:env in (prod
+`,
+			contexts: contexts,
+			want: []Issue{
+				{Line: 7, Key: "groups.broken", Message: `member "nowhere" is not a cluster, group or kubeconfig context`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := Validate([]byte(tt.config), tt.contexts)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			// The selector parse error text comes from apimachinery, so
+			// only compare the issues we fully control
+			var got []Issue
+			for _, issue := range issues {
+				if strings.HasPrefix(issue.Message, "invalid label selector") {
+					if issue.Line != 8 || issue.Key != "groups.broken" {
+						t.Errorf("unexpected selector issue: %v", issue)
+					}
+					continue
+				}
+				got = append(got, issue)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Validate() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("issue %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidate_InvalidYAML(t *testing.T) {
+	if _, err := Validate([]byte("clusters: [\n"), nil); err == nil {
+		t.Error("Validate() expected error for invalid YAML")
+	}
+}
+
+func TestValidate_DedicatedKubeconfig(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfigPath := filepath.Join(dir, "airgap.yaml")
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: airgap
+  cluster:
+    server: https://airgap.example.com
+contexts:
+- name: airgap
+  context:
+    cluster: airgap
+    user: airgap
+users:
+- name: airgap
+  user:
+    token: test
+current-context: airgap
+`
+	if err := os.WriteFile(kubeconfigPath, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	config := `clusters:
+  airgap:
+    kubeconfig: ` + kubeconfigPath + `
+  missing:
+    kubeconfig: ` + filepath.Join(dir, "missing.yaml") + `
+`
+
+	issues, err := Validate([]byte(config), []string{"prod"})
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if len(issues) != 1 {
+		t.Fatalf("Validate() = %v, want one issue", issues)
+	}
+	if issues[0].Key != "clusters.missing.kubeconfig" || issues[0].Line != 5 {
+		t.Errorf("issue = %v, want clusters.missing.kubeconfig on line 5", issues[0])
+	}
+}
@@ -0,0 +1,264 @@
+package executor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Defaults for AdaptiveOptions
+const (
+	defaultLatencyTolerance = 2.0
+	defaultMaxErrorRate     = 0.25
+
+	// baselineWeight is how much each task moves its cluster's baseline latency
+	baselineWeight = 0.2
+)
+
+// AdaptiveOptions configures adaptive concurrency
+// The controller follows AIMD: after every window of finished tasks, as many
+// as the current concurrency, it raises the concurrency by one if latencies
+// stayed flat and halves it if they climbed or too many tasks failed.
+// Latency is judged against each cluster's own baseline, so a large cluster
+// that is always slow doesn't hold back the small ones.
+type AdaptiveOptions struct {
+	// Min is the lowest concurrency a cut goes to; zero means 1
+	Min int
+
+	// Max is the highest concurrency a raise goes to; zero or more than the
+	// pool's worker count means the worker count
+	Max int
+
+	// Initial is the concurrency to start at; zero means halfway between Min
+	// and Max
+	Initial int
+
+	// LatencyTolerance is how many times its cluster's baseline latency a
+	// task may take before it counts as slow; zero means 2
+	LatencyTolerance float64
+
+	// MaxErrorRate is the fraction of a window's tasks that may fail before
+	// the concurrency is cut; zero means 0.25
+	MaxErrorRate float64
+}
+
+// ConcurrencyDecision is a change the adaptive controller made
+type ConcurrencyDecision struct {
+	// Completed is how many tasks of the run had finished
+	Completed int
+
+	// From and To are the concurrency before and after
+	From int
+	To   int
+
+	// Reason says what the controller saw
+	Reason string
+}
+
+// String describes the decision, such as "5 -> 6 after 5 tasks: latency flat"
+func (d ConcurrencyDecision) String() string {
+	return fmt.Sprintf("%d -> %d after %d tasks: %s", d.From, d.To, d.Completed, d.Reason)
+}
+
+// ConcurrencyReport tells how the adaptive controller sized a run
+type ConcurrencyReport struct {
+	// Min and Max are the controller's bounds
+	Min int
+	Max int
+
+	// Initial and Final are the concurrency when the run started and ended
+	Initial int
+	Final   int
+
+	// Decisions lists every change in order
+	Decisions []ConcurrencyDecision
+}
+
+// String summarizes the report, such as "adaptive 5 -> 7 (min 1, max 10;
+// raised 3 times, cut once)"
+func (r ConcurrencyReport) String() string {
+	raised, cut := 0, 0
+	for _, d := range r.Decisions {
+		if d.To > d.From {
+			raised++
+		} else {
+			cut++
+		}
+	}
+
+	return fmt.Sprintf("adaptive %d -> %d (min %d, max %d; raised %s, cut %s)",
+		r.Initial, r.Final, r.Min, r.Max, times(raised), times(cut))
+}
+
+// times formats a count of occurrences
+func times(n int) string {
+	switch n {
+	case 0:
+		return "never"
+	case 1:
+		return "once"
+	default:
+		return fmt.Sprintf("%d times", n)
+	}
+}
+
+// adaptiveController sizes a pool's concurrency from the results of its tasks
+type adaptiveController struct {
+	mu   sync.Mutex
+	opts AdaptiveOptions
+
+	// limit is the current concurrency
+	limit int
+
+	// baselines holds each cluster's moving average latency; it is kept
+	// across runs so that a reused pool knows its clusters
+	baselines map[string]time.Duration
+
+	// window counts the tasks observed since the last decision
+	window, slow, failed int
+
+	// worst is the window's slowest task relative to its baseline
+	worst        float64
+	worstCluster string
+
+	// cutAt is when the concurrency was last cut; tasks that started before
+	// it ran under the old concurrency and are left out of the next window
+	cutAt time.Time
+
+	// completed, initial and decisions describe the current run
+	completed int
+	initial   int
+	decisions []ConcurrencyDecision
+}
+
+// newAdaptiveController fills in the defaults of opts for a pool of workers
+func newAdaptiveController(opts AdaptiveOptions, workers int) *adaptiveController {
+	if opts.Max <= 0 || opts.Max > workers {
+		opts.Max = workers
+	}
+	opts.Min = min(max(opts.Min, 1), opts.Max)
+	if opts.Initial <= 0 {
+		opts.Initial = (opts.Min + opts.Max + 1) / 2
+	}
+	opts.Initial = min(max(opts.Initial, opts.Min), opts.Max)
+	if opts.LatencyTolerance <= 0 {
+		opts.LatencyTolerance = defaultLatencyTolerance
+	}
+	if opts.MaxErrorRate <= 0 {
+		opts.MaxErrorRate = defaultMaxErrorRate
+	}
+
+	return &adaptiveController{
+		opts:      opts,
+		limit:     opts.Initial,
+		baselines: make(map[string]time.Duration),
+		initial:   opts.Initial,
+	}
+}
+
+// current returns the concurrency tasks should run at
+func (c *adaptiveController) current() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.limit
+}
+
+// beginRun starts a new report; the concurrency and baselines carry over
+// from the previous run
+func (c *adaptiveController) beginRun() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.completed = 0
+	c.initial = c.limit
+	c.decisions = nil
+}
+
+// observe records a task of clusterName that finished at finished and
+// returns the decision it led to, if any
+func (c *adaptiveController) observe(clusterName string, status Status, attempts int, duration time.Duration, finished time.Time) (ConcurrencyDecision, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.completed++
+
+	// Tasks that never ran or were cancelled say nothing about the clusters
+	if attempts == 0 || status == StatusCancelled || status == StatusNotStarted || status == StatusSkipped {
+		return ConcurrencyDecision{}, false
+	}
+
+	slow, ratio := false, 0.0
+	if status == StatusSucceeded {
+		baseline, ok := c.baselines[clusterName]
+		if ok && baseline > 0 {
+			ratio = float64(duration) / float64(baseline)
+			slow = ratio > c.opts.LatencyTolerance
+			baseline += time.Duration(baselineWeight * float64(duration-baseline))
+		} else {
+			baseline = duration
+		}
+		c.baselines[clusterName] = baseline
+	}
+
+	// A task that started before the last cut ran under the old concurrency
+	if finished.Add(-duration).Before(c.cutAt) {
+		return ConcurrencyDecision{}, false
+	}
+
+	if status != StatusSucceeded {
+		c.failed++
+	}
+	if slow {
+		c.slow++
+		if ratio > c.worst {
+			c.worst, c.worstCluster = ratio, clusterName
+		}
+	}
+
+	c.window++
+	if c.window < c.limit {
+		return ConcurrencyDecision{}, false
+	}
+
+	decision := ConcurrencyDecision{Completed: c.completed, From: c.limit, To: c.limit}
+	switch errorRate := float64(c.failed) / float64(c.window); {
+	case errorRate > c.opts.MaxErrorRate:
+		decision.To = max(c.limit/2, c.opts.Min)
+		decision.Reason = fmt.Sprintf("%d of %d tasks failed", c.failed, c.window)
+	case c.slow*2 > c.window:
+		decision.To = max(c.limit/2, c.opts.Min)
+		decision.Reason = fmt.Sprintf("%d of %d tasks slow, %s at %.1fx its baseline latency",
+			c.slow, c.window, c.worstCluster, c.worst)
+	default:
+		decision.To = min(c.limit+1, c.opts.Max)
+		decision.Reason = "latency flat"
+	}
+
+	c.window, c.slow, c.failed = 0, 0, 0
+	c.worst, c.worstCluster = 0, ""
+	if decision.To == decision.From {
+		return ConcurrencyDecision{}, false
+	}
+
+	if decision.To < decision.From {
+		c.cutAt = finished
+	}
+	c.limit = decision.To
+	c.decisions = append(c.decisions, decision)
+
+	return decision, true
+}
+
+// report describes the current run
+func (c *adaptiveController) report() ConcurrencyReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return ConcurrencyReport{
+		Min:       c.opts.Min,
+		Max:       c.opts.Max,
+		Initial:   c.initial,
+		Final:     c.limit,
+		Decisions: append([]ConcurrencyDecision(nil), c.decisions...),
+	}
+}
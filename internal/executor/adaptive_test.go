@@ -0,0 +1,169 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/cluster"
+)
+
+func TestNewAdaptiveController(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    AdaptiveOptions
+		workers int
+		want    AdaptiveOptions
+	}{
+		{
+			name:    "defaults",
+			workers: 10,
+			want:    AdaptiveOptions{Min: 1, Max: 10, Initial: 6, LatencyTolerance: 2, MaxErrorRate: 0.25},
+		},
+		{
+			name:    "max above the workers",
+			opts:    AdaptiveOptions{Min: 2, Max: 50, Initial: 40},
+			workers: 8,
+			want:    AdaptiveOptions{Min: 2, Max: 8, Initial: 8, LatencyTolerance: 2, MaxErrorRate: 0.25},
+		},
+		{
+			name:    "min above max",
+			opts:    AdaptiveOptions{Min: 20, Max: 4},
+			workers: 8,
+			want:    AdaptiveOptions{Min: 4, Max: 4, Initial: 4, LatencyTolerance: 2, MaxErrorRate: 0.25},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newAdaptiveController(tt.opts, tt.workers)
+			if c.opts != tt.want {
+				t.Errorf("options = %+v, want %+v", c.opts, tt.want)
+			}
+			if c.current() != tt.want.Initial {
+				t.Errorf("current() = %d, want %d", c.current(), tt.want.Initial)
+			}
+		})
+	}
+}
+
+func TestAdaptiveController(t *testing.T) {
+	now := time.Now()
+	c := newAdaptiveController(AdaptiveOptions{Min: 1, Max: 4, Initial: 2}, 4)
+
+	// observeWindow feeds a full window of tasks and returns the concurrency after it
+	observeWindow := func(cluster string, status Status, duration time.Duration) int {
+		for range c.current() {
+			now = now.Add(time.Second)
+			c.observe(cluster, status, 1, duration, now)
+		}
+		return c.current()
+	}
+
+	// Flat latencies raise the concurrency by one per window, up to Max
+	if got := observeWindow("small", StatusSucceeded, 10*time.Millisecond); got != 3 {
+		t.Fatalf("concurrency after a flat window = %d, want 3", got)
+	}
+	observeWindow("small", StatusSucceeded, 10*time.Millisecond)
+	if got := observeWindow("small", StatusSucceeded, 10*time.Millisecond); got != 4 {
+		t.Fatalf("concurrency = %d, want it held at Max", got)
+	}
+
+	// A slow cluster is judged against its own baseline
+	if got := observeWindow("huge", StatusSucceeded, 5*time.Second); got != 4 {
+		t.Fatalf("concurrency after a consistently slow cluster = %d, want 4", got)
+	}
+
+	// Latency climbing past the tolerance halves the concurrency
+	if got := observeWindow("small", StatusSucceeded, 100*time.Millisecond); got != 2 {
+		t.Fatalf("concurrency after slow tasks = %d, want 2", got)
+	}
+
+	// Tasks that started before the cut are not counted
+	c.observe("small", StatusFailed, 1, time.Hour, now.Add(time.Second))
+	if c.window != 0 || c.failed != 0 {
+		t.Errorf("window = %d, failed = %d; want a task started before the cut ignored", c.window, c.failed)
+	}
+
+	// Too many failures halve it again, down to Min
+	if got := observeWindow("small", StatusTimedOut, time.Millisecond); got != 1 {
+		t.Fatalf("concurrency after failures = %d, want 1", got)
+	}
+
+	// Tasks that never ran are ignored
+	c.observe("small", StatusNotStarted, 0, 0, now.Add(time.Minute))
+	if c.window != 0 {
+		t.Errorf("window = %d, want tasks that never ran ignored", c.window)
+	}
+
+	report := c.report()
+	if report.Initial != 2 || report.Final != 1 || len(report.Decisions) != 4 {
+		t.Errorf("report = %+v, want 2 -> 1 after 4 decisions", report)
+	}
+	if got, want := report.String(), "adaptive 2 -> 1 (min 1, max 4; raised 2 times, cut 2 times)"; got != want {
+		t.Errorf("report.String() = %q, want %q", got, want)
+	}
+	if !strings.Contains(report.Decisions[2].Reason, "small at") {
+		t.Errorf("cut reason = %q, want the slow cluster named", report.Decisions[2].Reason)
+	}
+
+	c.beginRun()
+	if report := c.report(); report.Initial != 1 || len(report.Decisions) != 0 {
+		t.Errorf("report after beginRun = %+v, want a fresh report at the current concurrency", report)
+	}
+}
+
+func TestPool_Adaptive(t *testing.T) {
+	pool := NewPool[string](8, slog.Default())
+	pool.SetAdaptive(&AdaptiveOptions{Initial: 1, Max: 2})
+
+	var inFlight, maxInFlight atomic.Int32
+	for i := 0; i < 12; i++ {
+		pool.Submit(Task[string]{
+			ClusterName: "c",
+			Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					m := maxInFlight.Load()
+					if n <= m || maxInFlight.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				if i >= 8 {
+					return "", errors.New("throttled")
+				}
+				return "ok", nil
+			},
+		})
+	}
+
+	results := pool.Execute(context.Background())
+	if len(results) != 12 {
+		t.Fatalf("got %d results, want 12", len(results))
+	}
+	if maxInFlight.Load() > 2 {
+		t.Errorf("ran %d tasks at once, want at most the adaptive Max of 2", maxInFlight.Load())
+	}
+
+	report, ok := pool.ConcurrencyReport()
+	if !ok {
+		t.Fatal("expected a concurrency report")
+	}
+	if report.Initial != 1 || len(report.Decisions) == 0 || report.Decisions[0].To != 2 {
+		t.Errorf("report = %+v, want a raise from 1 to 2", report)
+	}
+	if summary := pool.Summarize(results); summary.Concurrency == nil || !strings.Contains(summary.String(), "Concurrency: adaptive 1 -> ") {
+		t.Errorf("summary = %q, want the concurrency report", summary)
+	}
+
+	pool.SetAdaptive(nil)
+	if _, ok := pool.ConcurrencyReport(); ok {
+		t.Error("expected no report once adaptive concurrency is off")
+	}
+}
@@ -7,16 +7,18 @@ import (
 	"os"
 	"testing"
 	"time"
+
+	"github.com/aryankumar/fleet/internal/cluster"
 )
 
 // BenchmarkPool_Submit benchmarks task submission performance
 func BenchmarkPool_Submit(b *testing.B) {
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
-	pool := NewPool(10, logger)
+	pool := NewPool[any](10, logger)
 
-	task := Task{
+	task := Task[any]{
 		ClusterName: "benchmark-cluster",
-		Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
+		Execute: func(ctx context.Context, _ *cluster.Client) (any, error) {
 			return "done", nil
 		},
 	}
@@ -37,13 +39,13 @@ func BenchmarkPool_Execute(b *testing.B) {
 
 			for i := 0; i < b.N; i++ {
 				b.StopTimer()
-				pool := NewPool(workers, logger)
+				pool := NewPool[any](workers, logger)
 
 				// Submit 100 tasks
 				for j := 0; j < 100; j++ {
-					pool.Submit(Task{
+					pool.Submit(Task[any]{
 						ClusterName: fmt.Sprintf("cluster-%d", j),
-						Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
+						Execute: func(ctx context.Context, _ *cluster.Client) (any, error) {
 							// Simulate minimal work
 							time.Sleep(100 * time.Microsecond)
 							return "done", nil
@@ -64,12 +66,12 @@ func BenchmarkPool_ExecuteParallel(b *testing.B) {
 
 	b.RunParallel(func(pb *testing.PB) {
 		for pb.Next() {
-			pool := NewPool(4, logger)
+			pool := NewPool[any](4, logger)
 
 			for i := 0; i < 10; i++ {
-				pool.Submit(Task{
+				pool.Submit(Task[any]{
 					ClusterName: fmt.Sprintf("cluster-%d", i),
-					Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, _ *cluster.Client) (any, error) {
 						return "done", nil
 					},
 				})
@@ -83,9 +85,9 @@ func BenchmarkPool_ExecuteParallel(b *testing.B) {
 // BenchmarkResult_Filtering benchmarks result filtering operations
 func BenchmarkResult_Filtering(b *testing.B) {
 	// Create a large result set
-	results := make([]Result, 1000)
+	results := make([]Result[any], 1000)
 	for i := 0; i < 1000; i++ {
-		results[i] = Result{
+		results[i] = Result[any]{
 			ClusterName: fmt.Sprintf("cluster-%d", i),
 			Data:        "test-data",
 			Duration:    time.Duration(i) * time.Millisecond,
@@ -140,11 +142,11 @@ func BenchmarkPool_ProgressReporting(b *testing.B) {
 	b.Run("WithProgress", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			b.StopTimer()
-			pool := NewPool(4, logger)
+			pool := NewPool[any](4, logger)
 			for j := 0; j < 50; j++ {
-				pool.Submit(Task{
+				pool.Submit(Task[any]{
 					ClusterName: fmt.Sprintf("cluster-%d", j),
-					Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, _ *cluster.Client) (any, error) {
 						return "done", nil
 					},
 				})
@@ -160,11 +162,11 @@ func BenchmarkPool_ProgressReporting(b *testing.B) {
 	b.Run("WithoutProgress", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			b.StopTimer()
-			pool := NewPool(4, logger)
+			pool := NewPool[any](4, logger)
 			for j := 0; j < 50; j++ {
-				pool.Submit(Task{
+				pool.Submit(Task[any]{
 					ClusterName: fmt.Sprintf("cluster-%d", j),
-					Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, _ *cluster.Client) (any, error) {
 						return "done", nil
 					},
 				})
@@ -178,9 +180,9 @@ func BenchmarkPool_ProgressReporting(b *testing.B) {
 
 // BenchmarkDurationFunctions benchmarks duration calculation functions
 func BenchmarkDurationFunctions(b *testing.B) {
-	results := make([]Result, 1000)
+	results := make([]Result[any], 1000)
 	for i := 0; i < 1000; i++ {
-		results[i] = Result{
+		results[i] = Result[any]{
 			ClusterName: fmt.Sprintf("cluster-%d", i),
 			Duration:    time.Duration(i) * time.Millisecond,
 		}
@@ -214,13 +216,13 @@ func BenchmarkPool_Shutdown(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
 		b.StopTimer()
-		pool := NewPool(4, logger)
+		pool := NewPool[any](4, logger)
 
 		// Submit tasks
 		for j := 0; j < 20; j++ {
-			pool.Submit(Task{
+			pool.Submit(Task[any]{
 				ClusterName: fmt.Sprintf("cluster-%d", j),
-				Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
+				Execute: func(ctx context.Context, _ *cluster.Client) (any, error) {
 					time.Sleep(time.Millisecond)
 					return "done", nil
 				},
@@ -254,15 +256,15 @@ func BenchmarkMemoryAllocation(b *testing.B) {
 	b.Run("PoolCreation", func(b *testing.B) {
 		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
-			NewPool(10, logger)
+			NewPool[any](10, logger)
 		}
 	})
 
 	b.Run("TaskSubmission", func(b *testing.B) {
-		pool := NewPool(10, logger)
-		task := Task{
+		pool := NewPool[any](10, logger)
+		task := Task[any]{
 			ClusterName: "test",
-			Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, _ *cluster.Client) (any, error) {
 				return nil, nil
 			},
 		}
@@ -277,9 +279,9 @@ func BenchmarkMemoryAllocation(b *testing.B) {
 	b.Run("ResultCollection", func(b *testing.B) {
 		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
-			results := make([]Result, 100)
+			results := make([]Result[any], 100)
 			for j := 0; j < 100; j++ {
-				results[j] = Result{
+				results[j] = Result[any]{
 					ClusterName: fmt.Sprintf("cluster-%d", j),
 					Data:        "test",
 					Duration:    time.Millisecond,
@@ -296,12 +298,12 @@ func BenchmarkContextPropagation(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
 		b.StopTimer()
-		pool := NewPool(8, logger)
+		pool := NewPool[any](8, logger)
 
 		for j := 0; j < 100; j++ {
-			pool.Submit(Task{
+			pool.Submit(Task[any]{
 				ClusterName: fmt.Sprintf("cluster-%d", j),
-				Execute: func(ctx context.Context, _ interface{}) (interface{}, error) {
+				Execute: func(ctx context.Context, _ *cluster.Client) (any, error) {
 					select {
 					case <-ctx.Done():
 						return nil, ctx.Err()
@@ -8,6 +8,12 @@
 //   - Worker pool with configurable concurrency
 //   - Context-aware task execution and cancellation
 //   - Progress reporting callbacks
+//   - Per-task retries with exponential backoff for transient errors
+//   - Wave rollouts with canaries, failure thresholds and health gates
+//   - Panic isolation and an opt-in fail-fast mode
+//   - Many tasks per cluster with a per-cluster in-flight limit and fair scheduling
+//   - Optional adaptive (AIMD) concurrency driven by per-cluster latencies and errors
+//   - Reusable pools with long-lived workers, Drain and Reset
 //   - Graceful shutdown with timeout support
 //   - Result filtering and aggregation utilities
 //   - Thread-safe operations with proper synchronization
@@ -17,19 +23,22 @@
 //
 // Create a pool, submit tasks, and execute them:
 //
-//	pool := executor.NewPool(5, logger)
+//	pool := executor.NewPool[[]PodInfo](5, logger)
 //
-//	for _, cluster := range clusters {
-//	    pool.Submit(executor.Task{
-//	        ClusterName: cluster,
-//	        Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+//	for _, client := range clients {
+//	    pool.Submit(executor.Task[[]PodInfo]{
+//	        Client: client,
+//	        Execute: func(ctx context.Context, c *cluster.Client) ([]PodInfo, error) {
 //	            // Perform cluster operation
-//	            return result, nil
+//	            return listPods(ctx, c.Clientset)
 //	        },
 //	    })
 //	}
 //
 //	results := pool.Execute(context.Background())
+//	pods := executor.SuccessfulData(results)
+//
+// Code still using the untyped API can import the legacy subpackage instead.
 //
 // # Progress Reporting
 //
@@ -39,6 +48,15 @@
 //	    fmt.Printf("Progress: %d/%d\n", completed, total)
 //	})
 //
+// # Streaming Results
+//
+// Handle each result as soon as its task finishes instead of waiting for all
+// of them:
+//
+//	for result := range pool.ExecuteStream(ctx) {
+//	    fmt.Printf("%s: %v\n", result.ClusterName, result.Error)
+//	}
+//
 // # Result Aggregation
 //
 // Filter and analyze results:
@@ -57,6 +75,50 @@
 //
 //	results := pool.Execute(ctx)
 //
+// # Many Tasks per Cluster
+//
+// A cluster can have any number of tasks. SetClusterLimit caps how many of
+// one cluster's tasks run at once, and workers take tasks from the clusters
+// in turn so that one large cluster can't starve the rest. GroupByCluster
+// collects the results per cluster:
+//
+//	pool.SetClusterLimit(4)
+//	byCluster := executor.GroupByCluster(pool.Execute(ctx))
+//
+// # Timeouts and Deadlines
+//
+// A task's Timeout is measured from when the task starts, so tasks queued
+// behind slow ones get their full time. WithRunDeadline bounds the whole
+// run. Result.Status tells a task that timed out from one that was cancelled
+// while running and one that never started.
+//
+// # Adaptive Concurrency
+//
+// SetAdaptive turns the worker count into a ceiling. The controller raises
+// the concurrency by one after each window of tasks whose latencies stayed
+// near their clusters' baselines, and halves it when latencies or the error
+// rate climb. Pool.Summarize includes its ConcurrencyReport:
+//
+//	pool.SetAdaptive(&executor.AdaptiveOptions{})
+//	summary := pool.Summarize(pool.Execute(ctx))
+//
+// # Reusing a Pool
+//
+// Every run takes the queued tasks, so a pool can be run again with newly
+// submitted tasks, and Submit may be called while a run is in progress.
+// Long-running modes such as watch, serve or a REPL can Start the pool's
+// workers once, Submit tasks as they come and collect them with Drain:
+//
+//	if err := pool.Start(ctx); err != nil {
+//	    return err
+//	}
+//	defer pool.Shutdown(context.Background())
+//
+//	pool.Submit(task)
+//	results, err := pool.Drain(ctx)
+//
+// Reset discards the queued tasks that no run has taken yet.
+//
 // # Graceful Shutdown
 //
 // Shutdown the pool gracefully:
@@ -94,11 +156,17 @@
 //	    }
 //	}
 //
+// A panic in a task is recovered into a *PanicError carrying the stack trace.
+// With SetFailFast(true) the first failure cancels the running tasks instead,
+// and tasks that have not started are reported as Skipped with ErrSkipped.
+// Tasks turned away by an open circuit breaker (cluster.ErrCircuitOpen) are
+// Skipped too, with no attempts, and never trigger fail-fast.
+//
 // # Thread Safety
 //
 // All pool operations are thread-safe:
 //   - Submit can be called concurrently (when pool is not running)
-//   - Execute/ExecuteWithProgress are mutually exclusive (only one can run at a time)
+//   - Execute/ExecuteWithProgress/ExecuteStream are mutually exclusive (only one can run at a time)
 //   - Shutdown can be called concurrently with Execute
 //   - All accessors (TaskCount, WorkerCount, etc.) are thread-safe
 package executor
@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/aryankumar/fleet/internal/cluster"
 	"github.com/aryankumar/fleet/internal/executor"
 )
 
@@ -18,15 +19,15 @@ func Example() {
 	}))
 
 	// Create a pool with 3 workers
-	pool := executor.NewPool(3, logger)
+	pool := executor.NewPool[any](3, logger)
 
 	// Submit tasks
 	clusters := []string{"prod-us-east", "prod-us-west", "staging"}
-	for _, cluster := range clusters {
-		clusterName := cluster
-		pool.Submit(executor.Task{
+	for _, name := range clusters {
+		clusterName := name
+		pool.Submit(executor.Task[any]{
 			ClusterName: clusterName,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				// Simulate some work
 				time.Sleep(50 * time.Millisecond)
 				return fmt.Sprintf("result from %s", clusterName), nil
@@ -55,14 +56,14 @@ func ExamplePool_ExecuteWithProgress() {
 		Level: slog.LevelWarn, // Reduce log noise
 	}))
 
-	pool := executor.NewPool(2, logger)
+	pool := executor.NewPool[any](2, logger)
 
 	// Submit 5 tasks
 	for i := 1; i <= 5; i++ {
 		clusterName := fmt.Sprintf("cluster-%d", i)
-		pool.Submit(executor.Task{
+		pool.Submit(executor.Task[any]{
 			ClusterName: clusterName,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				time.Sleep(20 * time.Millisecond)
 				return "done", nil
 			},
@@ -87,7 +88,7 @@ func Example_resultAggregation() {
 		Level: slog.LevelWarn,
 	}))
 
-	pool := executor.NewPool(3, logger)
+	pool := executor.NewPool[any](3, logger)
 
 	// Submit tasks with some failures
 	tasks := map[string]bool{
@@ -98,11 +99,11 @@ func Example_resultAggregation() {
 		"cluster-5": true,  // success
 	}
 
-	for cluster, shouldSucceed := range tasks {
+	for name, shouldSucceed := range tasks {
 		succeed := shouldSucceed
-		pool.Submit(executor.Task{
-			ClusterName: cluster,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+		pool.Submit(executor.Task[any]{
+			ClusterName: name,
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				if succeed {
 					return "success", nil
 				}
@@ -141,14 +142,14 @@ func Example_contextCancellation() {
 		Level: slog.LevelWarn,
 	}))
 
-	pool := executor.NewPool(2, logger)
+	pool := executor.NewPool[any](2, logger)
 
 	// Submit long-running tasks
 	for i := 1; i <= 5; i++ {
 		clusterName := fmt.Sprintf("cluster-%d", i)
-		pool.Submit(executor.Task{
+		pool.Submit(executor.Task[any]{
 			ClusterName: clusterName,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				select {
 				case <-time.After(200 * time.Millisecond):
 					return "completed", nil
@@ -183,14 +184,14 @@ func Example_gracefulShutdown() {
 		Level: slog.LevelInfo,
 	}))
 
-	pool := executor.NewPool(2, logger)
+	pool := executor.NewPool[any](2, logger)
 
 	// Submit tasks
 	for i := 1; i <= 3; i++ {
 		clusterName := fmt.Sprintf("cluster-%d", i)
-		pool.Submit(executor.Task{
+		pool.Submit(executor.Task[any]{
 			ClusterName: clusterName,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				time.Sleep(50 * time.Millisecond)
 				return "done", nil
 			},
@@ -220,15 +221,15 @@ func Example_realWorldUsage() {
 	}))
 
 	// Simulate getting pod counts from multiple clusters
-	pool := executor.NewPool(3, logger)
+	pool := executor.NewPool[any](3, logger)
 
 	clusters := []string{"prod-1", "prod-2", "staging", "dev"}
 
-	for _, cluster := range clusters {
-		clusterName := cluster
-		pool.Submit(executor.Task{
+	for _, name := range clusters {
+		clusterName := name
+		pool.Submit(executor.Task[any]{
 			ClusterName: clusterName,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				// Simulate API call to get pod count
 				time.Sleep(30 * time.Millisecond)
 
@@ -276,3 +277,38 @@ func Example_realWorldUsage() {
 	summary := executor.Summarize(results)
 	fmt.Printf("\n%s\n", summary.String())
 }
+
+// ExamplePool_SetClusterLimit demonstrates fanning out many tasks per cluster
+func ExamplePool_SetClusterLimit() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}))
+
+	// Up to 8 tasks at once, but no more than 2 against any one cluster
+	pool := executor.NewPool[int](8, logger)
+	pool.SetClusterLimit(2)
+
+	// One task per namespace on each cluster
+	clusters := []string{"prod-us-east", "prod-us-west", "staging"}
+	namespaces := []string{"default", "kube-system", "monitoring", "payments"}
+	for _, clusterName := range clusters {
+		for _, namespace := range namespaces {
+			pool.Submit(executor.Task[int]{
+				ClusterName: clusterName,
+				Execute: func(ctx context.Context, client *cluster.Client) (int, error) {
+					// List pods in namespace
+					return len(namespace), nil
+				},
+			})
+		}
+	}
+
+	grouped := executor.GroupByCluster(pool.Execute(context.Background()))
+	for _, clusterName := range clusters {
+		fmt.Printf("%s: %d namespaces\n", clusterName, len(grouped[clusterName]))
+	}
+	// Output:
+	// prod-us-east: 4 namespaces
+	// prod-us-west: 4 namespaces
+	// staging: 4 namespaces
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/aryankumar/fleet/internal/cluster"
 	"github.com/aryankumar/fleet/internal/executor"
 )
 
@@ -26,7 +27,7 @@ func Example_integrationWithClusterManager() {
 
 	// Create executor pool with optimal worker count for API calls
 	// For I/O-bound operations like Kubernetes API calls, we can use more workers
-	pool := executor.NewPool(5, logger)
+	pool := executor.NewPool[any](5, logger)
 
 	// Simulate getting clusters from cluster manager
 	// In real usage: clusters := clusterManager.GetAllClients()
@@ -34,15 +35,14 @@ func Example_integrationWithClusterManager() {
 
 	// Submit tasks for each cluster
 	// Each task fetches pod information from a cluster
-	for _, cluster := range clusterNames {
-		clusterName := cluster // Capture for closure
+	for _, name := range clusterNames {
+		clusterName := name // Capture for closure
 
-		pool.Submit(executor.Task{
+		pool.Submit(executor.Task[any]{
 			ClusterName: clusterName,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				// In real implementation, this would be:
-				// k8sClient := client.(*cluster.Client)
-				// pods, err := k8sClient.GetPods(ctx, namespace)
+				// pods, err := client.Clientset.CoreV1().Pods(namespace).List(ctx, opts)
 
 				// Simulate API call with varying durations
 				time.Sleep(20 * time.Millisecond)
@@ -120,14 +120,14 @@ func Example_gracefulShutdownWithCleanup() {
 		Level: slog.LevelInfo,
 	}))
 
-	pool := executor.NewPool(3, logger)
+	pool := executor.NewPool[any](3, logger)
 
 	// Submit some long-running tasks
 	for i := 1; i <= 5; i++ {
 		clusterName := fmt.Sprintf("cluster-%d", i)
-		pool.Submit(executor.Task{
+		pool.Submit(executor.Task[any]{
 			ClusterName: clusterName,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				// Simulate work that respects context
 				select {
 				case <-time.After(100 * time.Millisecond):
@@ -141,7 +141,7 @@ func Example_gracefulShutdownWithCleanup() {
 
 	// Start execution in background
 	ctx := context.Background()
-	done := make(chan []executor.Result)
+	done := make(chan []executor.Result[any])
 	go func() {
 		results := pool.Execute(ctx)
 		done <- results
@@ -172,7 +172,7 @@ func Example_errorHandlingPatterns() {
 		Level: slog.LevelWarn,
 	}))
 
-	pool := executor.NewPool(3, logger)
+	pool := executor.NewPool[any](3, logger)
 
 	// Submit tasks with various error scenarios
 	tasks := map[string]error{
@@ -184,13 +184,13 @@ func Example_errorHandlingPatterns() {
 		"cluster-6": nil,                                 // Success
 	}
 
-	for cluster, expectedError := range tasks {
-		clusterName := cluster
+	for name, expectedError := range tasks {
+		clusterName := name
 		taskError := expectedError
 
-		pool.Submit(executor.Task{
+		pool.Submit(executor.Task[any]{
 			ClusterName: clusterName,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				time.Sleep(10 * time.Millisecond)
 				if taskError != nil {
 					return nil, taskError
@@ -241,7 +241,7 @@ func Example_performanceMonitoring() {
 		Level: slog.LevelWarn,
 	}))
 
-	pool := executor.NewPool(4, logger)
+	pool := executor.NewPool[any](4, logger)
 
 	// Submit tasks with varying durations
 	clusters := []struct {
@@ -255,11 +255,11 @@ func Example_performanceMonitoring() {
 	}
 
 	for _, c := range clusters {
-		cluster := c
-		pool.Submit(executor.Task{
-			ClusterName: cluster.name,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
-				time.Sleep(cluster.duration)
+		spec := c
+		pool.Submit(executor.Task[any]{
+			ClusterName: spec.name,
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
+				time.Sleep(spec.duration)
 				return "done", nil
 			},
 		})
@@ -0,0 +1,133 @@
+// Package legacy keeps the untyped executor API available while callers move
+// to the generic executor.Pool[T] and executor.Task[T].
+//
+// Code written against the old API only needs its import changed:
+//
+//	import executor "github.com/aryankumar/fleet/internal/executor/legacy"
+//
+// Results are executor.Result[any], so they work with the generic helpers in
+// the executor package as well as the wrappers here.
+//
+// Deprecated: use the generic API in the executor package.
+package legacy
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/cluster"
+	"github.com/aryankumar/fleet/internal/executor"
+)
+
+// Task is the untyped task of the old executor API
+type Task struct {
+	// ClusterName identifies which cluster this task targets
+	ClusterName string
+
+	// Client is passed to Execute; nil if the task binds its client itself
+	Client *cluster.Client
+
+	// Execute is the function to run for this task; client is the task's
+	// *cluster.Client, or nil if none was set
+	Execute func(ctx context.Context, client interface{}) (interface{}, error)
+
+	// Timeout bounds each attempt of this task; zero means only the pool context applies
+	Timeout time.Duration
+
+	// Retry controls how failed attempts are retried; the zero value runs the task once
+	Retry RetryPolicy
+}
+
+// Result is the outcome of an untyped task
+type Result = executor.Result[any]
+
+// Summary is re-exported for callers of Summarize
+type Summary = executor.Summary
+
+// RetryPolicy is re-exported for Task.Retry
+type RetryPolicy = executor.RetryPolicy
+
+// Pool runs untyped tasks on an executor.Pool[any]
+type Pool struct {
+	*executor.Pool[any]
+}
+
+// NewPool creates a pool with the specified number of workers
+func NewPool(workers int, logger *slog.Logger) *Pool {
+	return &Pool{Pool: executor.NewPool[any](workers, logger)}
+}
+
+// Submit adds an untyped task to the pool's queue
+func (p *Pool) Submit(task Task) error {
+	typed := executor.Task[any]{
+		ClusterName: task.ClusterName,
+		Client:      task.Client,
+		Timeout:     task.Timeout,
+		Retry:       task.Retry,
+	}
+	if task.Execute != nil {
+		typed.Execute = func(ctx context.Context, client *cluster.Client) (any, error) {
+			// Keep passing an untyped nil, as the old pool did, when there is no client
+			if client == nil {
+				return task.Execute(ctx, nil)
+			}
+			return task.Execute(ctx, client)
+		}
+	}
+	return p.Pool.Submit(typed)
+}
+
+// CountSuccessful returns the number of successful results
+func CountSuccessful(results []Result) int { return executor.CountSuccessful(results) }
+
+// CountFailed returns the number of failed results
+func CountFailed(results []Result) int { return executor.CountFailed(results) }
+
+// FilterSuccessful returns only the successful results
+func FilterSuccessful(results []Result) []Result { return executor.FilterSuccessful(results) }
+
+// FilterFailed returns only the failed results
+func FilterFailed(results []Result) []Result { return executor.FilterFailed(results) }
+
+// FilterByCluster returns results for a specific cluster
+func FilterByCluster(results []Result, clusterName string) []Result {
+	return executor.FilterByCluster(results, clusterName)
+}
+
+// GroupByCluster groups results by cluster name
+func GroupByCluster(results []Result) map[string][]Result { return executor.GroupByCluster(results) }
+
+// AverageDuration calculates the average duration of all results
+func AverageDuration(results []Result) time.Duration { return executor.AverageDuration(results) }
+
+// MaxDuration returns the maximum duration among all results
+func MaxDuration(results []Result) time.Duration { return executor.MaxDuration(results) }
+
+// MinDuration returns the minimum duration among all results
+func MinDuration(results []Result) time.Duration { return executor.MinDuration(results) }
+
+// GetErrors extracts all errors from results
+func GetErrors(results []Result) []error { return executor.GetErrors(results) }
+
+// GetClusterNames extracts unique cluster names from results
+func GetClusterNames(results []Result) []string { return executor.GetClusterNames(results) }
+
+// Summarize creates a summary of the results
+func Summarize(results []Result) Summary { return executor.Summarize(results) }
+
+// HasErrors returns true if any results contain errors
+func HasErrors(results []Result) bool { return executor.HasErrors(results) }
+
+// AllSuccessful returns true if all results are successful
+func AllSuccessful(results []Result) bool { return executor.AllSuccessful(results) }
+
+// SuccessRate returns the success rate as a percentage (0.0 to 100.0)
+func SuccessRate(results []Result) float64 { return executor.SuccessRate(results) }
+
+// FailureRate returns the failure rate as a percentage (0.0 to 100.0)
+func FailureRate(results []Result) float64 { return executor.FailureRate(results) }
+
+// NewRetryPolicy returns a policy that retries up to retries times with the
+// default backoff
+func NewRetryPolicy(retries int) RetryPolicy { return executor.NewRetryPolicy(retries) }
@@ -0,0 +1,59 @@
+package legacy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aryankumar/fleet/internal/cluster"
+)
+
+func TestPool(t *testing.T) {
+	pool := NewPool(2, nil)
+	client := &cluster.Client{Name: "with-client"}
+
+	var got interface{}
+	tasks := []Task{
+		{
+			ClusterName: "no-client",
+			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+				if client != nil {
+					return nil, errors.New("expected an untyped nil client")
+				}
+				return "ok", nil
+			},
+		},
+		{
+			Client: client,
+			Execute: func(ctx context.Context, c interface{}) (interface{}, error) {
+				got = c
+				return 42, nil
+			},
+		},
+		{
+			ClusterName: "failing",
+			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+				return nil, errors.New("boom")
+			},
+		},
+	}
+	for _, task := range tasks {
+		if err := pool.Submit(task); err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+	if err := pool.Submit(Task{ClusterName: "no-execute"}); err == nil {
+		t.Error("expected a task without an execute function to be rejected")
+	}
+
+	results := pool.Execute(context.Background())
+	if CountSuccessful(results) != 2 || CountFailed(results) != 1 {
+		t.Errorf("results = %+v, want 2 successful and 1 failed", results)
+	}
+	if results[0].Error != nil || results[0].Data != "ok" {
+		t.Errorf("no-client result = %+v", results[0])
+	}
+	if got != client || results[1].ClusterName != "with-client" || results[1].Data != 42 {
+		t.Errorf("with-client result = %+v, want the task's client passed and its name used", results[1])
+	}
+}
@@ -2,48 +2,125 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"runtime/debug"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/aryankumar/fleet/internal/cluster"
+)
+
+// ErrSkipped marks the results of tasks that were never run because a
+// fail-fast pool stopped after an earlier failure
+var ErrSkipped = errors.New("task skipped")
+
+// Status is how a task ended
+type Status string
+
+const (
+	// StatusSucceeded is a task that returned no error
+	StatusSucceeded Status = "succeeded"
+
+	// StatusFailed is a task that returned an error
+	StatusFailed Status = "failed"
+
+	// StatusTimedOut is a task stopped by its own timeout or by the run's
+	// deadline while it was running
+	StatusTimedOut Status = "timed-out"
+
+	// StatusCancelled is a task whose context was cancelled while it was
+	// running, by the user or by fail-fast
+	StatusCancelled Status = "cancelled"
+
+	// StatusNotStarted is a task that never started because the run was
+	// cancelled or reached its deadline first
+	StatusNotStarted Status = "not-started"
+
+	// StatusSkipped is a task that was never run because the pool stopped
+	// early, after a failure in fail-fast mode or an aborted rollout, or
+	// because the cluster's circuit breaker was open
+	StatusSkipped Status = "skipped"
 )
 
+// PanicError is the error of a task whose Execute function panicked
+type PanicError struct {
+	// Value is the value passed to panic
+	Value any
+
+	// Stack is the stack trace of the panicking goroutine
+	Stack []byte
+}
+
+// Error returns the panic value; the stack trace is in Stack
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("task panicked: %v", e.Value)
+}
+
 // Task represents a unit of work to be executed by the worker pool
-// Each task is associated with a specific cluster and contains the execution logic
-type Task struct {
-	// ClusterName identifies which cluster this task targets
+// Each task is associated with a specific cluster and contains the execution logic;
+// T is the type of data it returns. A cluster can have any number of tasks.
+type Task[T any] struct {
+	// ClusterName identifies which cluster this task targets; it defaults to
+	// the client's name
 	ClusterName string
 
+	// Client is the cluster client passed to Execute
+	Client *cluster.Client
+
 	// Execute is the function to run for this task
-	// The client parameter is the cluster client (typically *cluster.Client)
 	// Returns the result data and any error encountered
-	Execute func(ctx context.Context, client interface{}) (interface{}, error)
+	Execute func(ctx context.Context, client *cluster.Client) (T, error)
+
+	// Timeout bounds each attempt of this task, measured from when the attempt
+	// starts rather than when the run starts, so tasks queued behind slow ones
+	// get their full time; zero means only the run's context applies
+	Timeout time.Duration
+
+	// Retry controls how failed attempts are retried; the zero value runs the task once
+	Retry RetryPolicy
 }
 
 // Result represents the outcome of executing a task
-type Result struct {
+type Result[T any] struct {
 	// ClusterName identifies which cluster this result is from
 	ClusterName string
 
-	// Data contains the successful result data (nil if error occurred)
-	Data interface{}
+	// Data contains the successful result data (the zero value if an error occurred)
+	Data T
 
 	// Error contains any error that occurred during execution (nil if successful)
 	Error error
 
-	// Duration is how long the task took to execute
+	// Duration is how long the task took to execute, including retries
 	Duration time.Duration
+
+	// Attempts is how many times the task was run
+	Attempts int
+
+	// AttemptErrors holds the error of every failed attempt in order; the
+	// last one is Error if the task failed
+	AttemptErrors []error
+
+	// Status tells how the task ended, such as timed out, cancelled or never
+	// started; Error says why it did not succeed
+	Status Status
+
+	// Wave is the rollout wave the task belonged to, starting at 1; zero
+	// unless the pool ran with ExecuteWaves
+	Wave int
 }
 
 // Pool manages a pool of workers that execute tasks concurrently
 // It provides bounded concurrency, graceful shutdown, and progress reporting
-type Pool struct {
+type Pool[T any] struct {
 	// workers is the number of concurrent workers
 	workers int
 
 	// tasks is the queue of tasks to execute
-	tasks []Task
+	tasks []Task[T]
 
 	// mu protects the tasks slice and state flags
 	mu sync.Mutex
@@ -56,11 +133,33 @@ type Pool struct {
 
 	// running indicates if the pool is currently executing
 	running atomic.Bool
+
+	// failFast stops the remaining tasks after the first failure
+	failFast atomic.Bool
+
+	// clusterLimit caps the tasks running at once for any one cluster; zero
+	// means only the worker count applies
+	clusterLimit atomic.Int32
+
+	// adaptive sizes the concurrency from the tasks' latencies and errors; nil
+	// unless SetAdaptive enabled it
+	adaptive atomic.Pointer[adaptiveController]
+
+	// concurrency is the adaptive controller's current concurrency; zero
+	// means every worker may run a task
+	concurrency atomic.Int32
+
+	// sched is the long-lived scheduler of a started pool, fed by every run;
+	// nil until Start is called
+	sched *scheduler[T]
+
+	// workersDone is closed once a started pool's workers have stopped
+	workersDone chan struct{}
 }
 
 // NewPool creates a new worker pool with the specified number of workers
 // workers must be > 0, otherwise it defaults to 1
-func NewPool(workers int, logger *slog.Logger) *Pool {
+func NewPool[T any](workers int, logger *slog.Logger) *Pool[T] {
 	if workers <= 0 {
 		workers = 1
 	}
@@ -69,27 +168,83 @@ func NewPool(workers int, logger *slog.Logger) *Pool {
 		logger = slog.Default()
 	}
 
-	return &Pool{
+	return &Pool[T]{
 		workers: workers,
-		tasks:   make([]Task, 0),
+		tasks:   make([]Task[T], 0),
 		logger:  logger,
 	}
 }
 
+// SetClusterLimit caps how many of one cluster's tasks run at once, on top
+// of the pool's worker count; zero or less removes the cap
+// Whatever the limit, workers take tasks from the clusters in turn so that a
+// cluster with many tasks doesn't hold up the others.
+func (p *Pool[T]) SetClusterLimit(limit int) {
+	p.clusterLimit.Store(int32(max(limit, 0)))
+}
+
+// SetAdaptive turns on adaptive concurrency, or turns it off if opts is nil
+// The pool's worker count becomes the most tasks that may run at once, and
+// the controller moves the concurrency between opts.Min and that as the
+// tasks finish. Its decisions are logged and kept in ConcurrencyReport.
+func (p *Pool[T]) SetAdaptive(opts *AdaptiveOptions) {
+	if opts == nil {
+		p.adaptive.Store(nil)
+		p.concurrency.Store(0)
+		return
+	}
+
+	controller := newAdaptiveController(*opts, p.workers)
+	p.adaptive.Store(controller)
+	p.concurrency.Store(int32(controller.current()))
+}
+
+// ConcurrencyReport returns how the adaptive controller sized the latest
+// run, or false if adaptive concurrency is off
+func (p *Pool[T]) ConcurrencyReport() (ConcurrencyReport, bool) {
+	controller := p.adaptive.Load()
+	if controller == nil {
+		return ConcurrencyReport{}, false
+	}
+	return controller.report(), true
+}
+
+// Summarize summarizes results like the Summarize function, adding the
+// concurrency report of an adaptive pool
+func (p *Pool[T]) Summarize(results []Result[T]) Summary {
+	summary := Summarize(results)
+	if report, ok := p.ConcurrencyReport(); ok {
+		summary.Concurrency = &report
+	}
+	return summary
+}
+
+// WithRunDeadline returns ctx bounded by an overall deadline for a run, on
+// top of each task's own Timeout; zero means no deadline
+// Tasks running when it passes time out, and tasks that have not started are
+// reported as not started.
+func WithRunDeadline(ctx context.Context, deadline time.Duration) (context.Context, context.CancelFunc) {
+	if deadline <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, deadline)
+}
+
 // Submit adds a task to the pool's queue
-// Returns an error if the pool is shutting down or already running
-func (p *Pool) Submit(task Task) error {
+// It may be called while a run is in progress: the task waits for the next
+// run, or for the next round of a running Drain. Returns an error if the
+// pool is shutting down.
+func (p *Pool[T]) Submit(task Task[T]) error {
 	if p.shutdown.Load() {
 		return fmt.Errorf("pool is shutting down, cannot submit new tasks")
 	}
 
-	if p.running.Load() {
-		return fmt.Errorf("pool is running, cannot submit new tasks")
-	}
-
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	if task.ClusterName == "" && task.Client != nil {
+		task.ClusterName = task.Client.Name
+	}
 	if task.ClusterName == "" {
 		return fmt.Errorf("task must have a cluster name")
 	}
@@ -104,211 +259,489 @@ func (p *Pool) Submit(task Task) error {
 	return nil
 }
 
+// Start launches the pool's workers and keeps them running across runs until
+// Shutdown is called or ctx ends
+// Every Execute, ExecuteStream, ExecuteWaves and Drain after Start queues its
+// tasks on these workers instead of starting its own, so long-running modes
+// such as watch, serve or a REPL can reuse one pool. A pool that is never
+// started starts workers for each run and stops them when it ends.
+func (p *Pool[T]) Start(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.shutdown.Load() {
+		return fmt.Errorf("pool is shutting down, cannot start")
+	}
+	if p.sched != nil && !p.sched.isClosed() {
+		return fmt.Errorf("pool already started")
+	}
+
+	sched := newScheduler[T](&p.clusterLimit, &p.concurrency)
+	workersDone := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go p.worker(i, sched, &wg)
+	}
+	go func() {
+		wg.Wait()
+		close(workersDone)
+	}()
+
+	// The workers finish the queued tasks and stop once ctx ends
+	context.AfterFunc(ctx, sched.close)
+
+	p.sched = sched
+	p.workersDone = workersDone
+	p.logger.Info("started workers", "workers", p.workers)
+
+	return nil
+}
+
+// Drain runs the queued tasks, and any submitted while it runs, until the
+// queue is empty and returns their results in submission order
+// Tasks submitted during a round of Drain run in the next round, so other
+// goroutines can keep submitting while it runs. In fail-fast mode the tasks
+// of later rounds are skipped once one has failed. If ctx ends, the tasks
+// still queued are reported as not started and ctx's error is returned with
+// the results.
+func (p *Pool[T]) Drain(ctx context.Context) ([]Result[T], error) {
+	if !p.running.CompareAndSwap(false, true) {
+		return nil, fmt.Errorf("pool is already running")
+	}
+	defer p.running.Store(false)
+
+	if controller := p.adaptive.Load(); controller != nil {
+		controller.beginRun()
+	}
+
+	var (
+		results     []Result[T]
+		firstFailed string
+	)
+	for {
+		p.mu.Lock()
+		tasks := p.tasks
+		p.tasks = make([]Task[T], 0)
+		p.mu.Unlock()
+
+		if len(tasks) == 0 {
+			return results, ctx.Err()
+		}
+
+		if firstFailed != "" {
+			for _, task := range tasks {
+				results = append(results, skippedResult[T](task.ClusterName, firstFailed))
+			}
+			continue
+		}
+
+		round := make([]Result[T], len(tasks))
+		p.run(ctx, tasks, nil, func(index int, result Result[T]) {
+			round[index] = result
+		})
+		results = append(results, round...)
+
+		if p.failFast.Load() {
+			for _, result := range round {
+				if result.Error != nil && result.Attempts > 0 {
+					firstFailed = result.ClusterName
+					break
+				}
+			}
+		}
+	}
+}
+
+// Reset discards the queued tasks that no run has taken yet and returns how
+// many there were; tasks already running are not affected
+func (p *Pool[T]) Reset() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	dropped := len(p.tasks)
+	p.tasks = make([]Task[T], 0)
+	if dropped > 0 {
+		p.logger.Debug("queued tasks discarded", "tasks", dropped)
+	}
+
+	return dropped
+}
+
+// SetFailFast sets whether the pool stops after the first failed task
+// The shared context is cancelled so that running tasks can stop, and tasks
+// that have not started are reported as skipped with an error wrapping
+// ErrSkipped instead of being run.
+func (p *Pool[T]) SetFailFast(enabled bool) {
+	p.failFast.Store(enabled)
+}
+
 // Execute runs all submitted tasks using the worker pool pattern
 // It creates a bounded number of worker goroutines that process tasks concurrently
 // Returns a slice of results, one for each task (order may not match submission order)
-func (p *Pool) Execute(ctx context.Context) []Result {
+func (p *Pool[T]) Execute(ctx context.Context) []Result[T] {
 	return p.ExecuteWithProgress(ctx, nil)
 }
 
 // ExecuteWithProgress runs all tasks with progress reporting
 // The progressFn callback is called after each task completes with (completed, total) counts
 // Progress updates are safe to use for UI updates or logging
-func (p *Pool) ExecuteWithProgress(ctx context.Context, progressFn func(completed, total int)) []Result {
+func (p *Pool[T]) ExecuteWithProgress(ctx context.Context, progressFn func(completed, total int)) []Result[T] {
+	tasks, ok := p.start()
+	if !ok {
+		return []Result[T]{}
+	}
+	defer p.running.Store(false)
+
+	results := make([]Result[T], len(tasks))
+	p.run(ctx, tasks, progressFn, func(index int, result Result[T]) {
+		results[index] = result
+	})
+
+	return results
+}
+
+// ExecuteStream runs all tasks like Execute but sends each result on the
+// returned channel as soon as its task finishes, so callers can show fast
+// clusters without waiting for the slowest one
+// The channel is closed once every task has a result. It is buffered for
+// every result, so a caller that stops reading early doesn't block the pool.
+func (p *Pool[T]) ExecuteStream(ctx context.Context) <-chan Result[T] {
+	tasks, ok := p.start()
+	stream := make(chan Result[T], len(tasks))
+	if !ok {
+		close(stream)
+		return stream
+	}
+
+	go func() {
+		defer close(stream)
+		defer p.running.Store(false)
+
+		p.run(ctx, tasks, nil, func(_ int, result Result[T]) {
+			stream <- result
+		})
+	}()
+
+	return stream
+}
+
+// start marks the pool as running and takes its queued tasks, so that a
+// task runs only once and tasks submitted from now on wait for the next run
+// It returns false if the pool is already running.
+func (p *Pool[T]) start() ([]Task[T], bool) {
 	if !p.running.CompareAndSwap(false, true) {
 		p.logger.Error("pool is already running")
-		return []Result{}
+		return nil, false
 	}
-	defer p.running.Store(false)
 
 	p.mu.Lock()
-	taskCount := len(p.tasks)
+	tasks := p.tasks
+	p.tasks = make([]Task[T], 0)
+	p.mu.Unlock()
+
+	if controller := p.adaptive.Load(); controller != nil {
+		controller.beginRun()
+	}
+
+	return tasks, true
+}
+
+// run executes tasks on the pool's workers and calls emit once per task, from
+// a single goroutine, with the task's index and result in completion order
+func (p *Pool[T]) run(ctx context.Context, tasks []Task[T], progressFn func(completed, total int), emit func(index int, result Result[T])) {
+	taskCount := len(tasks)
 	if taskCount == 0 {
-		p.mu.Unlock()
 		p.logger.Debug("no tasks to execute")
-		return []Result{}
+		return
 	}
 
-	// Create a copy of tasks to avoid holding the lock during execution
-	tasksCopy := make([]Task, len(p.tasks))
-	copy(tasksCopy, p.tasks)
-	p.mu.Unlock()
-
 	p.logger.Info("starting task execution",
 		"workers", p.workers,
-		"tasks", taskCount)
+		"tasks", taskCount,
+		"cluster_limit", p.clusterLimit.Load(),
+		"adaptive", p.adaptive.Load() != nil)
 
 	startTime := time.Now()
 
-	// Create channels for task distribution and result collection
-	// Buffer size = task count to avoid blocking
-	taskChan := make(chan taskWithIndex, taskCount)
-	resultChan := make(chan resultWithIndex, taskCount)
+	// In fail-fast mode the first failure cancels runCtx; firstFailed holds
+	// the failed task's cluster name
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var firstFailed atomic.Value
+	var onFailure func(clusterName string)
+	if p.failFast.Load() {
+		onFailure = func(clusterName string) {
+			if ctx.Err() == nil && firstFailed.CompareAndSwap(nil, clusterName) {
+				p.logger.Warn("task failed, skipping remaining tasks (fail-fast)", "cluster", clusterName)
+				cancel()
+			}
+		}
+	}
+
+	// Results arrive from the workers as tasks finish; the buffer holds
+	// every task's result so a worker never blocks on the consumer, and a
+	// finished result must not be lost to cancellation
+	resultChan := make(chan resultWithIndex[T], taskCount)
 
 	// Completed counter for progress reporting
 	var completed atomic.Int32
 
-	// Start worker goroutines
-	var wg sync.WaitGroup
-	workerCount := p.workers
-	if workerCount > taskCount {
-		// Don't create more workers than tasks
-		workerCount = taskCount
-	}
+	finish := func(index int, result Result[T]) {
+		if result.Error != nil && result.Attempts > 0 && onFailure != nil {
+			onFailure(result.ClusterName)
+		}
 
-	p.logger.Debug("starting workers", "count", workerCount)
+		// Call progress callback if provided
+		completedCount := completed.Add(1)
+		if progressFn != nil {
+			progressFn(int(completedCount), taskCount)
+		}
 
-	for i := 0; i < workerCount; i++ {
-		wg.Add(1)
-		go p.worker(ctx, i, taskChan, resultChan, &wg, &completed, taskCount, progressFn)
+		resultChan <- resultWithIndex[T]{result: result, index: index}
 	}
 
-	// Send all tasks to the task channel
-	for i, task := range tasksCopy {
-		select {
-		case taskChan <- taskWithIndex{task: task, index: i}:
-		case <-ctx.Done():
-			p.logger.Warn("context cancelled while queuing tasks")
-			close(taskChan)
-			goto waitForWorkers
-		}
+	jobs := make([]job[T], taskCount)
+	for i, task := range tasks {
+		jobs[i] = job[T]{task: task, index: i, ctx: runCtx, finish: finish}
 	}
-	close(taskChan)
-
-waitForWorkers:
-	// Wait for all workers to complete
-	wg.Wait()
-	close(resultChan)
 
-	// Collect results
-	results := make([]Result, taskCount)
-	resultsReceived := 0
-
-	for res := range resultChan {
-		if res.index >= 0 && res.index < taskCount {
-			results[res.index] = res.result
-			resultsReceived++
-		}
-	}
-
-	// For any tasks that didn't complete (e.g., context cancelled before execution)
-	// create error results
-	for i := range results {
-		if results[i].ClusterName == "" {
-			results[i] = Result{
-				ClusterName: tasksCopy[i].ClusterName,
-				Error:       fmt.Errorf("task not executed: %w", ctx.Err()),
-				Duration:    0,
-			}
+	// Hand tasks out fairly across clusters; once runCtx ends the rest are
+	// reported as not started
+	sched, wait := p.dispatch(jobs)
+	defer wait()
+	stopWaking := sched.wakeOn(runCtx)
+	defer stopWaking()
+
+	// Pass results on as they arrive
+	successCount, skippedCount := 0, 0
+	for range taskCount {
+		res := <-resultChan
+		result := res.result
+
+		if result.Error == nil {
+			successCount++
+		} else if failed, ok := firstFailed.Load().(string); ok && result.Status == StatusNotStarted {
+			// Reached after the fail-fast cancellation, never run
+			result = skippedResult[T](result.ClusterName, failed)
+			skippedCount++
+		} else if result.Status == StatusSkipped {
+			skippedCount++
 		}
+		emit(res.index, result)
 	}
 
 	totalDuration := time.Since(startTime)
-	successCount := CountSuccessful(results)
-	failureCount := taskCount - successCount
+	failureCount := taskCount - successCount - skippedCount
 
-	p.logger.Info("task execution completed",
+	attrs := []any{
 		"total", taskCount,
 		"successful", successCount,
 		"failed", failureCount,
-		"duration", totalDuration)
+		"skipped", skippedCount,
+		"duration", totalDuration,
+	}
+	if report, ok := p.ConcurrencyReport(); ok {
+		attrs = append(attrs, "concurrency", report.String())
+	}
+	p.logger.Info("task execution completed", attrs...)
+}
 
-	return results
+// dispatch queues jobs on a started pool's workers, or on workers of their
+// own if the pool isn't started; wait returns once those workers have stopped
+func (p *Pool[T]) dispatch(jobs []job[T]) (sched *scheduler[T], wait func()) {
+	p.mu.Lock()
+	sched = p.sched
+	p.mu.Unlock()
+
+	if sched != nil && sched.push(jobs...) {
+		return sched, func() {}
+	}
+
+	sched = newScheduler[T](&p.clusterLimit, &p.concurrency)
+	sched.push(jobs...)
+	sched.close()
+
+	// Don't create more workers than tasks
+	workerCount := min(p.workers, len(jobs))
+	p.logger.Debug("starting workers", "count", workerCount)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go p.worker(i, sched, &wg)
+	}
+
+	return sched, wg.Wait
 }
 
-// worker is the worker goroutine that processes tasks from the task channel
-func (p *Pool) worker(
-	ctx context.Context,
-	workerID int,
-	taskChan <-chan taskWithIndex,
-	resultChan chan<- resultWithIndex,
-	wg *sync.WaitGroup,
-	completed *atomic.Int32,
-	total int,
-	progressFn func(completed, total int),
-) {
+// skippedResult is the result of a task skipped because firstFailed failed
+func skippedResult[T any](clusterName, firstFailed string) Result[T] {
+	return Result[T]{
+		ClusterName: clusterName,
+		Error:       fmt.Errorf("%w: fail-fast after %s failed", ErrSkipped, firstFailed),
+		Status:      StatusSkipped,
+	}
+}
+
+// notStartedResult is the result of a task that never started because ctx ended
+func notStartedResult[T any](ctx context.Context, clusterName string) Result[T] {
+	return Result[T]{
+		ClusterName: clusterName,
+		Error:       fmt.Errorf("task not started: %w", context.Cause(ctx)),
+		Status:      StatusNotStarted,
+	}
+}
+
+// worker is the worker goroutine that runs jobs from sched until it is
+// closed and empty
+func (p *Pool[T]) worker(workerID int, sched *scheduler[T], wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	p.logger.Debug("worker started", "worker_id", workerID)
 
 	for {
-		select {
-		case <-ctx.Done():
-			p.logger.Debug("worker stopping due to context cancellation", "worker_id", workerID)
+		item, ok := sched.take()
+		if !ok {
+			p.logger.Debug("worker finished", "worker_id", workerID)
 			return
+		}
 
-		case taskItem, ok := <-taskChan:
-			if !ok {
-				// Channel closed, no more tasks
-				p.logger.Debug("worker finished (no more tasks)", "worker_id", workerID)
-				return
-			}
+		// Execute the task; a job whose run has ended is reported as not started
+		result := p.executeTask(item.ctx, item.task)
+		p.adapt(result)
+		sched.done(item.task.ClusterName)
 
-			// Execute the task
-			result := p.executeTask(ctx, taskItem.task)
+		p.logger.Debug("task completed",
+			"worker_id", workerID,
+			"cluster", item.task.ClusterName,
+			"success", result.Error == nil,
+			"duration", result.Duration)
 
-			// Send result
-			select {
-			case resultChan <- resultWithIndex{result: result, index: taskItem.index}:
-			case <-ctx.Done():
-				p.logger.Warn("context cancelled while sending result",
-					"worker_id", workerID,
-					"cluster", taskItem.task.ClusterName)
-				return
-			}
+		item.finish(item.index, result)
+	}
+}
 
-			// Update progress
-			completedCount := completed.Add(1)
-			p.logger.Debug("task completed",
-				"worker_id", workerID,
-				"cluster", taskItem.task.ClusterName,
-				"success", result.Error == nil,
-				"duration", result.Duration,
-				"progress", fmt.Sprintf("%d/%d", completedCount, total))
-
-			// Call progress callback if provided
-			if progressFn != nil {
-				progressFn(int(completedCount), total)
-			}
-		}
+// adapt feeds a finished task to the adaptive controller, if there is one,
+// and applies its decision before the task's slot is freed
+func (p *Pool[T]) adapt(result Result[T]) {
+	controller := p.adaptive.Load()
+	if controller == nil {
+		return
+	}
+
+	decision, changed := controller.observe(result.ClusterName, result.Status, result.Attempts, result.Duration, time.Now())
+	if !changed {
+		return
+	}
+
+	p.concurrency.Store(int32(decision.To))
+	msg := "adaptive concurrency raised"
+	if decision.To < decision.From {
+		msg = "adaptive concurrency cut"
 	}
+	p.logger.Info(msg, "from", decision.From, "to", decision.To, "reason", decision.Reason)
 }
 
 // executeTask executes a single task and returns the result
-func (p *Pool) executeTask(ctx context.Context, task Task) Result {
+func (p *Pool[T]) executeTask(ctx context.Context, task Task[T]) Result[T] {
 	startTime := time.Now()
 
 	p.logger.Debug("executing task", "cluster", task.ClusterName)
 
 	// Check context before execution
-	select {
-	case <-ctx.Done():
-		return Result{
-			ClusterName: task.ClusterName,
-			Error:       fmt.Errorf("task cancelled before execution: %w", ctx.Err()),
-			Duration:    time.Since(startTime),
-		}
-	default:
+	if ctx.Err() != nil {
+		return notStartedResult[T](ctx, task.ClusterName)
 	}
 
-	// Execute the task
-	// Note: We pass nil as the client here. In real usage, the Execute function
-	// should have the client bound via closure or the pool should maintain a client map
-	data, err := task.Execute(ctx, nil)
+	var (
+		data          T
+		err           error
+		timedOut      bool
+		attemptErrors []error
+	)
+	attempts := 0
+	for attempts < task.Retry.attempts() {
+		if attempts > 0 {
+			backoff := task.Retry.Backoff(attempts, err)
+			p.logger.Info("retrying task",
+				"cluster", task.ClusterName,
+				"attempt", attempts+1,
+				"backoff", backoff,
+				"error", err)
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+			case <-timer.C:
+			}
+			if ctx.Err() != nil {
+				// Out of time; report the last real failure
+				break
+			}
+		}
+
+		attempts++
+		attemptData, attemptTimedOut, attemptErr := p.runAttempt(ctx, task)
+		if errors.Is(attemptErr, cluster.ErrCircuitOpen) {
+			// The breaker turned the attempt away before it reached the
+			// cluster; keep the last real failure, if there was one
+			attempts--
+			if attempts == 0 {
+				err = attemptErr
+			}
+			break
+		}
+		data, timedOut, err = attemptData, attemptTimedOut, attemptErr
+		if err == nil {
+			break
+		}
+		attemptErrors = append(attemptErrors, err)
+		if ctx.Err() != nil || !task.Retry.shouldRetry(err) {
+			break
+		}
+	}
 
 	duration := time.Since(startTime)
 
-	result := Result{
-		ClusterName: task.ClusterName,
-		Data:        data,
-		Error:       err,
-		Duration:    duration,
+	result := Result[T]{
+		ClusterName:   task.ClusterName,
+		Data:          data,
+		Error:         err,
+		Duration:      duration,
+		Attempts:      attempts,
+		AttemptErrors: attemptErrors,
+		Status:        StatusSucceeded,
+	}
+
+	switch {
+	case err == nil:
+	case attempts == 0:
+		result.Status = StatusSkipped
+	case timedOut:
+		result.Status = StatusTimedOut
+		result.Error = fmt.Errorf("timed out after %s: %w", task.Timeout, err)
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.Status = StatusTimedOut
+		result.Error = fmt.Errorf("run deadline reached: %w", err)
+	case ctx.Err() != nil:
+		result.Status = StatusCancelled
+	default:
+		result.Status = StatusFailed
 	}
 
-	if err != nil {
+	if attempts == 0 {
+		p.logger.Info("task skipped",
+			"cluster", task.ClusterName,
+			"error", err)
+	} else if err != nil {
 		p.logger.Warn("task failed",
 			"cluster", task.ClusterName,
 			"error", err,
+			"attempts", attempts,
 			"duration", duration)
 	} else {
 		p.logger.Debug("task succeeded",
@@ -319,16 +752,56 @@ func (p *Pool) executeTask(ctx context.Context, task Task) Result {
 	return result
 }
 
+// runAttempt runs a task once, bounded by the task's timeout, and reports
+// whether that timeout expired while ctx was still live
+// A panic in Execute is recovered into a *PanicError so that it fails only
+// this task.
+func (p *Pool[T]) runAttempt(ctx context.Context, task Task[T]) (data T, timedOut bool, err error) {
+	attemptCtx := ctx
+	if task.Timeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, task.Timeout)
+		defer cancel()
+	}
+	defer func() {
+		timedOut = err != nil && ctx.Err() == nil && attemptCtx.Err() != nil
+	}()
+
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr := &PanicError{Value: r, Stack: debug.Stack()}
+			p.logger.Error("task panicked",
+				"cluster", task.ClusterName,
+				"panic", r,
+				"stack", string(panicErr.Stack))
+
+			var zero T
+			data, err = zero, panicErr
+		}
+	}()
+
+	data, err = task.Execute(attemptCtx, task.Client)
+	return data, false, err
+}
+
 // Shutdown gracefully shuts down the pool
 // It stops accepting new tasks and waits for in-progress tasks to complete
 // The context timeout controls how long to wait for tasks to finish
-func (p *Pool) Shutdown(ctx context.Context) error {
+func (p *Pool[T]) Shutdown(ctx context.Context) error {
 	if !p.shutdown.CompareAndSwap(false, true) {
 		return fmt.Errorf("pool already shut down")
 	}
 
 	p.logger.Info("shutting down worker pool")
 
+	// A started pool's workers finish the queued tasks, then stop
+	p.mu.Lock()
+	sched, workersDone := p.sched, p.workersDone
+	p.mu.Unlock()
+	if sched != nil {
+		sched.close()
+	}
+
 	// If the pool is currently running, wait for it to finish
 	deadline, hasDeadline := ctx.Deadline()
 	if hasDeadline {
@@ -348,40 +821,50 @@ func (p *Pool) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	if workersDone != nil {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("shutdown timeout: %w", ctx.Err())
+		case <-workersDone:
+		}
+	}
+
 	p.logger.Info("worker pool shut down successfully")
 	return nil
 }
 
 // IsShutdown returns true if the pool has been shut down
-func (p *Pool) IsShutdown() bool {
+func (p *Pool[T]) IsShutdown() bool {
 	return p.shutdown.Load()
 }
 
 // IsRunning returns true if the pool is currently executing tasks
-func (p *Pool) IsRunning() bool {
+func (p *Pool[T]) IsRunning() bool {
 	return p.running.Load()
 }
 
-// TaskCount returns the number of tasks currently queued
-func (p *Pool) TaskCount() int {
+// IsStarted returns true if the pool's workers were started with Start and
+// are still taking tasks
+func (p *Pool[T]) IsStarted() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sched != nil && !p.sched.isClosed()
+}
+
+// TaskCount returns the number of tasks queued for the next run
+func (p *Pool[T]) TaskCount() int {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	return len(p.tasks)
 }
 
 // WorkerCount returns the number of workers in the pool
-func (p *Pool) WorkerCount() int {
+func (p *Pool[T]) WorkerCount() int {
 	return p.workers
 }
 
-// taskWithIndex pairs a task with its original index for result ordering
-type taskWithIndex struct {
-	task  Task
-	index int
-}
-
 // resultWithIndex pairs a result with its original task index
-type resultWithIndex struct {
-	result Result
+type resultWithIndex[T any] struct {
+	result Result[T]
 	index  int
 }
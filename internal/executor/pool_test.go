@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/aryankumar/fleet/internal/cluster"
 )
 
 func TestNewPool(t *testing.T) {
@@ -36,7 +39,7 @@ func TestNewPool(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			pool := NewPool(tt.workers, nil)
+			pool := NewPool[any](tt.workers, nil)
 			if pool == nil {
 				t.Fatal("NewPool returned nil")
 			}
@@ -63,15 +66,15 @@ func TestNewPool(t *testing.T) {
 func TestPool_Submit(t *testing.T) {
 	tests := []struct {
 		name        string
-		task        Task
+		task        Task[any]
 		wantErr     bool
 		errContains string
 	}{
 		{
 			name: "valid task",
-			task: Task{
+			task: Task[any]{
 				ClusterName: "test-cluster",
-				Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+				Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 					return "success", nil
 				},
 			},
@@ -79,9 +82,9 @@ func TestPool_Submit(t *testing.T) {
 		},
 		{
 			name: "missing cluster name",
-			task: Task{
+			task: Task[any]{
 				ClusterName: "",
-				Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+				Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 					return nil, nil
 				},
 			},
@@ -90,7 +93,7 @@ func TestPool_Submit(t *testing.T) {
 		},
 		{
 			name: "missing execute function",
-			task: Task{
+			task: Task[any]{
 				ClusterName: "test-cluster",
 				Execute:     nil,
 			},
@@ -101,7 +104,7 @@ func TestPool_Submit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			pool := NewPool(1, slog.Default())
+			pool := NewPool[any](1, slog.Default())
 			err := pool.Submit(tt.task)
 
 			if tt.wantErr {
@@ -123,12 +126,12 @@ func TestPool_Submit(t *testing.T) {
 }
 
 func TestPool_Submit_WhileRunning(t *testing.T) {
-	pool := NewPool(1, slog.Default())
+	pool := NewPool[any](1, slog.Default())
 
 	// Submit a long-running task
-	err := pool.Submit(Task{
+	err := pool.Submit(Task[any]{
 		ClusterName: "cluster1",
-		Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+		Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 			time.Sleep(100 * time.Millisecond)
 			return "done", nil
 		},
@@ -144,24 +147,32 @@ func TestPool_Submit_WhileRunning(t *testing.T) {
 	// Wait a bit for execution to start
 	time.Sleep(10 * time.Millisecond)
 
-	// Try to submit another task while running
-	err = pool.Submit(Task{
+	// Submit another task while running; it waits for the next run
+	err = pool.Submit(Task[any]{
 		ClusterName: "cluster2",
-		Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+		Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 			return "done", nil
 		},
 	})
+	if err != nil {
+		t.Fatalf("failed to submit task while running: %v", err)
+	}
+	if pool.TaskCount() != 1 {
+		t.Errorf("expected 1 queued task, got %d", pool.TaskCount())
+	}
 
-	if err == nil {
-		t.Error("expected error when submitting while running")
+	for pool.IsRunning() {
+		time.Sleep(time.Millisecond)
 	}
-	if !contains(err.Error(), "running") {
-		t.Errorf("expected error about running, got: %v", err)
+
+	results := pool.Execute(ctx)
+	if len(results) != 1 || results[0].ClusterName != "cluster2" {
+		t.Errorf("results = %+v, want only the task submitted while running", results)
 	}
 }
 
 func TestPool_Submit_AfterShutdown(t *testing.T) {
-	pool := NewPool(1, slog.Default())
+	pool := NewPool[any](1, slog.Default())
 
 	ctx := context.Background()
 	err := pool.Shutdown(ctx)
@@ -169,9 +180,9 @@ func TestPool_Submit_AfterShutdown(t *testing.T) {
 		t.Fatalf("shutdown failed: %v", err)
 	}
 
-	err = pool.Submit(Task{
+	err = pool.Submit(Task[any]{
 		ClusterName: "cluster1",
-		Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+		Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 			return "done", nil
 		},
 	})
@@ -188,23 +199,23 @@ func TestPool_Execute(t *testing.T) {
 	tests := []struct {
 		name          string
 		workers       int
-		tasks         []Task
+		tasks         []Task[any]
 		expectedCount int
-		checkResults  func(t *testing.T, results []Result)
+		checkResults  func(t *testing.T, results []Result[any])
 	}{
 		{
 			name:    "single task",
 			workers: 1,
-			tasks: []Task{
+			tasks: []Task[any]{
 				{
 					ClusterName: "cluster1",
-					Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 						return "result1", nil
 					},
 				},
 			},
 			expectedCount: 1,
-			checkResults: func(t *testing.T, results []Result) {
+			checkResults: func(t *testing.T, results []Result[any]) {
 				if results[0].Error != nil {
 					t.Errorf("expected no error, got %v", results[0].Error)
 				}
@@ -219,34 +230,34 @@ func TestPool_Execute(t *testing.T) {
 		{
 			name:    "multiple tasks fewer workers",
 			workers: 2,
-			tasks: []Task{
+			tasks: []Task[any]{
 				{
 					ClusterName: "cluster1",
-					Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 						return "result1", nil
 					},
 				},
 				{
 					ClusterName: "cluster2",
-					Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 						return "result2", nil
 					},
 				},
 				{
 					ClusterName: "cluster3",
-					Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 						return "result3", nil
 					},
 				},
 				{
 					ClusterName: "cluster4",
-					Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 						return "result4", nil
 					},
 				},
 			},
 			expectedCount: 4,
-			checkResults: func(t *testing.T, results []Result) {
+			checkResults: func(t *testing.T, results []Result[any]) {
 				successful := CountSuccessful(results)
 				if successful != 4 {
 					t.Errorf("expected 4 successful results, got %d", successful)
@@ -256,22 +267,22 @@ func TestPool_Execute(t *testing.T) {
 		{
 			name:    "more workers than tasks",
 			workers: 10,
-			tasks: []Task{
+			tasks: []Task[any]{
 				{
 					ClusterName: "cluster1",
-					Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 						return "result1", nil
 					},
 				},
 				{
 					ClusterName: "cluster2",
-					Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 						return "result2", nil
 					},
 				},
 			},
 			expectedCount: 2,
-			checkResults: func(t *testing.T, results []Result) {
+			checkResults: func(t *testing.T, results []Result[any]) {
 				if len(results) != 2 {
 					t.Errorf("expected 2 results, got %d", len(results))
 				}
@@ -280,28 +291,28 @@ func TestPool_Execute(t *testing.T) {
 		{
 			name:    "mixed success and failure",
 			workers: 2,
-			tasks: []Task{
+			tasks: []Task[any]{
 				{
 					ClusterName: "cluster1",
-					Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 						return "success", nil
 					},
 				},
 				{
 					ClusterName: "cluster2",
-					Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 						return nil, errors.New("task failed")
 					},
 				},
 				{
 					ClusterName: "cluster3",
-					Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+					Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 						return "success", nil
 					},
 				},
 			},
 			expectedCount: 3,
-			checkResults: func(t *testing.T, results []Result) {
+			checkResults: func(t *testing.T, results []Result[any]) {
 				successful := CountSuccessful(results)
 				failed := CountFailed(results)
 				if successful != 2 {
@@ -316,7 +327,7 @@ func TestPool_Execute(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			pool := NewPool(tt.workers, slog.Default())
+			pool := NewPool[any](tt.workers, slog.Default())
 
 			// Submit all tasks
 			for _, task := range tt.tasks {
@@ -350,7 +361,7 @@ func TestPool_Execute(t *testing.T) {
 }
 
 func TestPool_Execute_Empty(t *testing.T) {
-	pool := NewPool(5, slog.Default())
+	pool := NewPool[any](5, slog.Default())
 
 	ctx := context.Background()
 	results := pool.Execute(ctx)
@@ -360,8 +371,35 @@ func TestPool_Execute_Empty(t *testing.T) {
 	}
 }
 
+func TestPool_Execute_TypedWithClient(t *testing.T) {
+	pool := NewPool[[]string](2, slog.Default())
+	clients := []*cluster.Client{{Name: "cluster-1"}, {Name: "cluster-2"}}
+
+	for _, client := range clients {
+		err := pool.Submit(Task[[]string]{
+			Client: client,
+			Execute: func(ctx context.Context, c *cluster.Client) ([]string, error) {
+				return []string{c.Name + "/pod"}, nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+
+	results := pool.Execute(context.Background())
+	for i, result := range results {
+		if result.ClusterName != clients[i].Name {
+			t.Errorf("ClusterName = %q, want the client's name %q", result.ClusterName, clients[i].Name)
+		}
+		if len(result.Data) != 1 || result.Data[0] != clients[i].Name+"/pod" {
+			t.Errorf("Data = %v, want the task's client passed to Execute", result.Data)
+		}
+	}
+}
+
 func TestPool_Execute_ContextCancellation(t *testing.T) {
-	pool := NewPool(2, slog.Default())
+	pool := NewPool[any](2, slog.Default())
 
 	// Create a context that will be cancelled
 	ctx, cancel := context.WithCancel(context.Background())
@@ -369,9 +407,9 @@ func TestPool_Execute_ContextCancellation(t *testing.T) {
 	// Submit tasks that check for cancellation
 	for i := 0; i < 5; i++ {
 		clusterName := fmt.Sprintf("cluster%d", i+1)
-		err := pool.Submit(Task{
+		err := pool.Submit(Task[any]{
 			ClusterName: clusterName,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				// Simulate work and check for cancellation
 				select {
 				case <-time.After(100 * time.Millisecond):
@@ -411,16 +449,134 @@ func TestPool_Execute_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestPool_Execute_TaskTimeout(t *testing.T) {
+	pool := NewPool[any](2, slog.Default())
+
+	slowTask := func(ctx context.Context, client *cluster.Client) (any, error) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+			return "completed", nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := pool.Submit(Task[any]{ClusterName: "short", Execute: slowTask, Timeout: 20 * time.Millisecond}); err != nil {
+		t.Fatalf("failed to submit task: %v", err)
+	}
+	if err := pool.Submit(Task[any]{ClusterName: "long", Execute: slowTask, Timeout: time.Second}); err != nil {
+		t.Fatalf("failed to submit task: %v", err)
+	}
+
+	results := pool.Execute(context.Background())
+
+	for _, r := range results {
+		switch r.ClusterName {
+		case "short":
+			if !errors.Is(r.Error, context.DeadlineExceeded) {
+				t.Errorf("expected short task to time out, got %v", r.Error)
+			}
+		case "long":
+			if r.Error != nil {
+				t.Errorf("expected long task to succeed, got %v", r.Error)
+			}
+		}
+	}
+}
+
+func TestPool_Execute_TimeoutFromTaskStart(t *testing.T) {
+	// One worker: the second task waits behind the first for longer than its
+	// timeout, but its timeout only starts when it does
+	pool := NewPool[any](1, slog.Default())
+	sleep := func(d time.Duration) func(context.Context, *cluster.Client) (any, error) {
+		return func(ctx context.Context, client *cluster.Client) (any, error) {
+			select {
+			case <-time.After(d):
+				return "completed", nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	pool.Submit(Task[any]{ClusterName: "slow", Execute: sleep(100 * time.Millisecond), Timeout: time.Second})
+	pool.Submit(Task[any]{ClusterName: "queued", Execute: sleep(10 * time.Millisecond), Timeout: 50 * time.Millisecond})
+	pool.Submit(Task[any]{ClusterName: "stuck", Execute: sleep(time.Second), Timeout: 20 * time.Millisecond})
+
+	results := pool.Execute(context.Background())
+
+	if results[0].Status != StatusSucceeded || results[1].Status != StatusSucceeded {
+		t.Errorf("statuses = %s, %s; want the queued task to get its full timeout", results[0].Status, results[1].Status)
+	}
+	if results[2].Status != StatusTimedOut || !errors.Is(results[2].Error, context.DeadlineExceeded) {
+		t.Errorf("stuck task = %s (%v), want timed out", results[2].Status, results[2].Error)
+	}
+}
+
+func TestPool_Execute_Status(t *testing.T) {
+	block := func(ctx context.Context, client *cluster.Client) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	tests := []struct {
+		name    string
+		ctx     func() (context.Context, context.CancelFunc)
+		want    []Status
+		wantErr error
+	}{
+		{
+			name: "run deadline",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return WithRunDeadline(context.Background(), 30*time.Millisecond)
+			},
+			want:    []Status{StatusTimedOut, StatusNotStarted},
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name: "cancelled by the user",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(30*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			want:    []Status{StatusCancelled, StatusNotStarted},
+			wantErr: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewPool[any](1, slog.Default())
+			pool.Submit(Task[any]{ClusterName: "running", Execute: block, Timeout: time.Minute})
+			pool.Submit(Task[any]{ClusterName: "queued", Execute: block, Timeout: time.Minute})
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			results := pool.Execute(ctx)
+			for i, want := range tt.want {
+				if results[i].Status != want || !errors.Is(results[i].Error, tt.wantErr) {
+					t.Errorf("%s: %s (%v), want %s", results[i].ClusterName, results[i].Status, results[i].Error, want)
+				}
+			}
+			if results[1].Attempts != 0 {
+				t.Errorf("queued task ran %d times, want never", results[1].Attempts)
+			}
+		})
+	}
+}
+
 func TestPool_ExecuteWithProgress(t *testing.T) {
-	pool := NewPool(2, slog.Default())
+	pool := NewPool[any](2, slog.Default())
 
 	// Submit tasks
 	taskCount := 5
 	for i := 0; i < taskCount; i++ {
 		clusterName := fmt.Sprintf("cluster%d", i+1)
-		err := pool.Submit(Task{
+		err := pool.Submit(Task[any]{
 			ClusterName: clusterName,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				time.Sleep(10 * time.Millisecond)
 				return "done", nil
 			},
@@ -487,8 +643,82 @@ func TestPool_ExecuteWithProgress(t *testing.T) {
 	progressMu.Unlock()
 }
 
+func TestPool_ExecuteStream(t *testing.T) {
+	pool := NewPool[string](2, slog.Default())
+
+	release := make(chan struct{})
+	pool.Submit(Task[string]{
+		ClusterName: "slow",
+		Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+			<-release
+			return "slow", nil
+		},
+	})
+	pool.Submit(Task[string]{
+		ClusterName: "fast",
+		Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+			return "fast", nil
+		},
+	})
+
+	stream := pool.ExecuteStream(context.Background())
+
+	// The fast result arrives while the slow task is still running
+	select {
+	case result := <-stream:
+		if result.ClusterName != "fast" || result.Data != "fast" {
+			t.Errorf("first result = %+v, want the fast cluster", result)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the fast result")
+	}
+	if !pool.IsRunning() {
+		t.Error("expected the pool to be running until the stream is drained")
+	}
+
+	close(release)
+	var rest []Result[string]
+	for result := range stream {
+		rest = append(rest, result)
+	}
+	if len(rest) != 1 || rest[0].ClusterName != "slow" {
+		t.Errorf("remaining results = %+v, want only the slow cluster", rest)
+	}
+	if pool.IsRunning() {
+		t.Error("expected the pool to stop running once the stream is closed")
+	}
+}
+
+func TestPool_ExecuteStream_Cancelled(t *testing.T) {
+	pool := NewPool[any](1, slog.Default())
+	for i := 0; i < 3; i++ {
+		pool.Submit(Task[any]{
+			ClusterName: fmt.Sprintf("cluster%d", i+1),
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
+				return "done", nil
+			},
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var results []Result[any]
+	for result := range pool.ExecuteStream(ctx) {
+		results = append(results, result)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want one per task", len(results))
+	}
+	for _, result := range results {
+		if !errors.Is(result.Error, context.Canceled) {
+			t.Errorf("%s: Error = %v, want context.Canceled", result.ClusterName, result.Error)
+		}
+	}
+}
+
 func TestPool_PartialFailures(t *testing.T) {
-	pool := NewPool(3, slog.Default())
+	pool := NewPool[any](3, slog.Default())
 
 	// Submit mix of successful and failing tasks
 	tasks := []struct {
@@ -505,9 +735,9 @@ func TestPool_PartialFailures(t *testing.T) {
 
 	for _, tc := range tasks {
 		shouldFail := tc.shouldFail
-		err := pool.Submit(Task{
+		err := pool.Submit(Task[any]{
 			ClusterName: tc.cluster,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				time.Sleep(10 * time.Millisecond)
 				if shouldFail {
 					return nil, errors.New("simulated failure")
@@ -555,15 +785,402 @@ func TestPool_PartialFailures(t *testing.T) {
 	}
 }
 
+func TestPool_Execute_Panic(t *testing.T) {
+	pool := NewPool[string](2, slog.Default())
+	pool.Submit(Task[string]{
+		ClusterName: "panics",
+		Retry:       RetryPolicy{MaxAttempts: 3, Retryable: func(error) bool { return true }},
+		Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+			var m map[string]int
+			m["boom"]++
+			return "unreachable", nil
+		},
+	})
+	pool.Submit(Task[string]{
+		ClusterName: "fine",
+		Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+			return "ok", nil
+		},
+	})
+
+	results := pool.Execute(context.Background())
+
+	var panicErr *PanicError
+	if !errors.As(results[0].Error, &panicErr) {
+		t.Fatalf("panicking task Error = %v, want a *PanicError", results[0].Error)
+	}
+	if !strings.Contains(string(panicErr.Stack), "TestPool_Execute_Panic") {
+		t.Errorf("Stack does not include the panicking function:\n%s", panicErr.Stack)
+	}
+	if results[0].Attempts != 1 || results[0].Data != "" {
+		t.Errorf("panicking task result = %+v, want one attempt and no data", results[0])
+	}
+	if results[1].Error != nil || results[1].Data != "ok" {
+		t.Errorf("other task result = %+v, want it unaffected by the panic", results[1])
+	}
+}
+
+func TestPool_FailFast(t *testing.T) {
+	pool := NewPool[string](1, slog.Default())
+	pool.SetFailFast(true)
+
+	ran := 0
+	for i := 0; i < 4; i++ {
+		pool.Submit(Task[string]{
+			ClusterName: fmt.Sprintf("cluster%d", i+1),
+			Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+				ran++
+				if i == 1 {
+					return "", errors.New("apply failed")
+				}
+				return "ok", nil
+			},
+		})
+	}
+
+	results := pool.Execute(context.Background())
+
+	if ran != 2 {
+		t.Errorf("%d tasks ran, want the rest stopped after the second failed", ran)
+	}
+	if CountSuccessful(results) != 1 || CountFailed(results) != 1 || CountSkipped(results) != 2 {
+		t.Errorf("summary = %+v, want 1 successful, 1 failed and 2 skipped", Summarize(results))
+	}
+	for _, result := range results[2:] {
+		if result.Status != StatusSkipped || !errors.Is(result.Error, ErrSkipped) || !strings.Contains(result.Error.Error(), "cluster2") {
+			t.Errorf("%s: result = %+v, want skipped because cluster2 failed", result.ClusterName, result)
+		}
+	}
+}
+
+func TestPool_CircuitOpen(t *testing.T) {
+	pool := NewPool[string](1, slog.Default())
+	pool.SetFailFast(true)
+
+	retried := 0
+	pool.Submit(Task[string]{
+		ClusterName: "open",
+		Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+			return "", fmt.Errorf("%w after 3 consecutive failures", cluster.ErrCircuitOpen)
+		},
+	})
+	pool.Submit(Task[string]{
+		ClusterName: "tripped",
+		Retry:       RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond},
+		Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+			retried++
+			if retried > 1 {
+				return "", cluster.ErrCircuitOpen
+			}
+			return "", context.DeadlineExceeded
+		},
+	})
+
+	results := pool.Execute(context.Background())
+
+	open := results[0]
+	if open.Status != StatusSkipped || open.Attempts != 0 || !errors.Is(open.Error, cluster.ErrCircuitOpen) {
+		t.Errorf("open circuit result = %+v, want skipped without an attempt", open)
+	}
+
+	// Fail-fast ignores the skipped cluster, so the next one still runs and
+	// reports its real failure rather than the circuit that opened after it
+	tripped := results[1]
+	if tripped.Status != StatusFailed || tripped.Attempts != 1 || !errors.Is(tripped.Error, context.DeadlineExceeded) {
+		t.Errorf("tripped circuit result = %+v, want one failed attempt", tripped)
+	}
+	if CountFailed(results) != 1 || CountSkipped(results) != 1 {
+		t.Errorf("summary = %+v, want 1 failed and 1 skipped", Summarize(results))
+	}
+}
+
+func TestPool_ClusterLimit(t *testing.T) {
+	pool := NewPool[string](6, slog.Default())
+	pool.SetClusterLimit(2)
+
+	var mu sync.Mutex
+	inFlight := make(map[string]int)
+	peak := make(map[string]int)
+
+	clusters := []string{"a", "b", "c"}
+	for _, name := range clusters {
+		for i := 0; i < 10; i++ {
+			pool.Submit(Task[string]{
+				ClusterName: name,
+				Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+					mu.Lock()
+					inFlight[name]++
+					peak[name] = max(peak[name], inFlight[name])
+					mu.Unlock()
+
+					time.Sleep(5 * time.Millisecond)
+
+					mu.Lock()
+					inFlight[name]--
+					mu.Unlock()
+					return fmt.Sprintf("%s-%d", name, i), nil
+				},
+			})
+		}
+	}
+
+	results := pool.Execute(context.Background())
+
+	for _, name := range clusters {
+		if peak[name] > 2 {
+			t.Errorf("cluster %s ran %d tasks at once, want at most 2", name, peak[name])
+		}
+	}
+
+	grouped := GroupByCluster(results)
+	for _, name := range clusters {
+		if len(grouped[name]) != 10 {
+			t.Errorf("cluster %s has %d results, want 10", name, len(grouped[name]))
+		}
+		for i, r := range grouped[name] {
+			if want := fmt.Sprintf("%s-%d", name, i); r.Data != want {
+				t.Errorf("cluster %s result %d = %q, want %q in submission order", name, i, r.Data, want)
+			}
+		}
+	}
+}
+
+func TestPool_FairScheduling(t *testing.T) {
+	pool := NewPool[string](1, slog.Default())
+
+	var order []string
+	submit := func(name string, count int) {
+		for i := 0; i < count; i++ {
+			pool.Submit(Task[string]{
+				ClusterName: name,
+				Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+					order = append(order, name)
+					return "ok", nil
+				},
+			})
+		}
+	}
+	submit("huge", 5)
+	submit("small-1", 1)
+	submit("small-2", 1)
+
+	pool.Execute(context.Background())
+
+	want := []string{"huge", "small-1", "small-2", "huge", "huge", "huge", "huge"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("tasks ran in order %v, want the clusters taking turns: %v", order, want)
+	}
+}
+
+func TestPool_Execute_Reuse(t *testing.T) {
+	pool := NewPool[string](2, slog.Default())
+
+	var runs atomic.Int32
+	submit := func(name string) {
+		pool.Submit(Task[string]{
+			ClusterName: name,
+			Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+				runs.Add(1)
+				return name, nil
+			},
+		})
+	}
+
+	submit("a")
+	submit("b")
+	if results := pool.Execute(context.Background()); len(results) != 2 {
+		t.Fatalf("first run returned %d results, want 2", len(results))
+	}
+	if pool.TaskCount() != 0 {
+		t.Errorf("expected the run to take the queued tasks, %d left", pool.TaskCount())
+	}
+
+	submit("c")
+	results := pool.Execute(context.Background())
+	if len(results) != 1 || results[0].Data != "c" {
+		t.Errorf("second run = %+v, want only the newly submitted task", results)
+	}
+	if runs.Load() != 3 {
+		t.Errorf("tasks ran %d times, want 3 with none run twice", runs.Load())
+	}
+}
+
+func TestPool_Start(t *testing.T) {
+	pool := NewPool[string](2, slog.Default())
+	pool.SetClusterLimit(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := pool.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := pool.Start(ctx); err == nil {
+		t.Error("expected an error starting a started pool")
+	}
+	if !pool.IsStarted() {
+		t.Fatal("expected the pool to be started")
+	}
+
+	// Several batches run on the same workers
+	for batch := 0; batch < 3; batch++ {
+		var inFlight, maxInFlight atomic.Int32
+		for i := 0; i < 4; i++ {
+			pool.Submit(Task[string]{
+				ClusterName: "shared",
+				Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+					n := inFlight.Add(1)
+					defer inFlight.Add(-1)
+					if n > maxInFlight.Load() {
+						maxInFlight.Store(n)
+					}
+					time.Sleep(time.Millisecond)
+					return fmt.Sprintf("batch-%d", batch), nil
+				},
+			})
+		}
+
+		var results []Result[string]
+		for result := range pool.ExecuteStream(context.Background()) {
+			results = append(results, result)
+		}
+		if len(results) != 4 || !AllSuccessful(results) {
+			t.Errorf("batch %d results = %+v, want 4 successful", batch, results)
+		}
+		if maxInFlight.Load() > 1 {
+			t.Errorf("batch %d ran %d tasks of one cluster at once, want the limit of 1", batch, maxInFlight.Load())
+		}
+	}
+
+	// A cancelled run reports its tasks as not started and leaves the
+	// workers running
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	cancelRun()
+	pool.Submit(Task[string]{
+		ClusterName: "cancelled",
+		Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+			return "ran", nil
+		},
+	})
+	if results := pool.Execute(runCtx); len(results) != 1 || results[0].Status != StatusNotStarted {
+		t.Errorf("cancelled run = %+v, want the task not started", results)
+	}
+	if !pool.IsStarted() {
+		t.Error("expected a cancelled run to leave the workers running")
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelShutdown()
+	if err := pool.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if pool.IsStarted() {
+		t.Error("expected the workers to stop on shutdown")
+	}
+}
+
+func TestPool_Drain(t *testing.T) {
+	pool := NewPool[string](2, slog.Default())
+	if err := pool.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer pool.Shutdown(context.Background())
+
+	submit := func(name string) {
+		err := pool.Submit(Task[string]{
+			ClusterName: name,
+			Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+				if name == "first" {
+					// Submit while Drain is running
+					pool.Submit(Task[string]{
+						ClusterName: "late",
+						Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+							return "late", nil
+						},
+					})
+				}
+				return name, nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+	submit("first")
+	submit("second")
+
+	results, err := pool.Drain(context.Background())
+	if err != nil {
+		t.Fatalf("Drain() error = %v", err)
+	}
+	var got []string
+	for _, result := range results {
+		got = append(got, result.Data)
+	}
+	if strings.Join(got, ",") != "first,second,late" {
+		t.Errorf("Drain() = %v, want every task in submission order, including the late one", got)
+	}
+	if pool.TaskCount() != 0 {
+		t.Errorf("expected an empty queue after Drain, got %d", pool.TaskCount())
+	}
+
+	results, err = pool.Drain(context.Background())
+	if err != nil || len(results) != 0 {
+		t.Errorf("second Drain() = %v, %v; want nothing left to run", results, err)
+	}
+}
+
+func TestPool_Drain_Cancelled(t *testing.T) {
+	pool := NewPool[string](1, slog.Default())
+	pool.Submit(Task[string]{
+		ClusterName: "a",
+		Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+			return "ran", nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := pool.Drain(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Drain() error = %v, want context.Canceled", err)
+	}
+	if len(results) != 1 || results[0].Status != StatusNotStarted {
+		t.Errorf("Drain() = %+v, want the task not started", results)
+	}
+}
+
+func TestPool_Reset(t *testing.T) {
+	pool := NewPool[string](1, slog.Default())
+	for _, name := range []string{"a", "b"} {
+		pool.Submit(Task[string]{
+			ClusterName: name,
+			Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+				return name, nil
+			},
+		})
+	}
+
+	if dropped := pool.Reset(); dropped != 2 {
+		t.Errorf("Reset() = %d, want 2", dropped)
+	}
+	if pool.TaskCount() != 0 {
+		t.Errorf("expected no queued tasks after Reset, got %d", pool.TaskCount())
+	}
+	if results := pool.Execute(context.Background()); len(results) != 0 {
+		t.Errorf("Execute() after Reset = %+v, want nothing run", results)
+	}
+}
+
 func TestPool_GracefulShutdown(t *testing.T) {
-	pool := NewPool(2, slog.Default())
+	pool := NewPool[any](2, slog.Default())
 
 	// Submit long-running tasks
 	for i := 0; i < 3; i++ {
 		clusterName := fmt.Sprintf("cluster%d", i+1)
-		err := pool.Submit(Task{
+		err := pool.Submit(Task[any]{
 			ClusterName: clusterName,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				time.Sleep(100 * time.Millisecond)
 				return "completed", nil
 			},
@@ -575,7 +1192,7 @@ func TestPool_GracefulShutdown(t *testing.T) {
 
 	// Start execution in background
 	ctx := context.Background()
-	var results []Result
+	var results []Result[any]
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -609,9 +1226,9 @@ func TestPool_GracefulShutdown(t *testing.T) {
 	}
 
 	// Verify we can't submit after shutdown
-	err = pool.Submit(Task{
+	err = pool.Submit(Task[any]{
 		ClusterName: "cluster4",
-		Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+		Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 			return nil, nil
 		},
 	})
@@ -621,12 +1238,12 @@ func TestPool_GracefulShutdown(t *testing.T) {
 }
 
 func TestPool_GracefulShutdown_Timeout(t *testing.T) {
-	pool := NewPool(1, slog.Default())
+	pool := NewPool[any](1, slog.Default())
 
 	// Submit a very long task
-	err := pool.Submit(Task{
+	err := pool.Submit(Task[any]{
 		ClusterName: "cluster1",
-		Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+		Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 			time.Sleep(1 * time.Second)
 			return "done", nil
 		},
@@ -656,7 +1273,7 @@ func TestPool_GracefulShutdown_Timeout(t *testing.T) {
 }
 
 func TestPool_DoubleShutdown(t *testing.T) {
-	pool := NewPool(1, slog.Default())
+	pool := NewPool[any](1, slog.Default())
 
 	ctx := context.Background()
 	err := pool.Shutdown(ctx)
@@ -676,7 +1293,7 @@ func TestPool_DoubleShutdown(t *testing.T) {
 
 func TestPool_ConcurrentExecution(t *testing.T) {
 	// This test verifies that tasks are actually executed concurrently
-	pool := NewPool(5, slog.Default())
+	pool := NewPool[any](5, slog.Default())
 
 	// Submit tasks that track their execution time
 	var startTimes sync.Map
@@ -685,9 +1302,9 @@ func TestPool_ConcurrentExecution(t *testing.T) {
 	taskCount := 10
 	for i := 0; i < taskCount; i++ {
 		clusterName := fmt.Sprintf("cluster%d", i+1)
-		err := pool.Submit(Task{
+		err := pool.Submit(Task[any]{
 			ClusterName: clusterName,
-			Execute: func(ctx context.Context, client interface{}) (interface{}, error) {
+			Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
 				cluster := clusterName
 				startTimes.Store(cluster, time.Now())
 				time.Sleep(50 * time.Millisecond)
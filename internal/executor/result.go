@@ -7,7 +7,7 @@ import (
 )
 
 // CountSuccessful returns the number of successful results (no error)
-func CountSuccessful(results []Result) int {
+func CountSuccessful[T any](results []Result[T]) int {
 	count := 0
 	for _, r := range results {
 		if r.Error == nil {
@@ -17,11 +17,28 @@ func CountSuccessful(results []Result) int {
 	return count
 }
 
-// CountFailed returns the number of failed results (has error)
-func CountFailed(results []Result) int {
+// CountFailed returns the number of failed results (has error and was not skipped)
+func CountFailed[T any](results []Result[T]) int {
 	count := 0
 	for _, r := range results {
-		if r.Error != nil {
+		if r.Error != nil && r.Status != StatusSkipped {
+			count++
+		}
+	}
+	return count
+}
+
+// CountSkipped returns the number of results for tasks skipped by fail-fast
+// or an aborted rollout
+func CountSkipped[T any](results []Result[T]) int {
+	return CountByStatus(results, StatusSkipped)
+}
+
+// CountByStatus returns the number of results with the given status
+func CountByStatus[T any](results []Result[T], status Status) int {
+	count := 0
+	for _, r := range results {
+		if r.Status == status {
 			count++
 		}
 	}
@@ -29,8 +46,8 @@ func CountFailed(results []Result) int {
 }
 
 // FilterSuccessful returns only the successful results
-func FilterSuccessful(results []Result) []Result {
-	filtered := make([]Result, 0, len(results))
+func FilterSuccessful[T any](results []Result[T]) []Result[T] {
+	filtered := make([]Result[T], 0, len(results))
 	for _, r := range results {
 		if r.Error == nil {
 			filtered = append(filtered, r)
@@ -39,11 +56,11 @@ func FilterSuccessful(results []Result) []Result {
 	return filtered
 }
 
-// FilterFailed returns only the failed results
-func FilterFailed(results []Result) []Result {
-	filtered := make([]Result, 0, len(results))
+// FilterFailed returns only the failed results, leaving out skipped ones
+func FilterFailed[T any](results []Result[T]) []Result[T] {
+	filtered := make([]Result[T], 0, len(results))
 	for _, r := range results {
-		if r.Error != nil {
+		if r.Error != nil && r.Status != StatusSkipped {
 			filtered = append(filtered, r)
 		}
 	}
@@ -51,8 +68,8 @@ func FilterFailed(results []Result) []Result {
 }
 
 // FilterByCluster returns results for a specific cluster
-func FilterByCluster(results []Result, clusterName string) []Result {
-	filtered := make([]Result, 0)
+func FilterByCluster[T any](results []Result[T], clusterName string) []Result[T] {
+	filtered := make([]Result[T], 0)
 	for _, r := range results {
 		if r.ClusterName == clusterName {
 			filtered = append(filtered, r)
@@ -63,8 +80,8 @@ func FilterByCluster(results []Result, clusterName string) []Result {
 
 // GroupByCluster groups results by cluster name
 // Returns a map where the key is the cluster name and value is a slice of results
-func GroupByCluster(results []Result) map[string][]Result {
-	grouped := make(map[string][]Result)
+func GroupByCluster[T any](results []Result[T]) map[string][]Result[T] {
+	grouped := make(map[string][]Result[T])
 	for _, r := range results {
 		grouped[r.ClusterName] = append(grouped[r.ClusterName], r)
 	}
@@ -72,7 +89,7 @@ func GroupByCluster(results []Result) map[string][]Result {
 }
 
 // AverageDuration calculates the average duration of all results
-func AverageDuration(results []Result) time.Duration {
+func AverageDuration[T any](results []Result[T]) time.Duration {
 	if len(results) == 0 {
 		return 0
 	}
@@ -86,7 +103,7 @@ func AverageDuration(results []Result) time.Duration {
 }
 
 // MaxDuration returns the maximum duration among all results
-func MaxDuration(results []Result) time.Duration {
+func MaxDuration[T any](results []Result[T]) time.Duration {
 	if len(results) == 0 {
 		return 0
 	}
@@ -101,7 +118,7 @@ func MaxDuration(results []Result) time.Duration {
 }
 
 // MinDuration returns the minimum duration among all results
-func MinDuration(results []Result) time.Duration {
+func MinDuration[T any](results []Result[T]) time.Duration {
 	if len(results) == 0 {
 		return 0
 	}
@@ -117,7 +134,7 @@ func MinDuration(results []Result) time.Duration {
 
 // GetErrors extracts all errors from results
 // Returns a slice of errors for failed results
-func GetErrors(results []Result) []error {
+func GetErrors[T any](results []Result[T]) []error {
 	errors := make([]error, 0)
 	for _, r := range results {
 		if r.Error != nil {
@@ -127,8 +144,43 @@ func GetErrors(results []Result) []error {
 	return errors
 }
 
+// SuccessfulData returns the data of the successful results, in order
+func SuccessfulData[T any](results []Result[T]) []T {
+	data := make([]T, 0, len(results))
+	for _, r := range results {
+		if r.Error == nil {
+			data = append(data, r.Data)
+		}
+	}
+	return data
+}
+
+// Untyped converts results to Result[any], for code such as the output
+// formatters that handles results of any type
+func Untyped[T any](results []Result[T]) []Result[any] {
+	untyped := make([]Result[any], len(results))
+	for i, r := range results {
+		untyped[i] = r.Untyped()
+	}
+	return untyped
+}
+
+// Untyped returns the result with its data as any
+func (r Result[T]) Untyped() Result[any] {
+	return Result[any]{
+		ClusterName:   r.ClusterName,
+		Data:          r.Data,
+		Error:         r.Error,
+		Duration:      r.Duration,
+		Attempts:      r.Attempts,
+		AttemptErrors: r.AttemptErrors,
+		Status:        r.Status,
+		Wave:          r.Wave,
+	}
+}
+
 // GetClusterNames extracts unique cluster names from results
-func GetClusterNames(results []Result) []string {
+func GetClusterNames[T any](results []Result[T]) []string {
 	seen := make(map[string]bool)
 	names := make([]string, 0)
 
@@ -147,17 +199,22 @@ type Summary struct {
 	Total       int
 	Successful  int
 	Failed      int
+	Skipped     int
 	AvgDuration time.Duration
 	MaxDuration time.Duration
 	MinDuration time.Duration
+
+	// Concurrency is set by Pool.Summarize for a pool with adaptive concurrency
+	Concurrency *ConcurrencyReport
 }
 
 // Summarize creates a summary of the results
-func Summarize(results []Result) Summary {
+func Summarize[T any](results []Result[T]) Summary {
 	return Summary{
 		Total:       len(results),
 		Successful:  CountSuccessful(results),
 		Failed:      CountFailed(results),
+		Skipped:     CountSkipped(results),
 		AvgDuration: AverageDuration(results),
 		MaxDuration: MaxDuration(results),
 		MinDuration: MinDuration(results),
@@ -171,18 +228,24 @@ func (s Summary) String() string {
 	sb.WriteString(fmt.Sprintf("Total: %d, ", s.Total))
 	sb.WriteString(fmt.Sprintf("Successful: %d, ", s.Successful))
 	sb.WriteString(fmt.Sprintf("Failed: %d", s.Failed))
+	if s.Skipped > 0 {
+		sb.WriteString(fmt.Sprintf(", Skipped: %d", s.Skipped))
+	}
 
 	if s.Total > 0 {
 		sb.WriteString(fmt.Sprintf(", Avg: %s", s.AvgDuration.Round(time.Millisecond)))
 		sb.WriteString(fmt.Sprintf(", Max: %s", s.MaxDuration.Round(time.Millisecond)))
 		sb.WriteString(fmt.Sprintf(", Min: %s", s.MinDuration.Round(time.Millisecond)))
 	}
+	if s.Concurrency != nil {
+		sb.WriteString(fmt.Sprintf(", Concurrency: %s", s.Concurrency))
+	}
 
 	return sb.String()
 }
 
 // HasErrors returns true if any results contain errors
-func HasErrors(results []Result) bool {
+func HasErrors[T any](results []Result[T]) bool {
 	for _, r := range results {
 		if r.Error != nil {
 			return true
@@ -192,12 +255,12 @@ func HasErrors(results []Result) bool {
 }
 
 // AllSuccessful returns true if all results are successful
-func AllSuccessful(results []Result) bool {
+func AllSuccessful[T any](results []Result[T]) bool {
 	return !HasErrors(results)
 }
 
 // SuccessRate returns the success rate as a percentage (0.0 to 100.0)
-func SuccessRate(results []Result) float64 {
+func SuccessRate[T any](results []Result[T]) float64 {
 	if len(results) == 0 {
 		return 0.0
 	}
@@ -205,7 +268,7 @@ func SuccessRate(results []Result) float64 {
 }
 
 // FailureRate returns the failure rate as a percentage (0.0 to 100.0)
-func FailureRate(results []Result) float64 {
+func FailureRate[T any](results []Result[T]) float64 {
 	if len(results) == 0 {
 		return 0.0
 	}
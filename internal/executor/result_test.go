@@ -9,17 +9,17 @@ import (
 func TestCountSuccessful(t *testing.T) {
 	tests := []struct {
 		name     string
-		results  []Result
+		results  []Result[any]
 		expected int
 	}{
 		{
 			name:     "empty results",
-			results:  []Result{},
+			results:  []Result[any]{},
 			expected: 0,
 		},
 		{
 			name: "all successful",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: nil},
 				{ClusterName: "c3", Error: nil},
@@ -28,7 +28,7 @@ func TestCountSuccessful(t *testing.T) {
 		},
 		{
 			name: "all failed",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: errors.New("error1")},
 				{ClusterName: "c2", Error: errors.New("error2")},
 			},
@@ -36,7 +36,7 @@ func TestCountSuccessful(t *testing.T) {
 		},
 		{
 			name: "mixed",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: errors.New("error")},
 				{ClusterName: "c3", Error: nil},
@@ -59,17 +59,17 @@ func TestCountSuccessful(t *testing.T) {
 func TestCountFailed(t *testing.T) {
 	tests := []struct {
 		name     string
-		results  []Result
+		results  []Result[any]
 		expected int
 	}{
 		{
 			name:     "empty results",
-			results:  []Result{},
+			results:  []Result[any]{},
 			expected: 0,
 		},
 		{
 			name: "all successful",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: nil},
 			},
@@ -77,7 +77,7 @@ func TestCountFailed(t *testing.T) {
 		},
 		{
 			name: "all failed",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: errors.New("error1")},
 				{ClusterName: "c2", Error: errors.New("error2")},
 				{ClusterName: "c3", Error: errors.New("error3")},
@@ -86,7 +86,7 @@ func TestCountFailed(t *testing.T) {
 		},
 		{
 			name: "mixed",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: errors.New("error")},
 				{ClusterName: "c3", Error: nil},
@@ -94,6 +94,14 @@ func TestCountFailed(t *testing.T) {
 			},
 			expected: 2,
 		},
+		{
+			name: "skipped are not failed",
+			results: []Result[any]{
+				{ClusterName: "c1", Error: errors.New("error")},
+				{ClusterName: "c2", Error: ErrSkipped, Status: StatusSkipped},
+			},
+			expected: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -107,7 +115,7 @@ func TestCountFailed(t *testing.T) {
 }
 
 func TestFilterSuccessful(t *testing.T) {
-	results := []Result{
+	results := []Result[any]{
 		{ClusterName: "c1", Error: nil, Data: "data1"},
 		{ClusterName: "c2", Error: errors.New("error"), Data: nil},
 		{ClusterName: "c3", Error: nil, Data: "data3"},
@@ -135,7 +143,7 @@ func TestFilterSuccessful(t *testing.T) {
 }
 
 func TestFilterFailed(t *testing.T) {
-	results := []Result{
+	results := []Result[any]{
 		{ClusterName: "c1", Error: nil, Data: "data1"},
 		{ClusterName: "c2", Error: errors.New("error"), Data: nil},
 		{ClusterName: "c3", Error: nil, Data: "data3"},
@@ -163,7 +171,7 @@ func TestFilterFailed(t *testing.T) {
 }
 
 func TestFilterByCluster(t *testing.T) {
-	results := []Result{
+	results := []Result[any]{
 		{ClusterName: "cluster1", Data: "data1"},
 		{ClusterName: "cluster2", Data: "data2"},
 		{ClusterName: "cluster1", Data: "data3"},
@@ -190,7 +198,7 @@ func TestFilterByCluster(t *testing.T) {
 }
 
 func TestGroupByCluster(t *testing.T) {
-	results := []Result{
+	results := []Result[any]{
 		{ClusterName: "cluster1", Data: "data1"},
 		{ClusterName: "cluster2", Data: "data2"},
 		{ClusterName: "cluster1", Data: "data3"},
@@ -220,24 +228,24 @@ func TestGroupByCluster(t *testing.T) {
 func TestAverageDuration(t *testing.T) {
 	tests := []struct {
 		name     string
-		results  []Result
+		results  []Result[any]
 		expected time.Duration
 	}{
 		{
 			name:     "empty results",
-			results:  []Result{},
+			results:  []Result[any]{},
 			expected: 0,
 		},
 		{
 			name: "single result",
-			results: []Result{
+			results: []Result[any]{
 				{Duration: 100 * time.Millisecond},
 			},
 			expected: 100 * time.Millisecond,
 		},
 		{
 			name: "multiple results",
-			results: []Result{
+			results: []Result[any]{
 				{Duration: 100 * time.Millisecond},
 				{Duration: 200 * time.Millisecond},
 				{Duration: 300 * time.Millisecond},
@@ -246,7 +254,7 @@ func TestAverageDuration(t *testing.T) {
 		},
 		{
 			name: "different durations",
-			results: []Result{
+			results: []Result[any]{
 				{Duration: 50 * time.Millisecond},
 				{Duration: 150 * time.Millisecond},
 			},
@@ -267,24 +275,24 @@ func TestAverageDuration(t *testing.T) {
 func TestMaxDuration(t *testing.T) {
 	tests := []struct {
 		name     string
-		results  []Result
+		results  []Result[any]
 		expected time.Duration
 	}{
 		{
 			name:     "empty results",
-			results:  []Result{},
+			results:  []Result[any]{},
 			expected: 0,
 		},
 		{
 			name: "single result",
-			results: []Result{
+			results: []Result[any]{
 				{Duration: 100 * time.Millisecond},
 			},
 			expected: 100 * time.Millisecond,
 		},
 		{
 			name: "multiple results",
-			results: []Result{
+			results: []Result[any]{
 				{Duration: 100 * time.Millisecond},
 				{Duration: 500 * time.Millisecond},
 				{Duration: 200 * time.Millisecond},
@@ -306,24 +314,24 @@ func TestMaxDuration(t *testing.T) {
 func TestMinDuration(t *testing.T) {
 	tests := []struct {
 		name     string
-		results  []Result
+		results  []Result[any]
 		expected time.Duration
 	}{
 		{
 			name:     "empty results",
-			results:  []Result{},
+			results:  []Result[any]{},
 			expected: 0,
 		},
 		{
 			name: "single result",
-			results: []Result{
+			results: []Result[any]{
 				{Duration: 100 * time.Millisecond},
 			},
 			expected: 100 * time.Millisecond,
 		},
 		{
 			name: "multiple results",
-			results: []Result{
+			results: []Result[any]{
 				{Duration: 100 * time.Millisecond},
 				{Duration: 50 * time.Millisecond},
 				{Duration: 200 * time.Millisecond},
@@ -343,7 +351,7 @@ func TestMinDuration(t *testing.T) {
 }
 
 func TestGetErrors(t *testing.T) {
-	results := []Result{
+	results := []Result[any]{
 		{ClusterName: "c1", Error: nil},
 		{ClusterName: "c2", Error: errors.New("error1")},
 		{ClusterName: "c3", Error: nil},
@@ -366,17 +374,17 @@ func TestGetErrors(t *testing.T) {
 func TestGetClusterNames(t *testing.T) {
 	tests := []struct {
 		name     string
-		results  []Result
+		results  []Result[any]
 		expected int
 	}{
 		{
 			name:     "empty results",
-			results:  []Result{},
+			results:  []Result[any]{},
 			expected: 0,
 		},
 		{
 			name: "unique clusters",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1"},
 				{ClusterName: "c2"},
 				{ClusterName: "c3"},
@@ -385,7 +393,7 @@ func TestGetClusterNames(t *testing.T) {
 		},
 		{
 			name: "duplicate clusters",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1"},
 				{ClusterName: "c2"},
 				{ClusterName: "c1"},
@@ -416,7 +424,7 @@ func TestGetClusterNames(t *testing.T) {
 }
 
 func TestSummarize(t *testing.T) {
-	results := []Result{
+	results := []Result[any]{
 		{ClusterName: "c1", Error: nil, Duration: 100 * time.Millisecond},
 		{ClusterName: "c2", Error: errors.New("error"), Duration: 200 * time.Millisecond},
 		{ClusterName: "c3", Error: nil, Duration: 300 * time.Millisecond},
@@ -500,17 +508,17 @@ func TestSummary_String_Empty(t *testing.T) {
 func TestHasErrors(t *testing.T) {
 	tests := []struct {
 		name     string
-		results  []Result
+		results  []Result[any]
 		expected bool
 	}{
 		{
 			name:     "empty results",
-			results:  []Result{},
+			results:  []Result[any]{},
 			expected: false,
 		},
 		{
 			name: "no errors",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: nil},
 			},
@@ -518,7 +526,7 @@ func TestHasErrors(t *testing.T) {
 		},
 		{
 			name: "has errors",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: errors.New("error")},
 			},
@@ -539,17 +547,17 @@ func TestHasErrors(t *testing.T) {
 func TestAllSuccessful(t *testing.T) {
 	tests := []struct {
 		name     string
-		results  []Result
+		results  []Result[any]
 		expected bool
 	}{
 		{
 			name:     "empty results",
-			results:  []Result{},
+			results:  []Result[any]{},
 			expected: true,
 		},
 		{
 			name: "all successful",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: nil},
 			},
@@ -557,7 +565,7 @@ func TestAllSuccessful(t *testing.T) {
 		},
 		{
 			name: "has failures",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: errors.New("error")},
 			},
@@ -578,17 +586,17 @@ func TestAllSuccessful(t *testing.T) {
 func TestSuccessRate(t *testing.T) {
 	tests := []struct {
 		name     string
-		results  []Result
+		results  []Result[any]
 		expected float64
 	}{
 		{
 			name:     "empty results",
-			results:  []Result{},
+			results:  []Result[any]{},
 			expected: 0.0,
 		},
 		{
 			name: "all successful",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: nil},
 				{ClusterName: "c3", Error: nil},
@@ -597,7 +605,7 @@ func TestSuccessRate(t *testing.T) {
 		},
 		{
 			name: "all failed",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: errors.New("error")},
 				{ClusterName: "c2", Error: errors.New("error")},
 			},
@@ -605,7 +613,7 @@ func TestSuccessRate(t *testing.T) {
 		},
 		{
 			name: "50% success",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: errors.New("error")},
 			},
@@ -613,7 +621,7 @@ func TestSuccessRate(t *testing.T) {
 		},
 		{
 			name: "75% success",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: nil},
 				{ClusterName: "c3", Error: nil},
@@ -636,17 +644,17 @@ func TestSuccessRate(t *testing.T) {
 func TestFailureRate(t *testing.T) {
 	tests := []struct {
 		name     string
-		results  []Result
+		results  []Result[any]
 		expected float64
 	}{
 		{
 			name:     "empty results",
-			results:  []Result{},
+			results:  []Result[any]{},
 			expected: 0.0,
 		},
 		{
 			name: "all successful",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: nil},
 			},
@@ -654,7 +662,7 @@ func TestFailureRate(t *testing.T) {
 		},
 		{
 			name: "all failed",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: errors.New("error")},
 				{ClusterName: "c2", Error: errors.New("error")},
 			},
@@ -662,7 +670,7 @@ func TestFailureRate(t *testing.T) {
 		},
 		{
 			name: "50% failure",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: errors.New("error")},
 			},
@@ -670,7 +678,7 @@ func TestFailureRate(t *testing.T) {
 		},
 		{
 			name: "25% failure",
-			results: []Result{
+			results: []Result[any]{
 				{ClusterName: "c1", Error: nil},
 				{ClusterName: "c2", Error: nil},
 				{ClusterName: "c3", Error: nil},
@@ -705,3 +713,31 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestSuccessfulData(t *testing.T) {
+	results := []Result[[]string]{
+		{ClusterName: "cluster-1", Data: []string{"a", "b"}},
+		{ClusterName: "cluster-2", Error: errors.New("failed")},
+		{ClusterName: "cluster-3", Data: []string{"c"}},
+	}
+
+	data := SuccessfulData(results)
+	if len(data) != 2 || len(data[0]) != 2 || data[1][0] != "c" {
+		t.Errorf("SuccessfulData() = %v, want the data of cluster-1 and cluster-3", data)
+	}
+}
+
+func TestUntyped(t *testing.T) {
+	results := []Result[int]{
+		{ClusterName: "cluster-1", Data: 3, Attempts: 2, AttemptErrors: []error{errors.New("timeout")}},
+		{ClusterName: "cluster-2", Error: errors.New("failed")},
+	}
+
+	untyped := Untyped(results)
+	if len(untyped) != 2 || untyped[0].Data != 3 || untyped[0].Attempts != 2 || len(untyped[0].AttemptErrors) != 1 {
+		t.Errorf("Untyped() = %+v, want the data and attempts carried over", untyped)
+	}
+	if untyped[1].Error == nil || untyped[1].ClusterName != "cluster-2" {
+		t.Errorf("Untyped()[1] = %+v, want the error carried over", untyped[1])
+	}
+}
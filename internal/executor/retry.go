@@ -0,0 +1,141 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Default retry backoff settings
+const (
+	DefaultBaseBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff  = 10 * time.Second
+	DefaultJitter      = 0.2
+)
+
+// RetryPolicy controls how a failed task is retried
+// The zero value runs a task once.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first; values
+	// below 2 disable retries
+	MaxAttempts int
+
+	// BaseBackoff is the wait before the first retry; it doubles for each
+	// further retry up to MaxBackoff
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+
+	// Jitter randomises each wait by up to this fraction (0.2 means ±20%) so
+	// that tasks failing together don't retry in lockstep
+	Jitter float64
+
+	// Retryable decides which errors are worth retrying; nil uses IsRetryableError
+	Retryable func(error) bool
+}
+
+// NewRetryPolicy returns a policy that retries up to retries times with the
+// default backoff
+func NewRetryPolicy(retries int) RetryPolicy {
+	if retries <= 0 {
+		return RetryPolicy{}
+	}
+	return RetryPolicy{
+		MaxAttempts: retries + 1,
+		BaseBackoff: DefaultBaseBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+		Jitter:      DefaultJitter,
+	}
+}
+
+// attempts returns the number of attempts the policy allows, at least 1
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// shouldRetry reports whether err is worth another attempt; a panic never is
+func (p RetryPolicy) shouldRetry(err error) bool {
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		return false
+	}
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryableError(err)
+}
+
+// Backoff returns how long to wait before retry number retry (1 for the first
+// retry) after err. A server's Retry-After hint is honoured if it is longer.
+func (p RetryPolicy) Backoff(retry int, err error) time.Duration {
+	base, max := p.BaseBackoff, p.MaxBackoff
+	if base <= 0 {
+		base = DefaultBaseBackoff
+	}
+	if max <= 0 {
+		max = DefaultMaxBackoff
+	}
+
+	backoff := base
+	for i := 1; i < retry && backoff < max; i++ {
+		backoff *= 2
+	}
+	if p.Jitter > 0 {
+		backoff += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(backoff))
+	}
+	if backoff > max {
+		backoff = max
+	}
+
+	if hint := retryAfter(err); hint > backoff {
+		backoff = hint
+	}
+	return backoff
+}
+
+// retryAfter returns the delay a server or a util.RetryableError asked for
+func retryAfter(err error) time.Duration {
+	var retryErr *util.RetryableError
+	if errors.As(err, &retryErr) && retryErr.RetryAfter > 0 {
+		return time.Duration(retryErr.RetryAfter) * time.Second
+	}
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return 0
+}
+
+// IsRetryableError reports whether err is likely to be transient: errors marked
+// with util.RetryableError, timeouts, 429 Too Many Requests, 5xx responses and
+// connection resets. Cancellation is never retried.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var netErr net.Error
+	switch {
+	case util.IsRetryable(err), util.IsTimeout(err), util.IsConnectionError(err):
+		return true
+	case errors.Is(err, context.DeadlineExceeded):
+		return true
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), apierrors.IsTooManyRequests(err):
+		return true
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return true
+	}
+
+	var status apierrors.APIStatus
+	return errors.As(err, &status) && status.Status().Code >= 500
+}
@@ -0,0 +1,166 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/cluster"
+	"github.com/aryankumar/fleet/internal/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	resource := schema.GroupResource{Resource: "pods"}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "marked retryable", err: util.NewRetryableError(errors.New("flaky"), 0), want: true},
+		{name: "deadline exceeded", err: fmt.Errorf("list pods: %w", context.DeadlineExceeded), want: true},
+		{name: "cancelled", err: context.Canceled, want: false},
+		{name: "too many requests", err: apierrors.NewTooManyRequests("slow down", 1), want: true},
+		{name: "service unavailable", err: apierrors.NewServiceUnavailable("etcd down"), want: true},
+		{name: "internal error", err: apierrors.NewInternalError(errors.New("boom")), want: true},
+		{name: "connection reset", err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, want: true},
+		{name: "not found", err: apierrors.NewNotFound(resource, "web"), want: false},
+		{name: "forbidden", err: apierrors.NewForbidden(resource, "web", errors.New("denied")), want: false},
+		{name: "plain error", err: errors.New("invalid manifest"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryableError(tt.err); got != tt.want {
+				t.Errorf("IsRetryableError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	err := errors.New("timeout")
+
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, w := range want {
+		if got := policy.Backoff(i+1, err); got != w {
+			t.Errorf("Backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+
+	// A server's Retry-After wins over a shorter backoff
+	if got := policy.Backoff(1, apierrors.NewTooManyRequests("slow down", 2)); got != 2*time.Second {
+		t.Errorf("Backoff() with Retry-After = %v, want 2s", got)
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		if got := policy.Backoff(1, err); got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("jittered Backoff(1) = %v, want within 50ms of 100ms", got)
+		}
+	}
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	if policy := NewRetryPolicy(0); policy.attempts() != 1 {
+		t.Errorf("NewRetryPolicy(0) allows %d attempts, want 1", policy.attempts())
+	}
+
+	policy := NewRetryPolicy(2)
+	if policy.MaxAttempts != 3 || policy.BaseBackoff != DefaultBaseBackoff {
+		t.Errorf("NewRetryPolicy(2) = %+v, want 3 attempts with the default backoff", policy)
+	}
+}
+
+func TestPool_Retry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	tests := []struct {
+		name         string
+		failures     []error
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "succeeds first time",
+			wantAttempts: 1,
+		},
+		{
+			name:         "recovers from transient errors",
+			failures:     []error{context.DeadlineExceeded, apierrors.NewServiceUnavailable("down")},
+			wantAttempts: 3,
+		},
+		{
+			name:         "gives up after max attempts",
+			failures:     []error{context.DeadlineExceeded, context.DeadlineExceeded, context.DeadlineExceeded},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "does not retry permanent errors",
+			failures:     []error{errors.New("invalid manifest")},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			pool := NewPool[any](1, nil)
+			pool.Submit(Task[any]{
+				ClusterName: "cluster-1",
+				Retry:       policy,
+				Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
+					calls++
+					if calls <= len(tt.failures) {
+						return nil, tt.failures[calls-1]
+					}
+					return "ok", nil
+				},
+			})
+
+			result := pool.Execute(context.Background())[0]
+			if result.Attempts != tt.wantAttempts || calls != tt.wantAttempts {
+				t.Errorf("Attempts = %d (calls %d), want %d", result.Attempts, calls, tt.wantAttempts)
+			}
+			if (result.Error != nil) != tt.wantErr {
+				t.Errorf("Error = %v, wantErr %v", result.Error, tt.wantErr)
+			}
+			if len(result.AttemptErrors) != min(len(tt.failures), tt.wantAttempts) {
+				t.Errorf("AttemptErrors = %v, want one per failed attempt", result.AttemptErrors)
+			}
+		})
+	}
+}
+
+func TestPool_RetryStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	pool := NewPool[any](1, nil)
+	pool.Submit(Task[any]{
+		ClusterName: "cluster-1",
+		Retry:       RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Hour, MaxBackoff: time.Hour},
+		Execute: func(ctx context.Context, client *cluster.Client) (any, error) {
+			return nil, apierrors.NewServiceUnavailable("down")
+		},
+	})
+
+	start := time.Now()
+	result := pool.Execute(ctx)[0]
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Execute() took %v, want it to stop waiting when the context ends", elapsed)
+	}
+	if result.Attempts != 1 || !apierrors.IsServiceUnavailable(result.Error) {
+		t.Errorf("result = %+v, want the first attempt's error", result)
+	}
+}
@@ -0,0 +1,175 @@
+package executor
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// job is a task handed to the workers by a run
+type job[T any] struct {
+	task  Task[T]
+	index int
+
+	// ctx is the run's context; a job whose ctx has ended is reported as not
+	// started instead of being run
+	ctx context.Context
+
+	// finish receives the job's result on the worker that ran it
+	finish func(index int, result Result[T])
+}
+
+// scheduler hands jobs to workers fairly across clusters
+// Each cluster has its own queue in submission order. Workers take from the
+// clusters in turn, so a cluster with many tasks can't starve the rest, and
+// a cluster at its in-flight limit is passed over until one of its tasks
+// finishes. A run's jobs are queued on an ephemeral scheduler that is closed
+// straight away, or on the long-lived one of a started pool, which stays open
+// until the pool shuts down.
+type scheduler[T any] struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	// clusters lists the clusters in the order they first appear in the jobs
+	clusters []string
+
+	// queues holds each cluster's jobs that haven't been taken yet
+	queues map[string][]job[T]
+
+	// inFlight counts each cluster's jobs that have been taken but not done
+	inFlight map[string]int
+
+	// limit is the per-cluster in-flight limit; zero means none
+	limit *atomic.Int32
+
+	// concurrency caps the jobs in flight across all clusters, below the
+	// number of workers; zero means none
+	concurrency *atomic.Int32
+
+	// active is the number of jobs taken but not done
+	active int
+
+	// next is the index in clusters to look at first on the next take
+	next int
+
+	// queued is the number of jobs left in queues
+	queued int
+
+	// closed is set once no more jobs will be pushed; workers stop when the
+	// queues are empty
+	closed bool
+}
+
+// newScheduler returns an open scheduler with no jobs; limit and concurrency
+// are read on every take so that they can change while the scheduler is in use
+func newScheduler[T any](limit, concurrency *atomic.Int32) *scheduler[T] {
+	s := &scheduler[T]{
+		queues:      make(map[string][]job[T]),
+		inFlight:    make(map[string]int),
+		limit:       limit,
+		concurrency: concurrency,
+	}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// push queues jobs behind the ones already queued for their clusters
+// It returns false, queuing nothing, if the scheduler is closed.
+func (s *scheduler[T]) push(jobs ...job[T]) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	for _, j := range jobs {
+		name := j.task.ClusterName
+		if _, ok := s.queues[name]; !ok {
+			s.clusters = append(s.clusters, name)
+		}
+		s.queues[name] = append(s.queues[name], j)
+	}
+	s.queued += len(jobs)
+	s.cond.Broadcast()
+
+	return true
+}
+
+// close stops the scheduler accepting jobs; the queued jobs are still handed out
+func (s *scheduler[T]) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	s.cond.Broadcast()
+}
+
+// isClosed reports whether close has been called
+func (s *scheduler[T]) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// wakeOn wakes the waiting workers when ctx ends, so that jobs of a cancelled
+// run held back by a limit are reported without waiting
+func (s *scheduler[T]) wakeOn(ctx context.Context) func() bool {
+	return context.AfterFunc(ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.cond.Broadcast()
+	})
+}
+
+// take waits for the next job a worker may run
+// It returns false once the scheduler is closed and every job has been taken.
+// A job whose context has ended is handed out even if its cluster or the
+// concurrency is at its limit, since it will not run.
+func (s *scheduler[T]) take() (job[T], bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		limit := int(s.limit.Load())
+		concurrency := int(s.concurrency.Load())
+		full := concurrency > 0 && s.active >= concurrency
+		for i := range s.clusters {
+			idx := (s.next + i) % len(s.clusters)
+			name := s.clusters[idx]
+			queue := s.queues[name]
+			if len(queue) == 0 {
+				continue
+			}
+
+			item := queue[0]
+			if (full || limit > 0 && s.inFlight[name] >= limit) && item.ctx.Err() == nil {
+				continue
+			}
+
+			s.queues[name] = queue[1:]
+			s.inFlight[name]++
+			s.active++
+			s.queued--
+			s.next = idx + 1
+			return item, true
+		}
+
+		if s.closed && s.queued == 0 {
+			return job[T]{}, false
+		}
+
+		// No jobs queued, or every cluster with jobs left is at a limit
+		s.cond.Wait()
+	}
+}
+
+// done marks a job taken for clusterName as finished
+func (s *scheduler[T]) done(clusterName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inFlight[clusterName]--
+	s.active--
+	s.cond.Broadcast()
+}
@@ -0,0 +1,275 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Strategies for running a pool's tasks
+const (
+	// StrategyAll runs every task at once, bounded by the pool's workers
+	StrategyAll = "all"
+
+	// StrategyWaves runs tasks in waves of clusters, canaries first
+	StrategyWaves = "waves"
+)
+
+// ErrRolloutAborted marks the results of tasks that were never run because a
+// wave rollout stopped early
+var ErrRolloutAborted = errors.New("rollout aborted")
+
+// Threshold is a number of clusters given either as a count or as a
+// percentage of all clusters
+type Threshold struct {
+	// Count is used when Percent is zero
+	Count int
+
+	// Percent is a percentage of all clusters, from 0 to 100
+	Percent float64
+}
+
+// ParseThreshold parses a threshold such as "3" or "25%"
+// An empty string is the zero threshold.
+func ParseThreshold(s string) (Threshold, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Threshold{}, nil
+	}
+
+	if strings.HasSuffix(s, "%") {
+		percent, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, "%")), 64)
+		if err != nil || percent < 0 || percent > 100 {
+			return Threshold{}, fmt.Errorf("invalid percentage %q: must be between 0%% and 100%%", s)
+		}
+		return Threshold{Percent: percent}, nil
+	}
+
+	count, err := strconv.Atoi(s)
+	if err != nil || count < 0 {
+		return Threshold{}, fmt.Errorf("invalid value %q: must be a count such as 3 or a percentage such as 25%%", s)
+	}
+	return Threshold{Count: count}, nil
+}
+
+// String returns the threshold in the form ParseThreshold accepts
+func (t Threshold) String() string {
+	if t.Percent > 0 {
+		return strconv.FormatFloat(t.Percent, 'f', -1, 64) + "%"
+	}
+	return strconv.Itoa(t.Count)
+}
+
+// Of returns the threshold as a number of clusters out of total
+// Percentages are rounded up so that a non-zero threshold is at least 1.
+func (t Threshold) Of(total int) int {
+	if t.Percent > 0 {
+		return max(1, int(math.Ceil(float64(total)*t.Percent/100)))
+	}
+	return t.Count
+}
+
+// Exceeded reports whether failed clusters out of total is more than the
+// threshold allows; the zero threshold is exceeded by any failure
+func (t Threshold) Exceeded(failed, total int) bool {
+	if t.Percent > 0 {
+		return total > 0 && float64(failed)*100 > t.Percent*float64(total)
+	}
+	return failed > t.Count
+}
+
+// Wave is one batch of clusters in a wave rollout
+type Wave struct {
+	// Number is the wave's position, starting at 1
+	Number int
+
+	// Total is the number of waves in the rollout
+	Total int
+
+	// Clusters are the clusters in this wave
+	Clusters []string
+
+	// Canary is true for the wave of canary clusters
+	Canary bool
+}
+
+// WaveOptions configures a wave rollout
+type WaveOptions struct {
+	// Canary lists clusters to run first, in a wave of their own; names that
+	// have no tasks are ignored
+	Canary []string
+
+	// Size is the number of clusters in each wave after the canaries, as a
+	// count or a percentage of all clusters; zero puts them all in one wave
+	Size Threshold
+
+	// MaxFailures aborts the rollout after a wave once more clusters than this
+	// have failed; the zero value aborts on any failure
+	MaxFailures Threshold
+
+	// Pause is how long to wait between waves
+	Pause time.Duration
+
+	// Gate is called after every wave but the last; an error aborts the
+	// rollout. It is the place for health checks.
+	Gate func(ctx context.Context, wave Wave) error
+}
+
+// PlanWaves splits clusters into waves: the canaries first, then the other
+// clusters in name order, Size at a time
+func PlanWaves(clusters []string, opts WaveOptions) []Wave {
+	var canaries, rest []string
+	for _, name := range opts.Canary {
+		if slices.Contains(clusters, name) && !slices.Contains(canaries, name) {
+			canaries = append(canaries, name)
+		}
+	}
+	for _, name := range clusters {
+		if !slices.Contains(canaries, name) && !slices.Contains(rest, name) {
+			rest = append(rest, name)
+		}
+	}
+	slices.Sort(rest)
+
+	var waves []Wave
+	if len(canaries) > 0 {
+		waves = append(waves, Wave{Clusters: canaries, Canary: true})
+	}
+
+	size := opts.Size.Of(len(canaries) + len(rest))
+	if size <= 0 {
+		size = len(rest)
+	}
+	for chunk := range slices.Chunk(rest, max(size, 1)) {
+		waves = append(waves, Wave{Clusters: chunk})
+	}
+
+	for i := range waves {
+		waves[i].Number = i + 1
+		waves[i].Total = len(waves)
+	}
+	return waves
+}
+
+// ExecuteWaves runs the tasks one wave of clusters at a time, as planned by
+// PlanWaves, and streams the results like ExecuteStream with Result.Wave set
+// After each wave but the last the rollout is aborted if MaxFailures is
+// exceeded; otherwise it pauses and runs the gate. Tasks of the waves that
+// never ran are skipped, with an error wrapping ErrRolloutAborted.
+func (p *Pool[T]) ExecuteWaves(ctx context.Context, opts WaveOptions) <-chan Result[T] {
+	tasks, ok := p.start()
+	stream := make(chan Result[T], len(tasks))
+	if !ok {
+		close(stream)
+		return stream
+	}
+
+	go func() {
+		defer close(stream)
+		defer p.running.Store(false)
+
+		p.runWaves(ctx, tasks, opts, func(result Result[T]) {
+			stream <- result
+		})
+	}()
+
+	return stream
+}
+
+// runWaves runs tasks wave by wave and calls emit for every result
+func (p *Pool[T]) runWaves(ctx context.Context, tasks []Task[T], opts WaveOptions, emit func(Result[T])) {
+	// Group tasks by cluster, keeping submission order
+	var clusters []string
+	byCluster := make(map[string][]Task[T])
+	for _, task := range tasks {
+		if _, ok := byCluster[task.ClusterName]; !ok {
+			clusters = append(clusters, task.ClusterName)
+		}
+		byCluster[task.ClusterName] = append(byCluster[task.ClusterName], task)
+	}
+
+	waves := PlanWaves(clusters, opts)
+	failed := 0
+
+	for i, wave := range waves {
+		p.logger.Info("starting wave",
+			"wave", wave.Number,
+			"waves", wave.Total,
+			"canary", wave.Canary,
+			"clusters", wave.Clusters)
+
+		var waveTasks []Task[T]
+		for _, name := range wave.Clusters {
+			waveTasks = append(waveTasks, byCluster[name]...)
+		}
+
+		failedClusters := make(map[string]bool)
+		p.run(ctx, waveTasks, nil, func(_ int, result Result[T]) {
+			if result.Error != nil && result.Status != StatusSkipped {
+				failedClusters[result.ClusterName] = true
+			}
+			result.Wave = wave.Number
+			emit(result)
+		})
+		failed += len(failedClusters)
+
+		if i == len(waves)-1 {
+			return
+		}
+
+		reason := p.checkWave(ctx, wave, failed, len(clusters), opts)
+		if reason == "" {
+			continue
+		}
+
+		p.logger.Warn("rollout aborted", "wave", wave.Number, "reason", reason)
+		for _, skipped := range waves[i+1:] {
+			for _, name := range skipped.Clusters {
+				for _, task := range byCluster[name] {
+					emit(Result[T]{
+						ClusterName: task.ClusterName,
+						Error:       fmt.Errorf("%w after wave %d: %s", ErrRolloutAborted, wave.Number, reason),
+						Status:      StatusSkipped,
+						Wave:        skipped.Number,
+					})
+				}
+			}
+		}
+		return
+	}
+}
+
+// checkWave decides whether the rollout may go on after wave, pausing and
+// running the gate if so; it returns why the rollout should stop, or ""
+func (p *Pool[T]) checkWave(ctx context.Context, wave Wave, failed, total int, opts WaveOptions) string {
+	if failed > 0 && p.failFast.Load() {
+		return fmt.Sprintf("%d of %d clusters failed (fail-fast)", failed, total)
+	}
+	if opts.MaxFailures.Exceeded(failed, total) {
+		return fmt.Sprintf("%d of %d clusters failed (max failures %s)", failed, total, opts.MaxFailures)
+	}
+
+	if opts.Pause > 0 {
+		p.logger.Info("pausing before next wave", "wave", wave.Number, "pause", opts.Pause)
+		timer := time.NewTimer(opts.Pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err().Error()
+		case <-timer.C:
+		}
+	}
+
+	if opts.Gate != nil {
+		if err := opts.Gate(ctx, wave); err != nil {
+			return fmt.Sprintf("gate failed: %v", err)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,257 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/cluster"
+)
+
+func TestParseThreshold(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Threshold
+		wantErr bool
+	}{
+		{in: "", want: Threshold{}},
+		{in: "3", want: Threshold{Count: 3}},
+		{in: "25%", want: Threshold{Percent: 25}},
+		{in: " 12.5% ", want: Threshold{Percent: 12.5}},
+		{in: "-1", wantErr: true},
+		{in: "150%", wantErr: true},
+		{in: "a few", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseThreshold(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseThreshold(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseThreshold(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	quarter := Threshold{Percent: 25}
+	if got := quarter.Of(10); got != 3 {
+		t.Errorf("25%% of 10 = %d, want 3 (rounded up)", got)
+	}
+	if got := quarter.Of(1); got != 1 {
+		t.Errorf("25%% of 1 = %d, want at least 1", got)
+	}
+	if quarter.Exceeded(2, 8) || !quarter.Exceeded(3, 8) {
+		t.Error("expected 25% to allow 2 of 8 failures but not 3")
+	}
+
+	if (Threshold{}).Exceeded(0, 5) || !(Threshold{}).Exceeded(1, 5) {
+		t.Error("expected the zero threshold to be exceeded by the first failure")
+	}
+	if (Threshold{Count: 1}).Exceeded(1, 5) || !(Threshold{Count: 1}).Exceeded(2, 5) {
+		t.Error("expected a count of 1 to allow exactly one failure")
+	}
+}
+
+func TestPlanWaves(t *testing.T) {
+	clusters := []string{"e", "c", "a", "d", "b"}
+
+	tests := []struct {
+		name string
+		opts WaveOptions
+		want [][]string
+	}{
+		{
+			name: "canary then count",
+			opts: WaveOptions{Canary: []string{"d"}, Size: Threshold{Count: 2}},
+			want: [][]string{{"d"}, {"a", "b"}, {"c", "e"}},
+		},
+		{
+			name: "percentage of all clusters",
+			opts: WaveOptions{Size: Threshold{Percent: 40}},
+			want: [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+		},
+		{
+			name: "unknown canaries are ignored",
+			opts: WaveOptions{Canary: []string{"z", "b"}},
+			want: [][]string{{"b"}, {"a", "c", "d", "e"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waves := PlanWaves(clusters, tt.opts)
+
+			var got [][]string
+			for i, wave := range waves {
+				got = append(got, wave.Clusters)
+				if wave.Number != i+1 || wave.Total != len(waves) {
+					t.Errorf("wave %d numbered %d of %d", i, wave.Number, wave.Total)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlanWaves() = %v, want %v", got, tt.want)
+			}
+			if waves[0].Canary != (len(tt.opts.Canary) > 0) {
+				t.Errorf("first wave Canary = %v", waves[0].Canary)
+			}
+		})
+	}
+}
+
+// submitWaveTasks submits one task per cluster that fails for the named clusters
+func submitWaveTasks(t *testing.T, pool *Pool[string], clusters []string, failing ...string) {
+	t.Helper()
+
+	for _, name := range clusters {
+		fail := false
+		for _, f := range failing {
+			fail = fail || f == name
+		}
+		err := pool.Submit(Task[string]{
+			ClusterName: name,
+			Execute: func(ctx context.Context, client *cluster.Client) (string, error) {
+				if fail {
+					return "", errors.New("apply failed")
+				}
+				return "ok", nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+}
+
+func TestPool_ExecuteWaves(t *testing.T) {
+	clusters := []string{"a", "b", "c", "d", "e"}
+	pool := NewPool[string](5, slog.Default())
+	submitWaveTasks(t, pool, clusters)
+
+	var gated []int
+	opts := WaveOptions{
+		Canary: []string{"c"},
+		Size:   Threshold{Count: 2},
+		Gate: func(ctx context.Context, wave Wave) error {
+			gated = append(gated, wave.Number)
+			return nil
+		},
+	}
+
+	waveOf := make(map[string]int)
+	for result := range pool.ExecuteWaves(context.Background(), opts) {
+		if result.Error != nil {
+			t.Errorf("%s: unexpected error %v", result.ClusterName, result.Error)
+		}
+		waveOf[result.ClusterName] = result.Wave
+	}
+
+	want := map[string]int{"c": 1, "a": 2, "b": 2, "d": 3, "e": 3}
+	if !reflect.DeepEqual(waveOf, want) {
+		t.Errorf("waves = %v, want %v", waveOf, want)
+	}
+	if !reflect.DeepEqual(gated, []int{1, 2}) {
+		t.Errorf("gate ran after waves %v, want after every wave but the last", gated)
+	}
+}
+
+func TestPool_ExecuteWaves_Abort(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        WaveOptions
+		failFast    bool
+		failing     []string
+		wantRan     int
+		wantSkipped int
+	}{
+		{
+			name:        "canary failure stops the rollout",
+			opts:        WaveOptions{Canary: []string{"a"}, Size: Threshold{Count: 2}},
+			failing:     []string{"a"},
+			wantRan:     1,
+			wantSkipped: 3,
+		},
+		{
+			name:        "failures within the threshold continue",
+			opts:        WaveOptions{Canary: []string{"a"}, Size: Threshold{Count: 2}, MaxFailures: Threshold{Count: 1}},
+			failing:     []string{"a"},
+			wantRan:     4,
+			wantSkipped: 0,
+		},
+		{
+			name:        "fail-fast overrides the threshold",
+			opts:        WaveOptions{Canary: []string{"a"}, Size: Threshold{Count: 2}, MaxFailures: Threshold{Count: 1}},
+			failFast:    true,
+			failing:     []string{"a"},
+			wantRan:     1,
+			wantSkipped: 3,
+		},
+		{
+			name:        "percentage threshold",
+			opts:        WaveOptions{Size: Threshold{Count: 2}, MaxFailures: Threshold{Percent: 25}},
+			failing:     []string{"a", "b"},
+			wantRan:     2,
+			wantSkipped: 2,
+		},
+		{
+			name: "failed gate stops the rollout",
+			opts: WaveOptions{Size: Threshold{Count: 1}, Gate: func(ctx context.Context, wave Wave) error {
+				if wave.Number == 2 {
+					return fmt.Errorf("b: not ready")
+				}
+				return nil
+			}},
+			wantRan:     2,
+			wantSkipped: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewPool[string](2, slog.Default())
+			pool.SetFailFast(tt.failFast)
+			submitWaveTasks(t, pool, []string{"a", "b", "c", "d"}, tt.failing...)
+
+			ran, skipped := 0, 0
+			for result := range pool.ExecuteWaves(context.Background(), tt.opts) {
+				if errors.Is(result.Error, ErrRolloutAborted) {
+					skipped++
+					if result.Status != StatusSkipped {
+						t.Errorf("%s: aborted result not marked skipped", result.ClusterName)
+					}
+					if result.Wave == 0 {
+						t.Errorf("%s: skipped result has no wave", result.ClusterName)
+					}
+					continue
+				}
+				ran++
+			}
+			if ran != tt.wantRan || skipped != tt.wantSkipped {
+				t.Errorf("ran %d, skipped %d; want %d and %d", ran, skipped, tt.wantRan, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestPool_ExecuteWaves_PauseCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	pool := NewPool[string](1, slog.Default())
+	submitWaveTasks(t, pool, []string{"a", "b"})
+
+	var results []Result[string]
+	for result := range pool.ExecuteWaves(ctx, WaveOptions{Size: Threshold{Count: 1}, Pause: time.Hour}) {
+		results = append(results, result)
+	}
+	if len(results) != 2 || results[0].Error != nil || !errors.Is(results[1].Error, ErrRolloutAborted) {
+		t.Errorf("results = %+v, want the second wave aborted when the context ends during the pause", results)
+	}
+}
@@ -0,0 +1,109 @@
+package history
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/executor"
+)
+
+// ClusterStats summarizes one cluster's durations across runs
+// A cluster's duration in a run is the total of its tasks that reached the
+// cluster; runs in which none did, because they were skipped, not started or
+// turned away by an open circuit, are left out.
+type ClusterStats struct {
+	Cluster string `json:"cluster" yaml:"cluster"`
+
+	// Runs is how many runs the cluster ran in, and Failed how many of
+	// those had a task that did not succeed
+	Runs   int `json:"runs" yaml:"runs"`
+	Failed int `json:"failed" yaml:"failed"`
+
+	Min Duration `json:"min" yaml:"min"`
+	Avg Duration `json:"avg" yaml:"avg"`
+	Max Duration `json:"max" yaml:"max"`
+
+	// Latest is the duration in the newest run
+	Latest Duration `json:"latest" yaml:"latest"`
+
+	// Change is how much Latest differs from the average of the earlier
+	// runs, as a fraction such as 0.5 for 50% slower; zero with one run
+	Change float64 `json:"change" yaml:"change"`
+}
+
+// Compare returns duration statistics per cluster across runs, in cluster
+// name order
+func Compare(runs []*Run) []ClusterStats {
+	runs = slices.Clone(runs)
+	slices.SortStableFunc(runs, func(a, b *Run) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+
+	// Each cluster's durations, oldest run first
+	durations := make(map[string][]time.Duration)
+	failed := make(map[string]int)
+	for _, run := range runs {
+		totals := make(map[string]time.Duration)
+		runFailed := make(map[string]bool)
+		var order []string
+		for _, result := range run.Results {
+			if !result.reachedCluster() {
+				continue
+			}
+			if _, ok := totals[result.Cluster]; !ok {
+				order = append(order, result.Cluster)
+			}
+			totals[result.Cluster] += time.Duration(result.Duration)
+			if result.Status != executor.StatusSucceeded {
+				runFailed[result.Cluster] = true
+			}
+		}
+		for _, name := range order {
+			durations[name] = append(durations[name], totals[name])
+			if runFailed[name] {
+				failed[name]++
+			}
+		}
+	}
+
+	stats := make([]ClusterStats, 0, len(durations))
+	for name, ds := range durations {
+		s := ClusterStats{
+			Cluster: name,
+			Runs:    len(ds),
+			Failed:  failed[name],
+			Min:     Duration(slices.Min(ds)),
+			Max:     Duration(slices.Max(ds)),
+			Avg:     Duration(average(ds)),
+			Latest:  Duration(ds[len(ds)-1]),
+		}
+		if earlier := average(ds[:len(ds)-1]); earlier > 0 {
+			s.Change = float64(ds[len(ds)-1]-earlier) / float64(earlier)
+		}
+		stats = append(stats, s)
+	}
+
+	slices.SortFunc(stats, func(a, b ClusterStats) int {
+		return strings.Compare(a.Cluster, b.Cluster)
+	})
+	return stats
+}
+
+// reachedCluster reports whether a task got as far as its cluster; tasks
+// turned away by an open circuit are recorded as skipped without attempts
+func (r ClusterResult) reachedCluster() bool {
+	return r.Attempts > 0 && r.Status != executor.StatusSkipped && r.Status != executor.StatusNotStarted
+}
+
+// average returns the mean of ds, or zero if there are none
+func average(ds []time.Duration) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range ds {
+		total += d
+	}
+	return total / time.Duration(len(ds))
+}
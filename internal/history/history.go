@@ -0,0 +1,310 @@
+// Package history keeps a local record of command runs, so that results can
+// be looked at again after they have been printed and durations compared
+// across runs.
+//
+// Each run is one JSON file under ~/.fleet/history named by its ID, which
+// starts with the run's start time so that names sort oldest first. The
+// newest DefaultMaxRuns runs are kept.
+package history
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"github.com/aryankumar/fleet/internal/executor"
+)
+
+const (
+	// DefaultMaxRuns is how many runs a store keeps before dropping the oldest
+	DefaultMaxRuns = 200
+
+	// dirName is the store's directory in config.StateDir
+	dirName = "history"
+
+	// idTimeFormat starts every run ID so that IDs sort by start time, down
+	// to the millisecond
+	idTimeFormat = "20060102-150405.000"
+)
+
+// ErrNotFound is returned by Store.Get when no run matches the ID
+var ErrNotFound = errors.New("run not found")
+
+// Duration is a time.Duration stored as text such as "1.5s"
+type Duration time.Duration
+
+// MarshalText formats the duration like time.Duration.String
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText parses a duration written by MarshalText
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// String formats the duration rounded to the millisecond
+func (d Duration) String() string {
+	return time.Duration(d).Round(time.Millisecond).String()
+}
+
+// Run is one recorded command run
+type Run struct {
+	// ID identifies the run; it starts with the start time
+	ID string `json:"id" yaml:"id"`
+
+	// Command is the command that ran, such as "apply" or "get pods"
+	Command string `json:"command" yaml:"command"`
+
+	// Args is the command line after the program name
+	Args []string `json:"args" yaml:"args"`
+
+	// StartedAt is when the command started
+	StartedAt time.Time `json:"startedAt" yaml:"startedAt"`
+
+	// Duration is how long the command took until its results were recorded
+	Duration Duration `json:"duration" yaml:"duration"`
+
+	// Clusters are the clusters the run had results for, in name order
+	Clusters []string `json:"clusters" yaml:"clusters"`
+
+	// Results holds one entry per cluster task
+	Results []ClusterResult `json:"results" yaml:"results"`
+}
+
+// ClusterResult is the recorded outcome of one cluster's task
+type ClusterResult struct {
+	Cluster  string          `json:"cluster" yaml:"cluster"`
+	Status   executor.Status `json:"status" yaml:"status"`
+	Duration Duration        `json:"duration" yaml:"duration"`
+	Attempts int             `json:"attempts,omitempty" yaml:"attempts,omitempty"`
+	Wave     int             `json:"wave,omitempty" yaml:"wave,omitempty"`
+	Error    string          `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// Items is how many rows the task returned, such as pods or applied
+	// resources
+	Items int `json:"items" yaml:"items"`
+
+	// Resources lists the per-resource outcomes of apply and delete
+	Resources []Resource `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// Resource is the recorded outcome of applying or deleting one resource
+type Resource struct {
+	Resource  string `json:"resource" yaml:"resource"`
+	Kind      string `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Action    string `json:"action,omitempty" yaml:"action,omitempty"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// CommandLine returns the command line the run was started with
+func (r *Run) CommandLine() string {
+	return strings.Join(r.Args, " ")
+}
+
+// Failed returns how many of the run's cluster tasks did not succeed,
+// counting skipped ones
+func (r *Run) Failed() int {
+	failed := 0
+	for _, result := range r.Results {
+		if result.Status != executor.StatusSucceeded {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Store reads and writes runs in a directory
+type Store struct {
+	dir     string
+	maxRuns int
+}
+
+// NewStore returns a store in dir that keeps the newest DefaultMaxRuns runs
+func NewStore(dir string) *Store {
+	return &Store{dir: dir, maxRuns: DefaultMaxRuns}
+}
+
+// DefaultStore returns the store in ~/.fleet/history
+func DefaultStore() (*Store, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(filepath.Join(dir, dirName)), nil
+}
+
+// Dir returns the store's directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Save writes run to the store, giving it an ID if it has none, and drops
+// the oldest runs beyond the store's limit
+func (s *Store) Save(run *Run) error {
+	if run.ID == "" {
+		run.ID = newID(run.StartedAt)
+	}
+
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode run: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	// Write atomically so that a concurrent list never reads a partial file
+	tmp, err := os.CreateTemp(s.dir, run.ID+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to save run: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save run: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save run: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(run.ID)); err != nil {
+		return fmt.Errorf("failed to save run: %w", err)
+	}
+
+	return s.prune()
+}
+
+// List returns the stored runs, newest first
+// Files that can't be read are skipped.
+func (s *Store) List() ([]*Run, error) {
+	ids, err := s.ids()
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]*Run, 0, len(ids))
+	for _, id := range slices.Backward(ids) {
+		run, err := s.read(id)
+		if err != nil {
+			slog.Debug("skipping unreadable run", "id", id, "error", err)
+			continue
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// Get returns the run with the given ID, or the only run whose ID starts
+// with it; "latest" is the newest run
+func (s *Store) Get(id string) (*Run, error) {
+	ids, err := s.ids()
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "latest" {
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("no runs recorded yet: %w", ErrNotFound)
+		}
+		return s.read(ids[len(ids)-1])
+	}
+
+	var matches []string
+	for _, candidate := range ids {
+		if candidate == id {
+			return s.read(candidate)
+		}
+		if strings.HasPrefix(candidate, id) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%q: %w", id, ErrNotFound)
+	case 1:
+		return s.read(matches[0])
+	default:
+		return nil, fmt.Errorf("%q matches %d runs (%s); give more of the ID", id, len(matches), strings.Join(matches, ", "))
+	}
+}
+
+// ids returns the IDs of the stored runs, oldest first
+func (s *Store) ids() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if id, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// read loads one run
+func (s *Store) read(id string) (*Run, error) {
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run %s: %w", id, err)
+	}
+
+	run := &Run{}
+	if err := json.Unmarshal(data, run); err != nil {
+		return nil, fmt.Errorf("invalid run file %s: %w", s.path(id), err)
+	}
+	return run, nil
+}
+
+// prune removes the oldest runs beyond maxRuns
+func (s *Store) prune() error {
+	ids, err := s.ids()
+	if err != nil || len(ids) <= s.maxRuns {
+		return err
+	}
+
+	for _, id := range ids[:len(ids)-s.maxRuns] {
+		if err := os.Remove(s.path(id)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old run: %w", err)
+		}
+	}
+	return nil
+}
+
+// path returns the file of the run with the given ID
+func (s *Store) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// newID returns an ID made of the start time and a random suffix, so that
+// runs started in the same millisecond don't collide
+func newID(startedAt time.Time) string {
+	suffix := make([]byte, 2)
+	rand.Read(suffix)
+	return startedAt.UTC().Format(idTimeFormat) + "-" + hex.EncodeToString(suffix)
+}
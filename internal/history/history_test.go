@@ -0,0 +1,211 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/executor"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "history"))
+	store.maxRuns = 3
+
+	runs, err := store.List()
+	if err != nil || len(runs) != 0 {
+		t.Fatalf("List() on an empty store = %v, %v", runs, err)
+	}
+	if _, err := store.Get("latest"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(latest) on an empty store error = %v, want ErrNotFound", err)
+	}
+
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		run := &Run{
+			Command:   "get pods",
+			Args:      []string{"get", "pods", "-A"},
+			StartedAt: start.Add(time.Duration(i) * time.Minute),
+			Duration:  Duration(1500 * time.Millisecond),
+		}
+		if err := store.Save(run); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if !strings.HasPrefix(run.ID, "20261016-12") {
+			t.Errorf("ID = %q, want it to start with the start time", run.ID)
+		}
+	}
+
+	runs, err = store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(runs) != 3 {
+		t.Fatalf("List() returned %d runs, want the newest 3", len(runs))
+	}
+	if !runs[0].StartedAt.Equal(start.Add(3*time.Minute)) || !runs[2].StartedAt.Equal(start.Add(time.Minute)) {
+		t.Errorf("List() not newest first: %v, %v", runs[0].StartedAt, runs[2].StartedAt)
+	}
+	if runs[0].Duration != Duration(1500*time.Millisecond) || runs[0].CommandLine() != "get pods -A" {
+		t.Errorf("run = %+v, want it read back as saved", runs[0])
+	}
+
+	latest, err := store.Get("latest")
+	if err != nil || latest.ID != runs[0].ID {
+		t.Errorf("Get(latest) = %v, %v; want %s", latest, err, runs[0].ID)
+	}
+	if got, err := store.Get(runs[1].ID[:len("20261016-1202")]); err != nil || got.ID != runs[1].ID {
+		t.Errorf("Get(prefix) = %v, %v; want %s", got, err, runs[1].ID)
+	}
+	if _, err := store.Get("20261016"); err == nil || !strings.Contains(err.Error(), "matches 3 runs") {
+		t.Errorf("Get(ambiguous prefix) error = %v", err)
+	}
+	if _, err := store.Get("2025"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(unknown) error = %v, want ErrNotFound", err)
+	}
+
+	// Unreadable files are skipped
+	if err := os.WriteFile(filepath.Join(store.Dir(), "29990101-000000-0000.json"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if runs, err := store.List(); err != nil || len(runs) != 3 {
+		t.Errorf("List() with a corrupt file = %d runs, %v; want it skipped", len(runs), err)
+	}
+}
+
+func TestDuration_JSON(t *testing.T) {
+	data, err := json.Marshal(ClusterResult{Cluster: "a", Duration: Duration(2500 * time.Millisecond)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"duration":"2.5s"`) {
+		t.Errorf("encoded = %s, want a readable duration", data)
+	}
+
+	var decoded ClusterResult
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Duration != Duration(2500*time.Millisecond) {
+		t.Errorf("decoded = %+v, %v", decoded, err)
+	}
+}
+
+type row struct {
+	name string
+	err  error
+}
+
+func rowResource(r row) Resource {
+	res := Resource{Resource: r.name, Action: "created"}
+	if r.err != nil {
+		res.Action, res.Error = "", r.err.Error()
+	}
+	return res
+}
+
+func TestTee(t *testing.T) {
+	store := NewStore(t.TempDir())
+	ctx := NewContext(context.Background(), NewRecorder(store, "apply", []string{"apply", "-f", "app.yaml"}, nil))
+
+	in := make(chan executor.Result[[]row], 2)
+	in <- executor.Result[[]row]{
+		ClusterName: "b",
+		Data:        []row{{name: "deployment/web"}, {name: "service/web", err: errors.New("forbidden")}},
+		Duration:    time.Second,
+		Attempts:    1,
+		Status:      executor.StatusSucceeded,
+	}
+	in <- executor.Result[[]row]{
+		ClusterName: "a",
+		Error:       errors.New("connection refused"),
+		Attempts:    2,
+		Status:      executor.StatusFailed,
+	}
+	close(in)
+
+	passed := 0
+	for range Tee(ctx, in, rowResource) {
+		passed++
+	}
+	if passed != 2 {
+		t.Errorf("Tee passed %d results on, want 2", passed)
+	}
+
+	// The run is saved by the time the channel closes
+	run, err := store.Get("latest")
+	if err != nil {
+		t.Fatalf("Get(latest) error = %v", err)
+	}
+	if run.Command != "apply" || strings.Join(run.Clusters, ",") != "a,b" || run.Failed() != 1 {
+		t.Errorf("run = %+v", run)
+	}
+	b := run.Results[0]
+	if b.Items != 2 || len(b.Resources) != 2 || b.Resources[1].Error != "forbidden" {
+		t.Errorf("b = %+v, want its resources recorded", b)
+	}
+	if a := run.Results[1]; a.Error != "connection refused" || a.Attempts != 2 {
+		t.Errorf("a = %+v, want its error recorded", a)
+	}
+}
+
+func TestTee_NotRecording(t *testing.T) {
+	in := make(chan executor.Result[[]row])
+	if out := Tee(context.Background(), in, nil); out != (<-chan executor.Result[[]row])(in) {
+		t.Error("expected Tee to pass the channel through without a recorder")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	start := time.Now()
+	result := func(cluster string, d time.Duration, status executor.Status) ClusterResult {
+		return ClusterResult{Cluster: cluster, Duration: Duration(d), Attempts: 1, Status: status}
+	}
+
+	runs := []*Run{
+		// Newest first, as List returns them
+		{StartedAt: start.Add(2 * time.Minute), Results: []ClusterResult{
+			result("prod", 6*time.Second, executor.StatusSucceeded),
+			result("dev", time.Second, executor.StatusTimedOut),
+		}},
+		{StartedAt: start.Add(time.Minute), Results: []ClusterResult{
+			result("prod", 2*time.Second, executor.StatusSucceeded),
+			result("prod", 2*time.Second, executor.StatusSucceeded),
+			{Cluster: "dev", Status: executor.StatusNotStarted},
+			{Cluster: "prod", Status: executor.StatusSkipped, Error: "circuit open after 3 consecutive failures, skipping until 10:00:00"},
+		}},
+		{StartedAt: start, Results: []ClusterResult{
+			result("prod", 4*time.Second, executor.StatusSucceeded),
+			result("dev", time.Second, executor.StatusSucceeded),
+		}},
+	}
+
+	stats := Compare(runs)
+	if len(stats) != 2 || stats[0].Cluster != "dev" || stats[1].Cluster != "prod" {
+		t.Fatalf("Compare() = %+v, want dev and prod in name order", stats)
+	}
+
+	dev, prod := stats[0], stats[1]
+	if dev.Runs != 2 || dev.Failed != 1 || dev.Change != 0 {
+		t.Errorf("dev = %+v, want 2 runs (not started and circuit open left out), 1 failed, no change", dev)
+	}
+	want := ClusterStats{
+		Cluster: "prod",
+		Runs:    3,
+		Min:     Duration(4 * time.Second),
+		Avg:     Duration(14 * time.Second / 3),
+		Max:     Duration(6 * time.Second),
+		Latest:  Duration(6 * time.Second),
+		Change:  0.5,
+	}
+	if math.Abs(prod.Change-want.Change) > 1e-9 {
+		t.Errorf("prod change = %v, want %v", prod.Change, want.Change)
+	}
+	prod.Change = want.Change
+	if prod != want {
+		t.Errorf("prod = %+v, want %+v (tasks of a run summed)", prod, want)
+	}
+}
@@ -0,0 +1,130 @@
+package history
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/executor"
+)
+
+// Recorder collects the results of the current command and saves them as a run
+type Recorder struct {
+	store  *Store
+	logger *slog.Logger
+
+	mu  sync.Mutex
+	run Run
+}
+
+// NewRecorder starts recording a run of command with the given command line
+// arguments; nothing is written until Save
+func NewRecorder(store *Store, command string, args []string, logger *slog.Logger) *Recorder {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Recorder{
+		store:  store,
+		logger: logger,
+		run: Run{
+			Command:   command,
+			Args:      slices.Clone(args),
+			StartedAt: time.Now(),
+		},
+	}
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying r
+func NewContext(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the recorder stored in ctx, or nil if the command is
+// not being recorded
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(contextKey{}).(*Recorder)
+	return r
+}
+
+// Save writes the run to the store
+// A failure is logged rather than returned, since it shouldn't fail the
+// command whose results are already printed.
+func (r *Recorder) Save() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.run.Duration = Duration(time.Since(r.run.StartedAt))
+	r.run.Clusters = nil
+	for _, result := range r.run.Results {
+		if !slices.Contains(r.run.Clusters, result.Cluster) {
+			r.run.Clusters = append(r.run.Clusters, result.Cluster)
+		}
+	}
+	slices.Sort(r.run.Clusters)
+
+	if err := r.store.Save(&r.run); err != nil {
+		r.logger.Warn("failed to record run history", "error", err)
+		return
+	}
+	r.logger.Debug("recorded run", "id", r.run.ID, "dir", r.store.Dir())
+}
+
+// add records one result
+func (r *Recorder) add(result ClusterResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.run.Results = append(r.run.Results, result)
+}
+
+// Tee passes results on while recording them to the recorder in ctx, if
+// there is one, and saves the run once results is closed
+// The returned channel is closed after the run is saved. resource converts
+// each returned row to a Resource for the per-resource outcomes of apply and
+// delete; nil keeps only the row count.
+func Tee[E any](ctx context.Context, results <-chan executor.Result[[]E], resource func(E) Resource) <-chan executor.Result[[]E] {
+	r := FromContext(ctx)
+	if r == nil {
+		return results
+	}
+
+	out := make(chan executor.Result[[]E], cap(results))
+	go func() {
+		defer close(out)
+
+		for result := range results {
+			r.add(clusterResult(result, resource))
+			out <- result
+		}
+		r.Save()
+	}()
+
+	return out
+}
+
+// clusterResult converts an executor result for the record
+func clusterResult[E any](result executor.Result[[]E], resource func(E) Resource) ClusterResult {
+	cr := ClusterResult{
+		Cluster:  result.ClusterName,
+		Status:   result.Status,
+		Duration: Duration(result.Duration),
+		Attempts: result.Attempts,
+		Wave:     result.Wave,
+		Items:    len(result.Data),
+	}
+	if result.Error != nil {
+		cr.Error = result.Error.Error()
+	}
+
+	if resource != nil {
+		for _, row := range result.Data {
+			cr.Resources = append(cr.Resources, resource(row))
+		}
+	}
+
+	return cr
+}
@@ -12,7 +12,7 @@ import (
 
 	"github.com/aryankumar/fleet/internal/cluster"
 	"github.com/aryankumar/fleet/internal/config"
-	"github.com/aryankumar/fleet/internal/executor"
+	executor "github.com/aryankumar/fleet/internal/executor/legacy"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -0,0 +1,75 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// DirectorySource loads every kubeconfig file in a directory, in the style of
+// ~/.kube/kubeconfig.d
+type DirectorySource struct {
+	dir string
+}
+
+// NewDirectorySource creates a source for the kubeconfig files in dir
+// A leading ~ and environment variables are expanded.
+func NewDirectorySource(dir string) *DirectorySource {
+	return &DirectorySource{dir: dir}
+}
+
+// Name identifies the source in logs and errors
+func (s *DirectorySource) Name() string {
+	return "directory " + s.dir
+}
+
+// Load merges the files in the directory in name order; when two files
+// define the same context, the first one wins. Hidden files and
+// subdirectories are skipped.
+func (s *DirectorySource) Load(ctx context.Context) (*api.Config, error) {
+	dir, err := config.ExpandPath(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		// Stat follows symlinks, which kubeconfig.d directories often hold
+		path := filepath.Join(dir, entry.Name())
+		if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, path)
+	}
+	sort.Strings(files)
+
+	if len(files) == 0 {
+		return api.NewConfig(), nil
+	}
+
+	loadingRules := &clientcmd.ClientConfigLoadingRules{
+		Precedence: files,
+	}
+
+	merged, err := loadingRules.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig files: %w", err)
+	}
+
+	return merged, nil
+}
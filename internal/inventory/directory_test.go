@@ -0,0 +1,95 @@
+package inventory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeKubeconfig(t *testing.T, path, contextName, server string) {
+	t.Helper()
+
+	content := `apiVersion: v1
+kind: Config
+clusters:
+- name: ` + contextName + `
+  cluster:
+    server: ` + server + `
+contexts:
+- name: ` + contextName + `
+  context:
+    cluster: ` + contextName + `
+    user: ` + contextName + `
+users:
+- name: ` + contextName + `
+  user:
+    token: test
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+}
+
+func TestDirectorySource_Load(t *testing.T) {
+	dir := t.TempDir()
+	writeKubeconfig(t, filepath.Join(dir, "a-edge.yaml"), "edge", "https://edge.a")
+	writeKubeconfig(t, filepath.Join(dir, "b-edge.yaml"), "edge", "https://edge.b")
+	writeKubeconfig(t, filepath.Join(dir, "lab"), "lab", "https://lab")
+	writeKubeconfig(t, filepath.Join(dir, ".hidden.yaml"), "hidden", "https://hidden")
+	if err := os.Mkdir(filepath.Join(dir, "archive"), 0755); err != nil {
+		t.Fatalf("failed to create subdirectory: %v", err)
+	}
+	writeKubeconfig(t, filepath.Join(dir, "archive", "old.yaml"), "old", "https://old")
+
+	// Symlinked files are followed
+	target := filepath.Join(t.TempDir(), "linked.yaml")
+	writeKubeconfig(t, target, "linked", "https://linked")
+	if err := os.Symlink(target, filepath.Join(dir, "linked.yaml")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	source := NewDirectorySource(dir)
+	cfg, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := []string{"edge", "lab", "linked"}
+	if got := contextNames(cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("contexts = %v, want %v", got, want)
+	}
+	if got := cfg.Clusters["edge"].Server; got != "https://edge.a" {
+		t.Errorf("edge server = %q, want the first file's", got)
+	}
+}
+
+func TestDirectorySource_Errors(t *testing.T) {
+	t.Run("missing directory", func(t *testing.T) {
+		source := NewDirectorySource(filepath.Join(t.TempDir(), "missing"))
+		if _, err := source.Load(context.Background()); err == nil {
+			t.Error("Load() expected error for a missing directory")
+		}
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		cfg, err := NewDirectorySource(t.TempDir()).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if len(cfg.Contexts) != 0 {
+			t.Errorf("contexts = %v, want none", contextNames(cfg))
+		}
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "bad.yaml"), []byte("clusters: [\n"), 0600); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		if _, err := NewDirectorySource(dir).Load(context.Background()); err == nil {
+			t.Error("Load() expected error for an invalid kubeconfig")
+		}
+	})
+}
@@ -0,0 +1,106 @@
+// Package inventory discovers clusters from sources other than the kubeconfig,
+// such as kubeconfig.d directories and kubeconfig Secrets on a hub cluster.
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Source discovers clusters and describes them as kubeconfig contexts
+type Source interface {
+	// Name identifies the source in logs and errors
+	Name() string
+
+	// Load returns the discovered clusters as a kubeconfig. Each context
+	// should be named after the cluster it connects to.
+	Load(ctx context.Context) (*api.Config, error)
+}
+
+// Merge loads every source and combines their contexts into one kubeconfig
+//
+// Contexts already named in skip (typically those of the kubeconfig) and
+// contexts found by an earlier source win over later ones. A failing source
+// does not stop the others: the merged result of those that loaded is
+// returned together with the joined errors.
+func Merge(ctx context.Context, sources []Source, skip []string, logger *slog.Logger) (*api.Config, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	taken := make(map[string]string, len(skip))
+	for _, contextName := range skip {
+		taken[contextName] = "kubeconfig"
+	}
+
+	merged := api.NewConfig()
+	var errs []error
+
+	for _, source := range sources {
+		discovered, err := source.Load(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", source.Name(), err))
+			continue
+		}
+
+		contexts := make([]string, 0, len(discovered.Contexts))
+		for contextName := range discovered.Contexts {
+			contexts = append(contexts, contextName)
+		}
+		sort.Strings(contexts)
+
+		for _, contextName := range contexts {
+			if owner, ok := taken[contextName]; ok {
+				logger.Debug("ignoring duplicate discovered cluster",
+					"context", contextName,
+					"source", source.Name(),
+					"from", owner)
+				continue
+			}
+			taken[contextName] = source.Name()
+			config.MergeContext(merged, discovered, contextName)
+		}
+
+		logger.Debug("discovered clusters", "source", source.Name(), "count", len(contexts))
+	}
+
+	return merged, errors.Join(errs...)
+}
+
+// hubTimeout bounds each request to the hub cluster, since discovery runs
+// before every command and an unreachable hub must not stall them
+const hubTimeout = 5 * time.Second
+
+// FromConfig builds the sources configured under inventory in the fleet config
+// The hub client is created from the hub context in loader's kubeconfig. If
+// it can't be, the other sources are still returned along with the error.
+func FromConfig(cfg config.InventoryConfig, loader *config.KubeconfigLoader) ([]Source, error) {
+	sources := make([]Source, 0, len(cfg.Directories)+1)
+
+	for _, dir := range cfg.Directories {
+		sources = append(sources, NewDirectorySource(dir))
+	}
+
+	if cfg.Hub != nil && cfg.Hub.Context != "" {
+		restConfig, err := loader.BuildClientConfig(cfg.Hub.Context)
+		if err != nil {
+			return sources, fmt.Errorf("hub cluster: %w", err)
+		}
+		restConfig.Timeout = hubTimeout
+		clientset, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return sources, fmt.Errorf("hub cluster: failed to create clientset: %w", err)
+		}
+		sources = append(sources, NewSecretSource(cfg.Hub.Context, clientset, cfg.Hub.Namespace, cfg.Hub.AllowedExecCommands))
+	}
+
+	return sources, nil
+}
@@ -0,0 +1,113 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/aryankumar/fleet/internal/config"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// staticSource returns a fixed kubeconfig, or an error
+type staticSource struct {
+	name   string
+	config *api.Config
+	err    error
+}
+
+func (s *staticSource) Name() string { return s.name }
+
+func (s *staticSource) Load(ctx context.Context) (*api.Config, error) {
+	return s.config, s.err
+}
+
+// kubeconfigWith builds a kubeconfig with one context per server, each with
+// its own cluster and user named after the context
+func kubeconfigWith(servers map[string]string) *api.Config {
+	cfg := api.NewConfig()
+	for name, server := range servers {
+		cfg.Clusters[name] = &api.Cluster{Server: server}
+		cfg.AuthInfos[name] = &api.AuthInfo{Token: name + "-token"}
+		cfg.Contexts[name] = &api.Context{Cluster: name, AuthInfo: name}
+	}
+	return cfg
+}
+
+func contextNames(cfg *api.Config) []string {
+	names := make([]string, 0, len(cfg.Contexts))
+	for name := range cfg.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestMerge(t *testing.T) {
+	sources := []Source{
+		&staticSource{name: "first", config: kubeconfigWith(map[string]string{
+			"edge-1": "https://edge-1.first",
+			"prod":   "https://prod.first",
+		})},
+		&staticSource{name: "broken", err: errors.New("hub unreachable")},
+		&staticSource{name: "second", config: kubeconfigWith(map[string]string{
+			"edge-1": "https://edge-1.second",
+			"edge-2": "https://edge-2.second",
+		})},
+	}
+
+	merged, err := Merge(context.Background(), sources, []string{"prod"}, nil)
+	if err == nil {
+		t.Error("Merge() expected the failing source to be reported")
+	}
+
+	want := []string{"edge-1", "edge-2"}
+	if got := contextNames(merged); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("contexts = %v, want %v", got, want)
+	}
+
+	// The first source wins a duplicate
+	if got := merged.Clusters[merged.Contexts["edge-1"].Cluster].Server; got != "https://edge-1.first" {
+		t.Errorf("edge-1 server = %q, want the first source's", got)
+	}
+	if _, ok := merged.Contexts["prod"]; ok {
+		t.Error("prod should be left to the kubeconfig")
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	writeKubeconfig(t, kubeconfig, "mgmt-hub", "https://hub.example.com")
+	loader := config.NewKubeconfigLoader(kubeconfig)
+
+	sources, err := FromConfig(config.InventoryConfig{
+		Directories: []string{dir},
+		Hub:         &config.HubConfig{Context: "mgmt-hub"},
+	}, loader)
+	if err != nil || len(sources) != 2 {
+		t.Fatalf("FromConfig() = %d sources, %v; want the directory and the hub", len(sources), err)
+	}
+
+	// Discovery runs before every command, so hub requests are bounded
+	hub := sources[1].(*SecretSource).client.(*kubernetes.Clientset)
+	if timeout := hub.CoreV1().RESTClient().(*rest.RESTClient).Client.Timeout; timeout != hubTimeout {
+		t.Errorf("hub client timeout = %v, want %v", timeout, hubTimeout)
+	}
+
+	// A mistake in the hub settings keeps the other sources
+	sources, err = FromConfig(config.InventoryConfig{
+		Directories: []string{dir},
+		Hub:         &config.HubConfig{Context: "mgmt-hbu"},
+	}, loader)
+	if err == nil {
+		t.Error("expected an error for an unknown hub context")
+	}
+	if len(sources) != 1 || sources[0].Name() != NewDirectorySource(dir).Name() {
+		t.Errorf("sources = %v, want the directory source kept", sources)
+	}
+}
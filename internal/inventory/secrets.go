@@ -0,0 +1,277 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/aryankumar/fleet/internal/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// capiClusterNameLabel marks Cluster API secrets with the cluster they belong to
+	capiClusterNameLabel = "cluster.x-k8s.io/cluster-name"
+
+	// capiSecretType is the type of secrets created by Cluster API
+	capiSecretType corev1.SecretType = "cluster.x-k8s.io/secret"
+
+	// argoSecretTypeLabel marks Argo CD secrets; cluster secrets have the value "cluster"
+	argoSecretTypeLabel = "argocd.argoproj.io/secret-type"
+
+	// argoInClusterServer is how Argo CD refers to the cluster it runs in
+	argoInClusterServer = "https://kubernetes.default.svc"
+)
+
+// SecretSource reads kubeconfig-bearing Secrets from a hub cluster
+//
+// Two kinds of Secrets are recognised:
+//   - Cluster API "<cluster>-kubeconfig" secrets, whose "value" key holds a
+//     kubeconfig; the cluster is named after the cluster-name label
+//   - Argo CD cluster secrets, whose "name", "server" and "config" keys
+//     describe the cluster; it is named after "name"
+//
+// Credentials that run a command (exec plugins, and Argo CD's awsAuthConfig,
+// which runs "aws") would let anyone who can write Secrets on the hub run
+// commands on this machine, so they are only used when the command is
+// explicitly allowed.
+type SecretSource struct {
+	hubContext  string
+	client      kubernetes.Interface
+	namespace   string
+	allowedExec []string
+}
+
+// NewSecretSource creates a source reading Secrets through client, which
+// connects to the hub cluster at hubContext. An empty namespace searches all
+// namespaces. allowedExec lists the exec plugin commands Secrets may use;
+// Secrets using any other command are skipped.
+func NewSecretSource(hubContext string, client kubernetes.Interface, namespace string, allowedExec []string) *SecretSource {
+	return &SecretSource{
+		hubContext:  hubContext,
+		client:      client,
+		namespace:   namespace,
+		allowedExec: allowedExec,
+	}
+}
+
+// Name identifies the source in logs and errors
+func (s *SecretSource) Name() string {
+	return "hub " + s.hubContext
+}
+
+// Load lists the Cluster API and Argo CD secrets on the hub and converts
+// them into kubeconfig contexts. Secrets that cannot be parsed, or that run
+// a command that is not allowed, are logged and skipped.
+func (s *SecretSource) Load(ctx context.Context) (*api.Config, error) {
+	discovered := api.NewConfig()
+	secrets := s.client.CoreV1().Secrets(s.namespace)
+
+	capi, err := secrets.List(ctx, metav1.ListOptions{LabelSelector: capiClusterNameLabel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Cluster API secrets: %w", err)
+	}
+	for i := range capi.Items {
+		secret := &capi.Items[i]
+		if !isCAPIKubeconfigSecret(secret) {
+			continue
+		}
+		if err := addCAPISecret(discovered, secret, s.allowedExec); err != nil {
+			slog.Warn("skipping Cluster API secret", "secret", secret.Namespace+"/"+secret.Name, "error", err)
+		}
+	}
+
+	argo, err := secrets.List(ctx, metav1.ListOptions{LabelSelector: argoSecretTypeLabel + "=cluster"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Argo CD cluster secrets: %w", err)
+	}
+	for i := range argo.Items {
+		secret := &argo.Items[i]
+		if err := addArgoSecret(discovered, secret, s.allowedExec); err != nil {
+			slog.Warn("skipping Argo CD cluster secret", "secret", secret.Namespace+"/"+secret.Name, "error", err)
+		}
+	}
+
+	return discovered, nil
+}
+
+// isCAPIKubeconfigSecret reports whether secret is the admin kubeconfig that
+// Cluster API writes for a workload cluster
+func isCAPIKubeconfigSecret(secret *corev1.Secret) bool {
+	clusterName := secret.Labels[capiClusterNameLabel]
+	return clusterName != "" &&
+		secret.Name == clusterName+"-kubeconfig" &&
+		(secret.Type == capiSecretType || secret.Type == corev1.SecretTypeOpaque)
+}
+
+// addCAPISecret adds the current context of a Cluster API kubeconfig secret,
+// renamed after the cluster
+func addCAPISecret(dst *api.Config, secret *corev1.Secret, allowedExec []string) error {
+	data, ok := secret.Data["value"]
+	if !ok {
+		return fmt.Errorf("missing key %q", "value")
+	}
+
+	kubeconfig, err := clientcmd.Load(data)
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	contextName := kubeconfig.CurrentContext
+	if contextName == "" && len(kubeconfig.Contexts) == 1 {
+		for name := range kubeconfig.Contexts {
+			contextName = name
+		}
+	}
+	context, ok := kubeconfig.Contexts[contextName]
+	if !ok {
+		return fmt.Errorf("kubeconfig has no current context")
+	}
+
+	clusterName := secret.Labels[capiClusterNameLabel]
+	src := api.NewConfig()
+	src.Contexts[clusterName] = context
+	if cluster, ok := kubeconfig.Clusters[context.Cluster]; ok {
+		src.Clusters[context.Cluster] = cluster
+	}
+	if authInfo, ok := kubeconfig.AuthInfos[context.AuthInfo]; ok {
+		if err := checkExec(authInfo, allowedExec); err != nil {
+			return err
+		}
+		src.AuthInfos[context.AuthInfo] = authInfo
+	}
+
+	addContext(dst, src, clusterName)
+	return nil
+}
+
+// argoClusterConfig is the "config" key of an Argo CD cluster secret
+type argoClusterConfig struct {
+	Username        string `json:"username,omitempty"`
+	Password        string `json:"password,omitempty"`
+	BearerToken     string `json:"bearerToken,omitempty"`
+	TLSClientConfig struct {
+		Insecure   bool   `json:"insecure,omitempty"`
+		ServerName string `json:"serverName,omitempty"`
+		CAData     []byte `json:"caData,omitempty"`
+		CertData   []byte `json:"certData,omitempty"`
+		KeyData    []byte `json:"keyData,omitempty"`
+	} `json:"tlsClientConfig"`
+	AWSAuthConfig *struct {
+		ClusterName string `json:"clusterName"`
+		RoleARN     string `json:"roleARN,omitempty"`
+		Profile     string `json:"profile,omitempty"`
+	} `json:"awsAuthConfig,omitempty"`
+	ExecProviderConfig *struct {
+		Command     string            `json:"command"`
+		Args        []string          `json:"args,omitempty"`
+		Env         map[string]string `json:"env,omitempty"`
+		APIVersion  string            `json:"apiVersion,omitempty"`
+		InstallHint string            `json:"installHint,omitempty"`
+	} `json:"execProviderConfig,omitempty"`
+}
+
+// addArgoSecret converts an Argo CD cluster secret into a context
+// The in-cluster entry is skipped: it points at the hub itself, which is
+// already in the kubeconfig under the hub context.
+func addArgoSecret(dst *api.Config, secret *corev1.Secret, allowedExec []string) error {
+	name := string(secret.Data["name"])
+	server := string(secret.Data["server"])
+	if server == "" {
+		return fmt.Errorf("missing key %q", "server")
+	}
+	if strings.TrimSuffix(server, "/") == argoInClusterServer {
+		return nil
+	}
+	if name == "" {
+		name = server
+	}
+
+	var cfg argoClusterConfig
+	if raw := secret.Data["config"]; len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
+	cluster := api.NewCluster()
+	cluster.Server = server
+	cluster.InsecureSkipTLSVerify = cfg.TLSClientConfig.Insecure
+	cluster.TLSServerName = cfg.TLSClientConfig.ServerName
+	cluster.CertificateAuthorityData = cfg.TLSClientConfig.CAData
+
+	authInfo := api.NewAuthInfo()
+	authInfo.Token = cfg.BearerToken
+	authInfo.Username = cfg.Username
+	authInfo.Password = cfg.Password
+	authInfo.ClientCertificateData = cfg.TLSClientConfig.CertData
+	authInfo.ClientKeyData = cfg.TLSClientConfig.KeyData
+
+	switch {
+	case cfg.ExecProviderConfig != nil:
+		exec := cfg.ExecProviderConfig
+		authInfo.Exec = &api.ExecConfig{
+			Command:         exec.Command,
+			Args:            exec.Args,
+			APIVersion:      exec.APIVersion,
+			InstallHint:     exec.InstallHint,
+			InteractiveMode: api.NeverExecInteractiveMode,
+		}
+		for key, value := range exec.Env {
+			authInfo.Exec.Env = append(authInfo.Exec.Env, api.ExecEnvVar{Name: key, Value: value})
+		}
+	case cfg.AWSAuthConfig != nil:
+		// Argo CD uses its own helper; the AWS CLI produces the same token
+		aws := cfg.AWSAuthConfig
+		args := []string{"eks", "get-token", "--cluster-name", aws.ClusterName}
+		if aws.RoleARN != "" {
+			args = append(args, "--role-arn", aws.RoleARN)
+		}
+		authInfo.Exec = &api.ExecConfig{
+			Command:         "aws",
+			Args:            args,
+			APIVersion:      "client.authentication.k8s.io/v1beta1",
+			InteractiveMode: api.NeverExecInteractiveMode,
+		}
+		if aws.Profile != "" {
+			authInfo.Exec.Env = []api.ExecEnvVar{{Name: "AWS_PROFILE", Value: aws.Profile}}
+		}
+	}
+
+	if err := checkExec(authInfo, allowedExec); err != nil {
+		return err
+	}
+
+	src := api.NewConfig()
+	src.Clusters[name] = cluster
+	src.AuthInfos[name] = authInfo
+	src.Contexts[name] = &api.Context{Cluster: name, AuthInfo: name}
+
+	addContext(dst, src, name)
+	return nil
+}
+
+// checkExec returns an error if authInfo gets credentials by running a
+// command that is not in allowed
+func checkExec(authInfo *api.AuthInfo, allowed []string) error {
+	if authInfo.Exec == nil || slices.Contains(allowed, authInfo.Exec.Command) {
+		return nil
+	}
+	return fmt.Errorf("credentials run command %q, which is not in inventory.hub.allowedExecCommands", authInfo.Exec.Command)
+}
+
+// addContext adds a discovered context unless one of that name already exists
+func addContext(dst, src *api.Config, contextName string) {
+	if _, exists := dst.Contexts[contextName]; exists {
+		slog.Warn("ignoring duplicate cluster on hub", "cluster", contextName)
+		return
+	}
+	config.MergeContext(dst, src, contextName)
+}
@@ -0,0 +1,247 @@
+package inventory
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const capiKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: workload-1
+  cluster:
+    server: https://workload-1.example.com:6443
+    certificate-authority-data: Y2E=
+contexts:
+- name: workload-1-admin@workload-1
+  context:
+    cluster: workload-1
+    user: workload-1-admin
+current-context: workload-1-admin@workload-1
+users:
+- name: workload-1-admin
+  user:
+    token: capi-token
+`
+
+func hubSecrets() []corev1.Secret {
+	return []corev1.Secret{
+		{
+			// Cluster API admin kubeconfig
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload-1-kubeconfig",
+				Namespace: "capi-clusters",
+				Labels:    map[string]string{capiClusterNameLabel: "workload-1"},
+			},
+			Type: capiSecretType,
+			Data: map[string][]byte{"value": []byte(capiKubeconfig)},
+		},
+		{
+			// Other Cluster API secrets, such as certificates, are ignored
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workload-1-ca",
+				Namespace: "capi-clusters",
+				Labels:    map[string]string{capiClusterNameLabel: "workload-1"},
+			},
+			Type: capiSecretType,
+			Data: map[string][]byte{"tls.crt": []byte("cert")},
+		},
+		{
+			// Argo CD cluster with a bearer token
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-edge",
+				Namespace: "argocd",
+				Labels:    map[string]string{argoSecretTypeLabel: "cluster"},
+			},
+			Data: map[string][]byte{
+				"name":   []byte("edge"),
+				"server": []byte("https://edge.example.com"),
+				"config": []byte(`{"bearerToken":"argo-token","tlsClientConfig":{"insecure":false,"caData":"Y2E="}}`),
+			},
+		},
+		{
+			// Argo CD cluster using AWS authentication
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-eks",
+				Namespace: "argocd",
+				Labels:    map[string]string{argoSecretTypeLabel: "cluster"},
+			},
+			Data: map[string][]byte{
+				"name":   []byte("eks-prod"),
+				"server": []byte("https://ABC.gr7.us-east-1.eks.amazonaws.com"),
+				"config": []byte(`{"awsAuthConfig":{"clusterName":"prod","roleARN":"arn:aws:iam::123:role/argo"},"tlsClientConfig":{"caData":"Y2E="}}`),
+			},
+		},
+		{
+			// Argo CD cluster using an exec credential plugin
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-exec",
+				Namespace: "argocd",
+				Labels:    map[string]string{argoSecretTypeLabel: "cluster"},
+			},
+			Data: map[string][]byte{
+				"name":   []byte("exec"),
+				"server": []byte("https://exec.example.com"),
+				"config": []byte(`{"execProviderConfig":{"command":"fetch-token","args":["--cluster","exec"]}}`),
+			},
+		},
+		{
+			// Argo CD's entry for its own cluster is skipped
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "in-cluster",
+				Namespace: "argocd",
+				Labels:    map[string]string{argoSecretTypeLabel: "cluster"},
+			},
+			Data: map[string][]byte{
+				"name":   []byte("in-cluster"),
+				"server": []byte("https://kubernetes.default.svc"),
+			},
+		},
+		{
+			// Unparseable secrets are skipped
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-broken",
+				Namespace: "argocd",
+				Labels:    map[string]string{argoSecretTypeLabel: "cluster"},
+			},
+			Data: map[string][]byte{
+				"name":   []byte("broken"),
+				"server": []byte("https://broken.example.com"),
+				"config": []byte(`{not json`),
+			},
+		},
+		{
+			// Argo CD repository secrets are not clusters
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "repo-charts",
+				Namespace: "argocd",
+				Labels:    map[string]string{argoSecretTypeLabel: "repository"},
+			},
+			Data: map[string][]byte{"url": []byte("https://charts.example.com")},
+		},
+	}
+}
+
+func newFakeHub() *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	for _, secret := range hubSecrets() {
+		_, err := client.CoreV1().Secrets(secret.Namespace).Create(context.Background(), &secret, metav1.CreateOptions{})
+		if err != nil {
+			panic(err)
+		}
+	}
+	return client
+}
+
+func TestSecretSource_Load(t *testing.T) {
+	source := NewSecretSource("hub", newFakeHub(), "", []string{"aws"})
+	if source.Name() != "hub hub" {
+		t.Errorf("Name() = %q", source.Name())
+	}
+
+	cfg, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := []string{"edge", "eks-prod", "workload-1"}
+	if got := contextNames(cfg); !reflect.DeepEqual(got, want) {
+		t.Fatalf("contexts = %v, want %v", got, want)
+	}
+
+	t.Run("cluster api", func(t *testing.T) {
+		context := cfg.Contexts["workload-1"]
+		cluster := cfg.Clusters[context.Cluster]
+		if cluster.Server != "https://workload-1.example.com:6443" || string(cluster.CertificateAuthorityData) != "ca" {
+			t.Errorf("cluster = %+v, want the kubeconfig's server and CA", cluster)
+		}
+		if got := cfg.AuthInfos[context.AuthInfo].Token; got != "capi-token" {
+			t.Errorf("token = %q, want capi-token", got)
+		}
+	})
+
+	t.Run("argo cd bearer token", func(t *testing.T) {
+		context := cfg.Contexts["edge"]
+		cluster := cfg.Clusters[context.Cluster]
+		if cluster.Server != "https://edge.example.com" || string(cluster.CertificateAuthorityData) != "ca" {
+			t.Errorf("cluster = %+v, want the secret's server and CA", cluster)
+		}
+		if got := cfg.AuthInfos[context.AuthInfo].Token; got != "argo-token" {
+			t.Errorf("token = %q, want argo-token", got)
+		}
+	})
+
+	t.Run("argo cd aws auth", func(t *testing.T) {
+		exec := cfg.AuthInfos[cfg.Contexts["eks-prod"].AuthInfo].Exec
+		if exec == nil {
+			t.Fatal("expected an exec credential plugin")
+		}
+		wantArgs := []string{"eks", "get-token", "--cluster-name", "prod", "--role-arn", "arn:aws:iam::123:role/argo"}
+		if exec.Command != "aws" || !reflect.DeepEqual(exec.Args, wantArgs) {
+			t.Errorf("exec = %s %v, want aws %v", exec.Command, exec.Args, wantArgs)
+		}
+	})
+}
+
+func TestSecretSource_Namespace(t *testing.T) {
+	cfg, err := NewSecretSource("hub", newFakeHub(), "capi-clusters", nil).Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got := contextNames(cfg); !reflect.DeepEqual(got, []string{"workload-1"}) {
+		t.Errorf("contexts = %v, want only the Cluster API cluster", got)
+	}
+}
+
+func TestSecretSource_Exec(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		want    []string
+	}{
+		{name: "no commands allowed", want: []string{"edge", "workload-1"}},
+		{name: "aws allowed", allowed: []string{"aws"}, want: []string{"edge", "eks-prod", "workload-1"}},
+		{name: "plugin allowed", allowed: []string{"aws", "fetch-token"}, want: []string{"edge", "eks-prod", "exec", "workload-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewSecretSource("hub", newFakeHub(), "", tt.allowed).Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := contextNames(cfg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("contexts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("cluster api exec", func(t *testing.T) {
+		kubeconfig := strings.Replace(capiKubeconfig, "    token: capi-token\n", `    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: fetch-token
+`, 1)
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "workload-1-kubeconfig",
+				Labels: map[string]string{capiClusterNameLabel: "workload-1"},
+			},
+			Data: map[string][]byte{"value": []byte(kubeconfig)},
+		}
+
+		if err := addCAPISecret(api.NewConfig(), secret, nil); err == nil || !strings.Contains(err.Error(), "fetch-token") {
+			t.Errorf("addCAPISecret() error = %v, want the command rejected", err)
+		}
+		if err := addCAPISecret(api.NewConfig(), secret, []string{"fetch-token"}); err != nil {
+			t.Errorf("addCAPISecret() with the command allowed error = %v", err)
+		}
+	})
+}
@@ -1,11 +1,12 @@
 // Package output provides formatters for displaying Fleet CLI command results.
 //
-// The package supports multiple output formats (table, JSON, YAML) and provides
+// The package supports multiple output formats (table, JSON, YAML, NDJSON) and provides
 // a unified interface for formatting both single-cluster and multi-cluster results.
 //
 // # Features
 //
-//   - Multiple output formats: table (kubectl-style), JSON, and YAML
+//   - Multiple output formats: table (kubectl-style), JSON, YAML and NDJSON
+//   - Streaming output of results as they arrive
 //   - Color support with automatic TTY detection
 //   - Configurable options (no-color, no-headers, wide mode)
 //   - Multi-cluster result aggregation
@@ -21,9 +22,24 @@
 //	formatter.Format(os.Stdout, data)
 //
 //	// Format multi-cluster results
-//	results := []executor.Result{...}
+//	results := executor.Untyped(typedResults)
 //	formatter.FormatMultiCluster(os.Stdout, results)
 //
+// # Streaming
+//
+// A StreamFormatter writes each result as it arrives, for example from
+// executor.Pool.ExecuteStream. Table rows and NDJSON lines are written
+// immediately; JSON and YAML are buffered until the stream finishes:
+//
+//	stream := output.NewStreamFormatter(output.FormatTable)
+//	for result := range pool.ExecuteStream(ctx) {
+//	    stream.WriteResult(os.Stdout, result.Untyped())
+//	}
+//	err := stream.Finish(os.Stdout)
+//
+// WithColumns lays a streamed table out as a row per item of each result's
+// data, such as the pods a cluster returned.
+//
 // # Options
 //
 // Formatters can be configured with functional options:
@@ -52,6 +68,11 @@
 //   - Proper indentation and formatting
 //   - Compatible with kubectl-style workflows
 //
+// NDJSON Formatter:
+//   - One compact JSON object per line
+//   - Slices are written one element per line
+//   - Suitable for streaming into jq and log pipelines
+//
 // # Color Support
 //
 // Colors are automatically enabled for TTY outputs and can be disabled with:
@@ -15,7 +15,7 @@ func Example_tableFormatter() {
 	formatter := output.NewFormatter(output.FormatTable, output.WithNoColor(true))
 
 	// Create some results
-	results := []executor.Result{
+	results := []executor.Result[any]{
 		{
 			ClusterName: "production",
 			Data:        map[string]string{"pods": "10"},
@@ -40,7 +40,7 @@ func Example_jsonFormatter() {
 	formatter := output.NewFormatter(output.FormatJSON)
 
 	// Create results with mixed success/failure
-	results := []executor.Result{
+	results := []executor.Result[any]{
 		{
 			ClusterName: "cluster1",
 			Data:        map[string]interface{}{"status": "healthy", "count": 3},
@@ -88,7 +88,7 @@ func Example_wideMode() {
 	)
 
 	// Create results
-	results := []executor.Result{
+	results := []executor.Result[any]{
 		{
 			ClusterName: "cluster1",
 			Data:        "Successfully deployed",
@@ -117,7 +117,7 @@ func Example_noHeaders() {
 	)
 
 	// Create results
-	results := []executor.Result{
+	results := []executor.Result[any]{
 		{
 			ClusterName: "cluster1",
 			Data:        nil,
@@ -137,7 +137,7 @@ func Example_colorOutput() {
 	formatter := output.NewFormatter(output.FormatTable)
 
 	// Create results with successes and failures
-	results := []executor.Result{
+	results := []executor.Result[any]{
 		{
 			ClusterName: "production",
 			Data:        "healthy",
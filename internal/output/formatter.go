@@ -16,6 +16,8 @@ const (
 	FormatJSON Format = "json"
 	// FormatYAML outputs data in YAML format
 	FormatYAML Format = "yaml"
+	// FormatNDJSON outputs data as newline-delimited JSON, one object per line
+	FormatNDJSON Format = "ndjson"
 )
 
 // Formatter defines the interface for output formatting
@@ -25,7 +27,7 @@ type Formatter interface {
 	Format(w io.Writer, data interface{}) error
 
 	// FormatMultiCluster outputs multiple cluster results to the writer
-	FormatMultiCluster(w io.Writer, results []executor.Result) error
+	FormatMultiCluster(w io.Writer, results []executor.Result[any]) error
 }
 
 // Option is a functional option for configuring formatters
@@ -41,6 +43,10 @@ type Options struct {
 
 	// Wide enables wide output with additional columns
 	Wide bool
+
+	// Columns makes a streamed table show the items in each result's data
+	// instead of a row per cluster
+	Columns *Columns
 }
 
 // WithNoColor disables color output
@@ -64,6 +70,13 @@ func WithWide(wide bool) Option {
 	}
 }
 
+// WithColumns makes a streamed table write a row per item of each result's data
+func WithColumns(columns *Columns) Option {
+	return func(o *Options) {
+		o.Columns = columns
+	}
+}
+
 // NewFormatter creates a new formatter based on the specified format
 func NewFormatter(format Format, opts ...Option) Formatter {
 	options := &Options{}
@@ -76,9 +89,34 @@ func NewFormatter(format Format, opts ...Option) Formatter {
 		return NewJSONFormatter(options)
 	case FormatYAML:
 		return NewYAMLFormatter(options)
+	case FormatNDJSON:
+		return NewNDJSONFormatter(options)
 	case FormatTable:
 		fallthrough
 	default:
 		return NewTableFormatter(options)
 	}
 }
+
+// resultItem converts a cluster result to the structure used by the JSON,
+// YAML and NDJSON formatters
+func resultItem(result executor.Result[any]) map[string]interface{} {
+	item := map[string]interface{}{
+		"cluster":  result.ClusterName,
+		"duration": result.Duration.String(),
+	}
+
+	if result.Error != nil {
+		// Failures that are not plain errors keep their own status
+		item["status"] = "failed"
+		if result.Status != "" && result.Status != executor.StatusFailed {
+			item["status"] = string(result.Status)
+		}
+		item["error"] = result.Error.Error()
+	} else {
+		item["status"] = "success"
+		item["data"] = result.Data
+	}
+
+	return item
+}
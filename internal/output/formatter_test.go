@@ -35,6 +35,12 @@ func TestNewFormatter(t *testing.T) {
 			opts:         nil,
 			expectedType: "*output.YAMLFormatter",
 		},
+		{
+			name:         "ndjson formatter",
+			format:       FormatNDJSON,
+			opts:         nil,
+			expectedType: "*output.NDJSONFormatter",
+		},
 		{
 			name:         "empty format defaults to table",
 			format:       "",
@@ -95,6 +101,10 @@ func TestNewFormatter(t *testing.T) {
 				if _, ok := formatter.(*YAMLFormatter); !ok {
 					t.Errorf("expected YAMLFormatter, got %T", formatter)
 				}
+			case "*output.NDJSONFormatter":
+				if _, ok := formatter.(*NDJSONFormatter); !ok {
+					t.Errorf("expected NDJSONFormatter, got %T", formatter)
+				}
 			}
 		})
 	}
@@ -179,7 +189,7 @@ func TestFormatter_FormatAndFormatMultiCluster(t *testing.T) {
 		"value": 123,
 	}
 
-	results := []executor.Result{
+	results := []executor.Result[any]{
 		{
 			ClusterName: "cluster1",
 			Data:        map[string]string{"key": "value1"},
@@ -194,7 +204,7 @@ func TestFormatter_FormatAndFormatMultiCluster(t *testing.T) {
 		},
 	}
 
-	formats := []Format{FormatTable, FormatJSON, FormatYAML}
+	formats := []Format{FormatTable, FormatJSON, FormatYAML, FormatNDJSON}
 
 	for _, format := range formats {
 		t.Run(string(format), func(t *testing.T) {
@@ -229,7 +239,7 @@ func TestFormatter_FormatAndFormatMultiCluster(t *testing.T) {
 			// Test FormatMultiCluster with empty results
 			t.Run("FormatMultiCluster empty", func(t *testing.T) {
 				var buf bytes.Buffer
-				err := formatter.FormatMultiCluster(&buf, []executor.Result{})
+				err := formatter.FormatMultiCluster(&buf, []executor.Result[any]{})
 				if err != nil {
 					t.Errorf("FormatMultiCluster() error = %v", err)
 				}
@@ -150,13 +150,13 @@ func TestJSONFormatter_Format(t *testing.T) {
 func TestJSONFormatter_FormatMultiCluster(t *testing.T) {
 	tests := []struct {
 		name      string
-		results   []executor.Result
+		results   []executor.Result[any]
 		wantError bool
 		validate  func(t *testing.T, output string)
 	}{
 		{
 			name: "successful results",
-			results: []executor.Result{
+			results: []executor.Result[any]{
 				{
 					ClusterName: "cluster1",
 					Data:        map[string]string{"key": "value1"},
@@ -199,7 +199,7 @@ func TestJSONFormatter_FormatMultiCluster(t *testing.T) {
 		},
 		{
 			name: "failed results",
-			results: []executor.Result{
+			results: []executor.Result[any]{
 				{
 					ClusterName: "cluster1",
 					Data:        nil,
@@ -230,7 +230,7 @@ func TestJSONFormatter_FormatMultiCluster(t *testing.T) {
 		},
 		{
 			name:      "empty results",
-			results:   []executor.Result{},
+			results:   []executor.Result[any]{},
 			wantError: false,
 			validate: func(t *testing.T, output string) {
 				var result []map[string]interface{}
@@ -246,7 +246,7 @@ func TestJSONFormatter_FormatMultiCluster(t *testing.T) {
 		},
 		{
 			name: "mixed results",
-			results: []executor.Result{
+			results: []executor.Result[any]{
 				{
 					ClusterName: "cluster1",
 					Data:        "success data",
@@ -283,7 +283,7 @@ func TestJSONFormatter_FormatMultiCluster(t *testing.T) {
 		},
 		{
 			name: "duration formatting",
-			results: []executor.Result{
+			results: []executor.Result[any]{
 				{
 					ClusterName: "cluster1",
 					Data:        nil,
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/aryankumar/fleet/internal/executor"
+)
+
+// NDJSONFormatter formats output as newline-delimited JSON
+// Every record is written as soon as it is formatted, which makes it the
+// format of choice for streaming results into tools like jq.
+type NDJSONFormatter struct {
+	options *Options
+}
+
+// NewNDJSONFormatter creates a new NDJSON formatter
+func NewNDJSONFormatter(opts *Options) *NDJSONFormatter {
+	if opts == nil {
+		opts = &Options{}
+	}
+	return &NDJSONFormatter{
+		options: opts,
+	}
+}
+
+// Format outputs data as NDJSON; slices and arrays are written one element
+// per line
+func (f *NDJSONFormatter) Format(w io.Writer, data interface{}) error {
+	encoder := json.NewEncoder(w)
+
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return encoder.Encode(data)
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := encoder.Encode(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FormatMultiCluster outputs one line per cluster result
+func (f *NDJSONFormatter) FormatMultiCluster(w io.Writer, results []executor.Result[any]) error {
+	for _, result := range results {
+		if err := f.WriteResult(w, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteResult outputs a single cluster result as one line
+func (f *NDJSONFormatter) WriteResult(w io.Writer, result executor.Result[any]) error {
+	return json.NewEncoder(w).Encode(resultItem(result))
+}
+
+// Finish is a no-op; every NDJSON line is complete on its own
+func (f *NDJSONFormatter) Finish(w io.Writer) error {
+	return nil
+}
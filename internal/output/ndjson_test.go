@@ -0,0 +1,71 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/executor"
+)
+
+func TestNDJSONFormatter_Format(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      interface{}
+		wantLines int
+	}{
+		{name: "single object", data: map[string]interface{}{"name": "web"}, wantLines: 1},
+		{name: "slice", data: []map[string]string{{"name": "web"}, {"name": "db"}}, wantLines: 2},
+		{name: "empty slice", data: []string{}, wantLines: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewNDJSONFormatter(nil).Format(&buf, tt.data); err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			if buf.Len() == 0 {
+				lines = nil
+			}
+			if len(lines) != tt.wantLines {
+				t.Fatalf("got %d lines, want %d:\n%s", len(lines), tt.wantLines, buf.String())
+			}
+			for _, line := range lines {
+				if !json.Valid([]byte(line)) {
+					t.Errorf("line %q is not valid JSON", line)
+				}
+			}
+		})
+	}
+}
+
+func TestNDJSONFormatter_FormatMultiCluster(t *testing.T) {
+	results := []executor.Result[any]{
+		{ClusterName: "cluster1", Data: "ok", Duration: time.Second},
+		{ClusterName: "cluster2", Error: errors.New("connection refused")},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatter(FormatNDJSON).FormatMultiCluster(&buf, results); err != nil {
+		t.Fatalf("FormatMultiCluster() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want one per result:\n%s", len(lines), buf.String())
+	}
+
+	var item map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &item); err != nil {
+		t.Fatalf("line %q: %v", lines[1], err)
+	}
+	if item["cluster"] != "cluster2" || item["status"] != "failed" || item["error"] != "connection refused" {
+		t.Errorf("item = %v, want the failed cluster2 result", item)
+	}
+}
@@ -0,0 +1,181 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aryankumar/fleet/internal/executor"
+)
+
+// streamColumnWidth is the minimum width of a streamed table column
+// Rows are flushed one at a time, so columns can't be sized to fit every row.
+const streamColumnWidth = 16
+
+// StreamFormatter renders cluster results one at a time as they arrive,
+// typically from executor.Pool.ExecuteStream
+type StreamFormatter interface {
+	// WriteResult outputs a single cluster result
+	WriteResult(w io.Writer, result executor.Result[any]) error
+
+	// Finish completes the output once every result has been written
+	Finish(w io.Writer) error
+}
+
+// NewStreamFormatter creates a stream formatter for the specified format
+// Table rows and NDJSON lines are written as results arrive; JSON and YAML
+// can only be valid once complete, so they are buffered and written by Finish.
+func NewStreamFormatter(format Format, opts ...Option) StreamFormatter {
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	switch format {
+	case FormatNDJSON:
+		return NewNDJSONFormatter(options)
+	case FormatJSON:
+		return &bufferedStream{formatter: NewJSONFormatter(options)}
+	case FormatYAML:
+		return &bufferedStream{formatter: NewYAMLFormatter(options)}
+	case FormatTable:
+		fallthrough
+	default:
+		return NewTableFormatter(options).Stream()
+	}
+}
+
+// Columns lays out a streamed table of the items that clusters return, such
+// as pods, rather than of the clusters themselves
+type Columns struct {
+	// Headers are the column titles
+	Headers []string
+
+	// Rows returns the rows for a successful result's data
+	Rows func(data any, colors *ColorScheme) [][]string
+
+	// Noun names the items in the closing count, e.g. "pods"
+	Noun string
+}
+
+// ItemRows adapts a row function for one item to Columns.Rows, for results
+// whose data is a []T
+func ItemRows[T any](row func(item T, colors *ColorScheme) []string) func(data any, colors *ColorScheme) [][]string {
+	return func(data any, colors *ColorScheme) [][]string {
+		items, _ := data.([]T)
+		rows := make([][]string, 0, len(items))
+		for _, item := range items {
+			rows = append(rows, row(item, colors))
+		}
+		return rows
+	}
+}
+
+// Stream returns a stream formatter that writes a table row per result and
+// the usual summary when finished. With WithColumns it writes a row per item
+// of each successful result instead, and the number of items when finished.
+func (f *TableFormatter) Stream() StreamFormatter {
+	return &tableStream{formatter: f}
+}
+
+// tableStream writes table rows as results arrive
+type tableStream struct {
+	formatter *TableFormatter
+	colors    *ColorScheme
+	tw        *tabwriter.Writer
+	results   []executor.Result[any]
+	items     int
+}
+
+// WriteResult writes the header before the first row, then the result's rows
+func (s *tableStream) WriteResult(w io.Writer, result executor.Result[any]) error {
+	if s.colors == nil {
+		s.colors = NewColorScheme(w, s.formatter.options.NoColor)
+	}
+	s.results = append(s.results, result)
+
+	columns := s.formatter.options.Columns
+	if columns == nil {
+		s.writeRows(w, s.statusHeaders(), [][]string{s.formatter.formatResultRow(result, s.colors)})
+		return s.tw.Flush()
+	}
+
+	if result.Error != nil {
+		return nil
+	}
+	rows := columns.Rows(result.Data, s.colors)
+	if len(rows) == 0 {
+		return nil
+	}
+	s.items += len(rows)
+	s.writeRows(w, columns.Headers, rows)
+	return s.tw.Flush()
+}
+
+// statusHeaders returns the headers of a table with a row per cluster
+func (s *tableStream) statusHeaders() []string {
+	headers := []string{"CLUSTER", "STATUS", "DURATION"}
+	if s.formatter.options.Wide {
+		headers = append(headers, "DATA")
+	}
+	return headers
+}
+
+// writeRows writes rows, preceded by headers if nothing was written yet
+func (s *tableStream) writeRows(w io.Writer, headers []string, rows [][]string) {
+	if s.tw == nil {
+		s.tw = tabwriter.NewWriter(w, streamColumnWidth, 8, 3, ' ', 0)
+
+		if !s.formatter.options.NoHeaders {
+			colored := make([]string, len(headers))
+			for i, h := range headers {
+				colored[i] = s.colors.Header(h)
+			}
+			fmt.Fprintln(s.tw, strings.Join(colored, "\t"))
+		}
+	}
+
+	for _, row := range rows {
+		fmt.Fprintln(s.tw, strings.Join(row, "\t"))
+	}
+}
+
+// Finish prints the summary of every streamed result, or the item count
+// with WithColumns
+func (s *tableStream) Finish(w io.Writer) error {
+	if columns := s.formatter.options.Columns; columns != nil {
+		if s.items == 0 {
+			fmt.Fprintf(w, "No %s found\n", columns.Noun)
+			return nil
+		}
+		fmt.Fprintf(w, "\nTotal: %d %s\n", s.items, columns.Noun)
+		return nil
+	}
+
+	if len(s.results) == 0 {
+		fmt.Fprintln(w, "No results")
+		return nil
+	}
+
+	s.formatter.printSummary(w, s.results, s.colors)
+	return nil
+}
+
+// bufferedStream collects results for formats that can't be written
+// incrementally
+type bufferedStream struct {
+	formatter Formatter
+	results   []executor.Result[any]
+}
+
+// WriteResult stores the result until Finish
+func (s *bufferedStream) WriteResult(w io.Writer, result executor.Result[any]) error {
+	s.results = append(s.results, result)
+	return nil
+}
+
+// Finish writes every collected result
+func (s *bufferedStream) Finish(w io.Writer) error {
+	return s.formatter.FormatMultiCluster(w, s.results)
+}
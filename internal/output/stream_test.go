@@ -0,0 +1,110 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aryankumar/fleet/internal/executor"
+)
+
+func TestTableStream(t *testing.T) {
+	var buf bytes.Buffer
+	stream := NewStreamFormatter(FormatTable, WithNoColor(true))
+
+	if err := stream.WriteResult(&buf, executor.Result[any]{ClusterName: "fast", Duration: time.Second}); err != nil {
+		t.Fatalf("WriteResult() error = %v", err)
+	}
+
+	// The first row is written before any other result arrives
+	first := buf.String()
+	if !strings.Contains(first, "CLUSTER") || !strings.Contains(first, "fast") {
+		t.Fatalf("output after first result = %q, want the header and its row", first)
+	}
+
+	stream.WriteResult(&buf, executor.Result[any]{ClusterName: "slow", Error: errors.New("timeout")})
+	stream.WriteResult(&buf, executor.Result[any]{ClusterName: "never", Error: executor.ErrSkipped, Status: executor.StatusSkipped})
+	if err := stream.Finish(&buf); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+
+	out := buf.String()
+	if strings.Count(out, "CLUSTER") != 1 {
+		t.Errorf("expected the header once:\n%s", out)
+	}
+	if !strings.Contains(out, "slow") || !strings.Contains(out, "Failed") {
+		t.Errorf("expected the failed row:\n%s", out)
+	}
+	if !strings.Contains(out, "never") || !strings.Contains(out, "Skipped") {
+		t.Errorf("expected the skipped row:\n%s", out)
+	}
+	if !strings.Contains(out, "Summary: 1 successful, 1 failed, 1 skipped") {
+		t.Errorf("expected the summary at the end:\n%s", out)
+	}
+}
+
+func TestTableStream_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewStreamFormatter(FormatTable, WithNoColor(true)).Finish(&buf); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "No results" {
+		t.Errorf("output = %q, want No results", buf.String())
+	}
+}
+
+func TestTableStream_Columns(t *testing.T) {
+	var buf bytes.Buffer
+	stream := NewStreamFormatter(FormatTable, WithNoColor(true), WithColumns(&Columns{
+		Headers: []string{"CLUSTER", "NAME"},
+		Rows: ItemRows(func(name string, _ *ColorScheme) []string {
+			return []string{"cluster1", name}
+		}),
+		Noun: "pods",
+	}))
+
+	stream.WriteResult(&buf, executor.Result[any]{ClusterName: "cluster2", Error: errors.New("forbidden")})
+	if buf.Len() != 0 {
+		t.Fatalf("output after a failed result = %q, want nothing", buf.String())
+	}
+
+	stream.WriteResult(&buf, executor.Result[any]{ClusterName: "cluster1", Data: []string{"web", "db"}})
+	first := buf.String()
+	if !strings.HasPrefix(first, "CLUSTER") || !strings.Contains(first, "web") || !strings.Contains(first, "db") {
+		t.Fatalf("output after first result = %q, want the header and a row per item", first)
+	}
+
+	if err := stream.Finish(&buf); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), "\nTotal: 2 pods\n") {
+		t.Errorf("output =\n%s\nwant the item count at the end", buf.String())
+	}
+
+	buf.Reset()
+	empty := NewStreamFormatter(FormatTable, WithColumns(&Columns{Headers: []string{"NAME"}, Rows: ItemRows(func(string, *ColorScheme) []string { return nil }), Noun: "pods"}))
+	empty.WriteResult(&buf, executor.Result[any]{ClusterName: "cluster1", Data: []string{}})
+	empty.Finish(&buf)
+	if buf.String() != "No pods found\n" {
+		t.Errorf("output = %q, want No pods found", buf.String())
+	}
+}
+
+func TestNewStreamFormatter_Buffered(t *testing.T) {
+	var buf bytes.Buffer
+	stream := NewStreamFormatter(FormatJSON)
+
+	stream.WriteResult(&buf, executor.Result[any]{ClusterName: "cluster1", Data: "ok"})
+	if buf.Len() != 0 {
+		t.Fatalf("JSON written before Finish: %q", buf.String())
+	}
+
+	if err := stream.Finish(&buf); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(buf.String()), "[") || !strings.Contains(buf.String(), "cluster1") {
+		t.Errorf("output = %q, want a JSON array of the results", buf.String())
+	}
+}
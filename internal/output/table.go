@@ -45,7 +45,7 @@ func (f *TableFormatter) Format(w io.Writer, data interface{}) error {
 }
 
 // FormatMultiCluster outputs multiple cluster results as a table
-func (f *TableFormatter) FormatMultiCluster(w io.Writer, results []executor.Result) error {
+func (f *TableFormatter) FormatMultiCluster(w io.Writer, results []executor.Result[any]) error {
 	if len(results) == 0 {
 		fmt.Fprintln(w, "No results")
 		return nil
@@ -90,7 +90,7 @@ func (f *TableFormatter) FormatMultiCluster(w io.Writer, results []executor.Resu
 }
 
 // formatResultRow formats a single result as a table row
-func (f *TableFormatter) formatResultRow(result executor.Result, colors *ColorScheme) []string {
+func (f *TableFormatter) formatResultRow(result executor.Result[any], colors *ColorScheme) []string {
 	// Cluster name
 	clusterName := result.ClusterName
 	if !colors.Disabled {
@@ -105,6 +105,16 @@ func (f *TableFormatter) formatResultRow(result executor.Result, colors *ColorSc
 	if !colors.Disabled {
 		status = colors.StatusColor(result.Error != nil)(status)
 	}
+	switch result.Status {
+	case executor.StatusTimedOut:
+		status = colors.Error("Timed out")
+	case executor.StatusCancelled:
+		status = colors.Warning("Cancelled")
+	case executor.StatusNotStarted:
+		status = colors.Warning("Not started")
+	case executor.StatusSkipped:
+		status = colors.Warning("Skipped")
+	}
 
 	// Duration
 	duration := result.Duration.String()
@@ -197,7 +207,7 @@ func (f *TableFormatter) createTable(w io.Writer) *tablewriter.Table {
 }
 
 // printSummary prints a summary of the results
-func (f *TableFormatter) printSummary(w io.Writer, results []executor.Result, colors *ColorScheme) {
+func (f *TableFormatter) printSummary(w io.Writer, results []executor.Result[any], colors *ColorScheme) {
 	summary := executor.Summarize(results)
 
 	fmt.Fprintln(w, "")
@@ -218,5 +228,9 @@ func (f *TableFormatter) printSummary(w io.Writer, results []executor.Result, co
 		durationText = colors.Duration(durationText)
 	}
 
+	if summary.Skipped > 0 {
+		failedText += ", " + colors.Warning("%d skipped", summary.Skipped)
+	}
+
 	fmt.Fprintf(w, "%s, %s, %s\n", successText, failedText, durationText)
 }
@@ -114,7 +114,7 @@ func TestTableFormatter_Format(t *testing.T) {
 func TestTableFormatter_FormatMultiCluster(t *testing.T) {
 	tests := []struct {
 		name      string
-		results   []executor.Result
+		results   []executor.Result[any]
 		opts      *Options
 		wantError bool
 		contains  []string
@@ -122,7 +122,7 @@ func TestTableFormatter_FormatMultiCluster(t *testing.T) {
 	}{
 		{
 			name: "successful results",
-			results: []executor.Result{
+			results: []executor.Result[any]{
 				{
 					ClusterName: "cluster1",
 					Data:        map[string]string{"key": "value1"},
@@ -142,7 +142,7 @@ func TestTableFormatter_FormatMultiCluster(t *testing.T) {
 		},
 		{
 			name: "mixed results",
-			results: []executor.Result{
+			results: []executor.Result[any]{
 				{
 					ClusterName: "cluster1",
 					Data:        "success data",
@@ -162,14 +162,14 @@ func TestTableFormatter_FormatMultiCluster(t *testing.T) {
 		},
 		{
 			name:      "empty results",
-			results:   []executor.Result{},
+			results:   []executor.Result[any]{},
 			opts:      &Options{NoColor: true},
 			wantError: false,
 			contains:  []string{"No results"},
 		},
 		{
 			name: "wide mode",
-			results: []executor.Result{
+			results: []executor.Result[any]{
 				{
 					ClusterName: "cluster1",
 					Data:        "test data",
@@ -183,7 +183,7 @@ func TestTableFormatter_FormatMultiCluster(t *testing.T) {
 		},
 		{
 			name: "wide mode with error",
-			results: []executor.Result{
+			results: []executor.Result[any]{
 				{
 					ClusterName: "cluster1",
 					Data:        nil,
@@ -197,7 +197,7 @@ func TestTableFormatter_FormatMultiCluster(t *testing.T) {
 		},
 		{
 			name: "no headers mode",
-			results: []executor.Result{
+			results: []executor.Result[any]{
 				{
 					ClusterName: "cluster1",
 					Data:        "data",
@@ -212,7 +212,7 @@ func TestTableFormatter_FormatMultiCluster(t *testing.T) {
 		},
 		{
 			name: "wide mode with long data",
-			results: []executor.Result{
+			results: []executor.Result[any]{
 				{
 					ClusterName: "cluster1",
 					Data:        "this is a very long data string that should be truncated when displayed in the table",
@@ -284,13 +284,13 @@ func TestTableFormatter_FormatResultRow(t *testing.T) {
 
 	tests := []struct {
 		name           string
-		result         executor.Result
+		result         executor.Result[any]
 		wide           bool
 		checkPositions map[int]string // position -> expected substring
 	}{
 		{
 			name: "success result",
-			result: executor.Result{
+			result: executor.Result[any]{
 				ClusterName: "cluster1",
 				Data:        "test data",
 				Error:       nil,
@@ -304,7 +304,7 @@ func TestTableFormatter_FormatResultRow(t *testing.T) {
 		},
 		{
 			name: "error result",
-			result: executor.Result{
+			result: executor.Result[any]{
 				ClusterName: "cluster2",
 				Data:        nil,
 				Error:       errors.New("failed"),
@@ -318,7 +318,7 @@ func TestTableFormatter_FormatResultRow(t *testing.T) {
 		},
 		{
 			name: "wide mode with data",
-			result: executor.Result{
+			result: executor.Result[any]{
 				ClusterName: "cluster3",
 				Data:        "some data",
 				Error:       nil,
@@ -333,7 +333,7 @@ func TestTableFormatter_FormatResultRow(t *testing.T) {
 		},
 		{
 			name: "wide mode with error",
-			result: executor.Result{
+			result: executor.Result[any]{
 				ClusterName: "cluster4",
 				Data:        nil,
 				Error:       errors.New("connection error"),
@@ -32,25 +32,12 @@ func (f *YAMLFormatter) Format(w io.Writer, data interface{}) error {
 }
 
 // FormatMultiCluster outputs multiple cluster results as YAML
-func (f *YAMLFormatter) FormatMultiCluster(w io.Writer, results []executor.Result) error {
+func (f *YAMLFormatter) FormatMultiCluster(w io.Writer, results []executor.Result[any]) error {
 	// Convert results to a more YAML-friendly structure
 	output := make([]map[string]interface{}, len(results))
 
 	for i, result := range results {
-		item := map[string]interface{}{
-			"cluster":  result.ClusterName,
-			"duration": result.Duration.String(),
-		}
-
-		if result.Error != nil {
-			item["status"] = "failed"
-			item["error"] = result.Error.Error()
-		} else {
-			item["status"] = "success"
-			item["data"] = result.Data
-		}
-
-		output[i] = item
+		output[i] = resultItem(result)
 	}
 
 	encoder := yaml.NewEncoder(w)